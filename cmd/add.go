@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/kajvans/foundry/internal/backup"
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/generate"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/org"
+	"github.com/kajvans/foundry/internal/project"
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/spf13/cobra"
+)
+
+// addCmd represents the add command
+var addCmd = &cobra.Command{
+	Use:     "add <component>...",
+	Aliases: []string{"generate"},
+	Short:   "Add optional components to an existing Foundry project",
+	Long: `Add generated components, such as database services or tool configs, to a project
+created by Foundry.
+
+The project's language and name are read from its .foundry/stamp.yaml, so client
+boilerplate matches the project. Files that already exist are left untouched, except
+docker-compose.yml and .env.example, which are merged.
+
+With --stdout a single-file component (e.g. dockerfile, makefile) is printed instead
+of written, so it can be used in pipes and Makefiles.`,
+	Example: `  foundry add postgres
+  foundry add redis sqlite --path ./my-api
+  foundry add makefile --language Go
+  foundry add k8s
+  foundry generate dockerfile --stdout > Dockerfile.dev`,
+	Args: cobra.MinimumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return generate.Names(), cobra.ShellCompDirectiveNoFileComp
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		projectDir, _ := cmd.Flags().GetString("path")
+		language, _ := cmd.Flags().GetString("language")
+		toStdout, _ := cmd.Flags().GetBool("stdout")
+		if toStdout && len(args) != 1 {
+			exitWithError("--stdout renders exactly one component")
+		}
+
+		st, err := stamp.Load(projectDir)
+		if err != nil {
+			exitWithError("Error reading project stamp: %v", err)
+		}
+		if st == nil {
+			if language == "" {
+				exitWithError("%s is not a Foundry project (no %s)\nPass --language to add components anyway", projectDir, stamp.Path(projectDir))
+			}
+			absDir, _ := filepath.Abs(projectDir)
+			st = &stamp.Stamp{ProjectName: filepath.Base(absDir), Language: language}
+		}
+		if language == "" {
+			language = st.Language
+		}
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			exitWithError("Error loading config: %v", err)
+		}
+
+		ctx := &generate.Context{
+			ProjectName: st.ProjectName,
+			ProjectDir:  projectDir,
+			Language:    language,
+			Framework:   st.Framework,
+			Version:     st.Variables["VERSION"],
+			Docker:      cfg.Docker,
+			Author:      cfg.Author,
+			License:     cfg.License,
+			Versions:    generate.ResolveVersions(language, nil),
+			Variables:   st.Variables,
+			Tools:       cfg.InstalledDevTools,
+			Backup:      backup.New(projectDir),
+		}
+		if orgCfg, err := org.Load(cfg); err != nil {
+			color.Yellow(i18n.T("⚠ %v"), err)
+		} else {
+			ctx.DependencyUpdates = orgCfg.DependencyUpdates
+		}
+
+		if toStdout {
+			content, err := generate.Render(args[0], ctx)
+			if err != nil {
+				exitWithError("%v", err)
+			}
+			fmt.Print(content)
+			return
+		}
+
+		var added []string
+		var created, updated, unchanged int
+		for _, name := range args {
+			result, err := generate.Run(name, ctx)
+			if err != nil {
+				color.Red(i18n.T("✗ %v"), err)
+				continue
+			}
+			added = append(added, name)
+			created += len(result.Created)
+			updated += len(result.Updated)
+			unchanged += len(result.Unchanged)
+			if err := st.RecordFiles(projectDir, append(result.Written(), result.Unchanged...)...); err != nil {
+				color.Yellow(i18n.T("⚠ %v"), err)
+			}
+
+			written := result.Written()
+			switch {
+			case len(written) > 0:
+				color.Green(i18n.T("✓ %s: %s"), name, strings.Join(written, ", "))
+			case len(result.Skipped) > 0:
+				color.Yellow(i18n.T("⚠ %s: all files already exist, nothing written"), name)
+			default:
+				color.Green(i18n.T("✓ %s: already up to date"), name)
+			}
+		}
+
+		if len(added) == 0 {
+			exitWithError("No components were added")
+		}
+		st.AddComponents(added...)
+		if err := stamp.Save(projectDir, st); err != nil {
+			color.Yellow(i18n.T("⚠ Failed to update project stamp: %v"), err)
+		}
+		writeSBOM(projectDir, st)
+		if err := project.SaveBase(projectDir, projectDir, st.Files); err != nil {
+			color.Yellow(i18n.T("⚠ Failed to save merge base: %v"), err)
+		}
+		printBackup(ctx.Backup)
+		color.Cyan(i18n.T("\n%d created, %d updated, %d unchanged"), created, updated, unchanged)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+
+	addCmd.Flags().StringP("path", "p", ".", "Project directory to add components to")
+	addCmd.Flags().StringP("language", "l", "", "Override the language recorded in the project stamp")
+	addCmd.Flags().Bool("stdout", false, "Print a single-file component to stdout instead of writing it")
+
+	addCmd.Long += fmt.Sprintf("\n\nAvailable components: %s", strings.Join(generate.Names(), ", "))
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// valueFlags are the global flags that take a value, so the word after them is not the command
+var valueFlags = map[string]bool{"--config": true}
+
+// configOverride returns the --config path given in args, if any, so aliases are read from
+// the same config the command will use
+func configOverride(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, "--config="); ok {
+			return value
+		}
+		if arg == "--config" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// expandAlias replaces a command alias in args (without the program name) with the command
+// line it stands for. Built-in commands always win over aliases, and aliases may expand to
+// other aliases, as in git.
+func expandAlias(args []string, aliases map[string]string) ([]string, error) {
+	if len(aliases) == 0 {
+		return args, nil
+	}
+
+	// The command is the first word that is neither a global flag nor its value
+	pos := -1
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return args, nil
+		}
+		if strings.HasPrefix(arg, "-") {
+			if valueFlags[arg] {
+				i++
+			}
+			continue
+		}
+		pos = i
+		break
+	}
+	if pos < 0 {
+		return args, nil
+	}
+
+	seen := map[string]bool{}
+	for {
+		name := args[pos]
+		expansion, ok := aliases[name]
+		if !ok || isBuiltinCommand(name) {
+			return args, nil
+		}
+		if seen[name] {
+			return nil, i18n.Errorf("alias '%s' expands back to itself through other aliases", name)
+		}
+		seen[name] = true
+
+		words, err := utils.SplitArgs(expansion)
+		if err != nil {
+			return nil, i18n.Errorf("alias '%s': %w", name, err)
+		}
+		if len(words) == 0 {
+			return nil, i18n.Errorf("alias '%s' is empty", name)
+		}
+		args = append(append(append([]string{}, args[:pos]...), words...), args[pos+1:]...)
+	}
+}
+
+// isBuiltinCommand reports whether name is one of Foundry's own commands or their aliases
+func isBuiltinCommand(name string) bool {
+	switch name {
+	case "help", "completion", "__complete", "__completeNoDesc":
+		return true
+	}
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateAlias checks an alias can be defined: a single word that does not hide a built-in command
+func validateAlias(name, expansion string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t=") {
+		return i18n.Errorf("invalid alias name '%s'", name)
+	}
+	if isBuiltinCommand(name) {
+		return i18n.Errorf("'%s' is a built-in command and cannot be an alias", name)
+	}
+	if expansion == "" {
+		return nil
+	}
+	words, err := utils.SplitArgs(expansion)
+	if err != nil {
+		return err
+	}
+	if len(words) == 0 {
+		return i18n.Errorf("alias '%s' is empty", name)
+	}
+	return nil
+}
+
+// resolveAliases expands a command alias from the user config in args (without the program name).
+// Without a readable config the arguments are returned unchanged.
+func resolveAliases(args []string) ([]string, error) {
+	if path := configOverride(args); path != "" {
+		config.SetConfigPathOverride(path)
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return args, nil
+	}
+	return expandAlias(args, cfg.Aliases)
+}
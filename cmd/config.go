@@ -3,16 +3,22 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/fatih/color"
 	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/lang"
+	"github.com/kajvans/foundry/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 // configCmd represents the config command
 var configCmd = &cobra.Command{
-	Use:   "config [language] [template]",
+	Use:   "config [language] [template...]",
 	Short: "View or update configuration settings",
 	Long: `View or update Foundry configuration.
 
@@ -22,20 +28,50 @@ You can set specific values directly via flags:
   --license <type>           Set the license (MIT, Apache, etc.)
   --default-language <l>     Set the default language for new projects
   --clear-default <lang>     Clear default template for a specific language
+  --add-fallback <template>  Append a fallback default for the given language
+  --remove-default <tmpl>    Remove a template from the given language's defaults
   --docker                   Enable Dockerfile generation
   --interactive              Enable interactive mode for project creation
+  --project-root <dir>       Directory suggested when completing 'new --path' (repeatable)
+  --package-managers <e=pm>  Preferred package managers per ecosystem, e.g. javascript=pnpm,yarn,npm
+  --clone-depth <n>          History depth for 'new --git' clones (default 1)
+  --org-config <path|url>    Organization config with shared bundles (empty clears)
+  --template-index <url>     Template index 'template browse' reads (empty clears)
+  --strict-vars              Make 'new --strict-vars' the default
+  --global-gitignore         Leave files matched by your global gitignore out of templates
+  --keep-junk-files          Copy .DS_Store, Thumbs.db and the like into new projects
+  --follow-symlinks          Copy what template symlinks point to instead of the links
+  --binary-ext <ext,...>     Extensions never rendered, besides .png, .pdf, .woff2, ... (empty clears)
+  --text-ext <ext,...>       Extensions always rendered as text, e.g. .svg (empty clears)
+  --line-endings <mode>      Line endings of rendered text files: lf, crlf or auto (empty keeps them)
+  --hook-allow <prog,...>    Programs template hooks may run (empty allows any not denied)
+  --hook-deny <prog,...>     Programs template hooks may never run
+  --hook-container           Run template hooks in a container (docker or podman)
+  --hook-image <image>       Container image for hooks (default: the language's image)
+  --locale <en|nl>           Language of Foundry's messages (empty follows LANG)
+  --alias <name=command>     Define a command alias, e.g. web="new --template react-starter" (empty removes)
   --view                     Show current configuration settings
 
 To set a default template for a language, use positional arguments:
-  foundry config <language> <template-name>
+  foundry config <language> <template-name> [fallback...]
+
+Extra template names are ranked fallbacks: 'foundry new --language' uses the
+first one that still exists, so removing a template falls back to the next.
 `,
 	Example: `  foundry config --user "John" --docker
   foundry config --license Apache
   foundry config Go my-go-template
   foundry config Python flask-starter
+  foundry config Go go-service go-minimal
+  foundry config Go --add-fallback go-minimal
+  foundry config Go --remove-default go-service
   foundry config --clear-default Go
+  foundry config --package-managers javascript=pnpm,npm --package-managers python=uv,pip
+  foundry config --hook-allow go,npm,git --hook-deny curl,wget
+  foundry config --alias web="new --template react-starter --with dockerfile"
+  foundry config --alias web=
   foundry config --view`,
-	Args: cobra.MaximumNArgs(2),
+	Args: cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		config.PrintConfig()
 	},
@@ -43,11 +79,13 @@ To set a default template for a language, use positional arguments:
 
 func init() {
 	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configUndoCmd)
+	configCmd.AddCommand(configHistoryCmd)
 
 	// Load current config
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+		fmt.Fprintf(os.Stderr, i18n.T("Warning: failed to load config: %v\n"), err)
 		cfg = &config.Config{} // fallback
 	}
 
@@ -58,7 +96,27 @@ func init() {
 	configCmd.Flags().Bool("docker", cfg.Docker, "Enable Dockerfile generation")
 	configCmd.Flags().Bool("interactive", cfg.Interactive, "Enable interactive mode")
 	configCmd.Flags().Bool("view", false, "Show current configuration settings")
+	configCmd.Flags().StringArray("package-managers", []string{}, "Preferred package managers per ecosystem as ecosystem=pm1,pm2 (repeatable, empty list clears)")
+	configCmd.Flags().StringArray("project-root", cfg.ProjectRoots, "Directory suggested when completing 'new --path' (repeatable)")
+	configCmd.Flags().Int("clone-depth", cfg.CloneDepth, "History depth for 'new --git' clones (0 uses the default of 1)")
+	configCmd.Flags().String("org-config", cfg.OrgConfig, "Organization config file or http(s) URL with shared bundles (empty clears)")
+	configCmd.Flags().String("template-index", cfg.TemplateIndex, "Template index for 'template browse': URL, git repository or file (empty clears)")
+	configCmd.Flags().Bool("strict-vars", cfg.StrictVars, "Make 'new --strict-vars' the default: fail on variable collisions and unresolved placeholders")
+	configCmd.Flags().Bool("global-gitignore", cfg.GlobalGitignore, "Leave files matched by your global gitignore (core.excludesfile) out of template scans and new projects")
+	configCmd.Flags().Bool("keep-junk-files", cfg.KeepJunkFiles, "Copy OS and editor junk files (.DS_Store, Thumbs.db, desktop.ini, *.swp) into new projects")
+	configCmd.Flags().Bool("follow-symlinks", cfg.FollowSymlinks, "Copy the files and directories template symlinks point to instead of re-creating the links")
+	configCmd.Flags().StringSlice("binary-ext", cfg.BinaryExtensions, "File extensions copied without placeholder replacement, besides the built-in ones (.png, .pdf, .woff2, ...), comma-separated")
+	configCmd.Flags().StringSlice("text-ext", cfg.TextExtensions, "File extensions always rendered as text, whatever their content looks like, comma-separated")
+	configCmd.Flags().String("line-endings", cfg.LineEndings, "Line endings of rendered text files: lf, crlf or auto for the platform's own, unless the template chooses (empty keeps them as in the template)")
+	configCmd.Flags().StringSlice("hook-allow", cfg.HookPolicy.Allow, "Programs template hooks may run, comma-separated (empty allows any not denied)")
+	configCmd.Flags().StringSlice("hook-deny", cfg.HookPolicy.Deny, "Programs template hooks may never run, comma-separated")
+	configCmd.Flags().Bool("hook-container", cfg.HookPolicy.Container, "Run template hooks in a container instead of on the host")
+	configCmd.Flags().String("hook-image", cfg.HookPolicy.Image, "Container image for hooks (empty uses the language's official image)")
+	configCmd.Flags().String("locale", cfg.Locale, "Language of Foundry's messages: "+strings.Join(i18n.Locales(), ", ")+" (empty follows LANG)")
+	configCmd.Flags().StringArray("alias", []string{}, "Command alias as name=command, expanded like git aliases (repeatable, empty command removes)")
 	configCmd.Flags().String("clear-default", "", "Clear default template for a specific language")
+	configCmd.Flags().String("add-fallback", "", "Append a fallback default template for the language given as argument")
+	configCmd.Flags().String("remove-default", "", "Remove a template from the defaults of the language given as argument")
 
 	// TODO: Add a global --no-color flag (and respect NO_COLOR env) to disable colored output.
 	// TODO: Provide shell completions for <language> and <template> positional args.
@@ -72,9 +130,10 @@ func init() {
 		}
 		switch len(args) {
 		case 0:
-			// Suggest languages: unique set from templates plus some common values
-			langSet := map[string]struct{}{
-				"Go": {}, "Python": {}, "JavaScript": {}, "TypeScript": {}, "React": {}, "Rust": {}, "Java": {},
+			// Suggest languages: unique set from templates plus the language registry
+			langSet := map[string]struct{}{}
+			for _, name := range lang.Names() {
+				langSet[name] = struct{}{}
 			}
 			for _, t := range tpls {
 				if t.Language != "" {
@@ -89,7 +148,7 @@ func init() {
 			}
 			sort.Strings(langs)
 			return langs, cobra.ShellCompDirectiveNoFileComp
-		case 1:
+		default:
 			// Suggest template names
 			var names []string
 			for _, t := range tpls {
@@ -99,8 +158,6 @@ func init() {
 			}
 			sort.Strings(names)
 			return names, cobra.ShellCompDirectiveNoFileComp
-		default:
-			return nil, cobra.ShellCompDirectiveNoFileComp
 		}
 	}
 
@@ -110,29 +167,54 @@ func init() {
 			config.PrintConfig()
 			return
 		}
+		journalChanges()
 
 		changed := false
 
 		// Handle set-default with positional arguments
-		// Usage: foundry config <language> <template>
-		if len(args) == 2 {
+		// Usage: foundry config <language> <template> [fallback...]
+		if len(args) >= 2 {
 			lang := args[0]
-			tmpl := args[1]
-			if err := config.SetLanguageDefault(lang, tmpl); err != nil {
-				fmt.Fprintf(os.Stderr, "Error setting default for %s: %v\n", lang, err)
+			tmpls := args[1:]
+			if err := config.SetLanguageDefaults(lang, tmpls); err != nil {
+				fmt.Fprintf(os.Stderr, i18n.T("Error setting default for %s: %v\n"), lang, err)
 				os.Exit(1)
 			}
-			fmt.Printf("✓ Set default template for %s: %s\n", lang, tmpl)
+			fmt.Printf(i18n.T("✓ Set default template for %s: %s\n"), lang, strings.Join(tmpls, " > "))
+			changed = true
+		}
+
+		// Handle ranked fallback management for a single language argument
+		addFallback, _ := cmd.Flags().GetString("add-fallback")
+		removeDefault, _ := cmd.Flags().GetString("remove-default")
+		if (addFallback != "" || removeDefault != "") && len(args) != 1 {
+			fmt.Fprintln(os.Stderr, i18n.T("Error: --add-fallback and --remove-default require exactly one language argument"))
+			os.Exit(1)
+		}
+		if addFallback != "" {
+			if err := config.AddLanguageFallback(args[0], addFallback); err != nil {
+				fmt.Fprintf(os.Stderr, i18n.T("Error adding fallback for %s: %v\n"), args[0], err)
+				os.Exit(1)
+			}
+			fmt.Printf(i18n.T("✓ Added fallback template for %s: %s\n"), args[0], addFallback)
+			changed = true
+		}
+		if removeDefault != "" {
+			if err := config.RemoveLanguageFallback(args[0], removeDefault); err != nil {
+				fmt.Fprintf(os.Stderr, i18n.T("Error removing default for %s: %v\n"), args[0], err)
+				os.Exit(1)
+			}
+			fmt.Printf(i18n.T("✓ Removed %s from the defaults for %s\n"), removeDefault, args[0])
 			changed = true
 		}
 
 		// Handle clear-default flag
 		if clearLang, _ := cmd.Flags().GetString("clear-default"); clearLang != "" {
 			if err := config.ClearLanguageDefault(clearLang); err != nil {
-				fmt.Fprintf(os.Stderr, "Error clearing default for %s: %v\n", clearLang, err)
+				fmt.Fprintf(os.Stderr, i18n.T("Error clearing default for %s: %v\n"), clearLang, err)
 				os.Exit(1)
 			}
-			fmt.Printf("✓ Cleared default template for %s\n", clearLang)
+			fmt.Printf(i18n.T("✓ Cleared default template for %s\n"), clearLang)
 			changed = true
 		}
 
@@ -159,6 +241,145 @@ func init() {
 			config.SetConfigValue("interactive", interactive)
 			changed = true
 		}
+		if cmd.Flags().Changed("package-managers") {
+			prefs, _ := cmd.Flags().GetStringArray("package-managers")
+			for _, pref := range prefs {
+				ecosystem, list, ok := strings.Cut(pref, "=")
+				if !ok || strings.TrimSpace(ecosystem) == "" {
+					fmt.Fprintf(os.Stderr, i18n.T("Error: invalid --package-managers value '%s', expected ecosystem=pm1,pm2\n"), pref)
+					os.Exit(1)
+				}
+				var managers []string
+				for _, pm := range strings.Split(list, ",") {
+					if pm = strings.TrimSpace(pm); pm != "" {
+						managers = append(managers, pm)
+					}
+				}
+				if err := config.SetPackageManagerPreference(strings.TrimSpace(ecosystem), managers); err != nil {
+					fmt.Fprintf(os.Stderr, i18n.T("Error setting package managers for %s: %v\n"), ecosystem, err)
+					os.Exit(1)
+				}
+			}
+			changed = true
+		}
+		if cmd.Flags().Changed("clone-depth") {
+			depth, _ := cmd.Flags().GetInt("clone-depth")
+			if depth < 0 {
+				fmt.Fprintln(os.Stderr, i18n.T("Error: --clone-depth must not be negative"))
+				os.Exit(1)
+			}
+			config.SetConfigValue("clone_depth", depth)
+			changed = true
+		}
+		if cmd.Flags().Changed("org-config") {
+			location, _ := cmd.Flags().GetString("org-config")
+			if location != "" && !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+				if abs, err := filepath.Abs(location); err == nil {
+					location = abs
+				}
+			}
+			config.SetConfigValue("org_config", location)
+			changed = true
+		}
+		if cmd.Flags().Changed("template-index") {
+			location, _ := cmd.Flags().GetString("template-index")
+			if _, err := os.Stat(location); err == nil {
+				if abs, err := filepath.Abs(location); err == nil {
+					location = abs
+				}
+			}
+			config.SetConfigValue("template_index", location)
+			changed = true
+		}
+		if cmd.Flags().Changed("strict-vars") {
+			strict, _ := cmd.Flags().GetBool("strict-vars")
+			config.SetConfigValue("strict_vars", strict)
+			changed = true
+		}
+		if cmd.Flags().Changed("global-gitignore") {
+			on, _ := cmd.Flags().GetBool("global-gitignore")
+			config.SetConfigValue("global_gitignore", on)
+			changed = true
+		}
+		if cmd.Flags().Changed("keep-junk-files") {
+			keep, _ := cmd.Flags().GetBool("keep-junk-files")
+			config.SetConfigValue("keep_junk_files", keep)
+			changed = true
+		}
+		if cmd.Flags().Changed("follow-symlinks") {
+			follow, _ := cmd.Flags().GetBool("follow-symlinks")
+			config.SetConfigValue("follow_symlinks", follow)
+			changed = true
+		}
+		if cmd.Flags().Changed("binary-ext") {
+			exts, _ := cmd.Flags().GetStringSlice("binary-ext")
+			config.SetConfigValue("binary_extensions", exts)
+			changed = true
+		}
+		if cmd.Flags().Changed("text-ext") {
+			exts, _ := cmd.Flags().GetStringSlice("text-ext")
+			config.SetConfigValue("text_extensions", exts)
+			changed = true
+		}
+		if cmd.Flags().Changed("line-endings") {
+			mode, _ := cmd.Flags().GetString("line-endings")
+			mode = strings.ToLower(mode)
+			if mode != "" && !slices.Contains(utils.LineEndingModes, mode) {
+				exitWithError("Unknown line endings '%s' (use %s)", mode, strings.Join(utils.LineEndingModes, ", "))
+			}
+			config.SetConfigValue("line_endings", mode)
+			changed = true
+		}
+		if cmd.Flags().Changed("hook-allow") || cmd.Flags().Changed("hook-deny") || cmd.Flags().Changed("hook-container") || cmd.Flags().Changed("hook-image") {
+			policy := config.HookPolicy{}
+			policy.Allow, _ = cmd.Flags().GetStringSlice("hook-allow")
+			policy.Deny, _ = cmd.Flags().GetStringSlice("hook-deny")
+			policy.Container, _ = cmd.Flags().GetBool("hook-container")
+			policy.Image, _ = cmd.Flags().GetString("hook-image")
+			config.SetConfigValue("hook_policy", policy)
+			changed = true
+		}
+		if cmd.Flags().Changed("locale") {
+			locale, _ := cmd.Flags().GetString("locale")
+			locale = strings.ToLower(strings.TrimSpace(locale))
+			if locale != "" && !i18n.Supported(locale) {
+				fmt.Fprintf(os.Stderr, i18n.T("Error: unsupported locale '%s' (available: %s)\n"), locale, strings.Join(i18n.Locales(), ", "))
+				os.Exit(1)
+			}
+			config.SetConfigValue("locale", locale)
+			i18n.SetLocale(i18n.Detect(locale))
+			changed = true
+		}
+		if cmd.Flags().Changed("alias") {
+			defs, _ := cmd.Flags().GetStringArray("alias")
+			for _, def := range defs {
+				name, expansion, ok := strings.Cut(def, "=")
+				name, expansion = strings.TrimSpace(name), strings.TrimSpace(expansion)
+				if !ok {
+					fmt.Fprintf(os.Stderr, i18n.T("Error: invalid --alias value '%s', expected name=command\n"), def)
+					os.Exit(1)
+				}
+				if err := validateAlias(name, expansion); err != nil {
+					fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+					os.Exit(1)
+				}
+				if err := config.SetAlias(name, expansion); err != nil {
+					fmt.Fprintf(os.Stderr, i18n.T("Error setting alias %s: %v\n"), name, err)
+					os.Exit(1)
+				}
+			}
+			changed = true
+		}
+		if cmd.Flags().Changed("project-root") {
+			roots, _ := cmd.Flags().GetStringArray("project-root")
+			for i, root := range roots {
+				if abs, err := filepath.Abs(root); err == nil {
+					roots[i] = abs
+				}
+			}
+			config.SetConfigValue("project_roots", roots)
+			changed = true
+		}
 
 		if !changed {
 			// No updates provided; show current configuration
@@ -166,9 +387,77 @@ func init() {
 			return
 		}
 
-		fmt.Println("\nConfiguration updated. Current values:")
+		fmt.Println(i18n.T("\nConfiguration updated. Current values:"))
 		config.PrintConfig()
 	}
 
 	// Use default Cobra help which includes usage, flags, and examples
 }
+
+// journalChanges records the config before the running command changes it, so
+// foundry config undo can revert the command
+func journalChanges() {
+	config.Journal("foundry " + strings.Join(os.Args[1:], " "))
+}
+
+// configUndoCmd reverts the most recent config change
+var configUndoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert the most recent configuration change",
+	Long: `Restore the configuration as it was before the last command that changed it:
+foundry config with settings, template add, update, move, set-var and remove, and setting
+language defaults. Run it again to step further back; foundry config history
+lists what can be undone.
+
+Everything in the config file is restored, including what other commands such as
+foundry new or foundry detect recorded since.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		entry, err := config.Undo()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if entry == nil {
+			fmt.Println(i18n.T("Nothing to undo."))
+			return
+		}
+		color.Green(i18n.T("✓ Undid: %s (%s)"), entry.Command, entry.Time.Local().Format("2006-01-02 15:04:05"))
+
+		// Foundry's copy of a removed remote template is gone; fetching it again brings it back
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return
+		}
+		for _, t := range cfg.Templates {
+			if _, err := os.Stat(t.Path); os.IsNotExist(err) {
+				if t.Source != "" {
+					color.Yellow(i18n.T("⚠ Template '%s' has no files at %s; fetch them again with: foundry template update %s"), t.Name, t.Path, t.Name)
+				} else {
+					color.Yellow(i18n.T("⚠ Template '%s' has no files at %s"), t.Name, t.Path)
+				}
+			}
+		}
+	},
+}
+
+// configHistoryCmd lists the config changes that can be undone
+var configHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the configuration changes that can be undone",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		history, err := config.History()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(history) == 0 {
+			fmt.Println(i18n.T("No configuration changes recorded."))
+			return
+		}
+		for _, entry := range history {
+			fmt.Printf("%s  %s\n", entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Command)
+		}
+	},
+}
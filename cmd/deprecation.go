@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/manifest"
+)
+
+// templateDeprecation returns the template's deprecation, nil when it is not deprecated.
+// The template's own foundry.yaml is authoritative; the saved copy covers templates without one.
+func templateDeprecation(tmpl *config.Template) *manifest.Deprecation {
+	if tmpl.Path == "" {
+		return tmpl.Deprecated
+	}
+	if m, err := manifest.Load(tmpl.Path); err == nil && m != nil {
+		return m.Deprecated
+	}
+	return tmpl.Deprecated
+}
+
+// deprecationNotice describes a deprecation in one line: its message, replacement and sunset
+func deprecationNotice(d *manifest.Deprecation) string {
+	parts := []string{i18n.T("no longer recommended for new projects")}
+	if d.Message != "" {
+		parts[0] = d.Message
+	}
+	if d.Replacement != "" {
+		parts = append(parts, i18n.Sprintf("use '%s' instead", d.Replacement))
+	}
+	if d.Sunset != "" {
+		if d.PastSunset(time.Now()) {
+			parts = append(parts, i18n.Sprintf("unsupported since %s", d.Sunset))
+		} else {
+			parts = append(parts, i18n.Sprintf("sunset on %s", d.Sunset))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// checkDeprecation warns that a deprecated template is being used, or refuses it when the
+// org config blocks deprecated templates
+func checkDeprecation(tmpl *config.Template, block bool) error {
+	d := templateDeprecation(tmpl)
+	if d == nil {
+		return nil
+	}
+	if block {
+		return i18n.Errorf("template '%s' is deprecated and the org config blocks deprecated templates: %s", tmpl.Name, deprecationNotice(d))
+	}
+	color.Yellow(i18n.T("⚠ Template '%s' is deprecated: %s"), tmpl.Name, deprecationNotice(d))
+	return nil
+}
@@ -2,10 +2,11 @@ package cmd
 
 import (
 	"encoding/json"
-	"fmt"
 
 	"github.com/fatih/color"
 	"github.com/kajvans/foundry/internal/detect"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -22,9 +23,9 @@ No changes are made without your confirmation.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		jsonOut, _ := cmd.Flags().GetBool("json")
 		assumeYes, _ := cmd.Flags().GetBool("yes")
-		nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
+		nonInteractive := !canPrompt()
 
-		color.Cyan("Scanning your system...")
+		color.Cyan(i18n.T("Scanning your system..."))
 
 		// Call helper to perform detection
 		result := detect.ScanSystem()
@@ -39,23 +40,20 @@ No changes are made without your confirmation.`,
 		}
 
 		// Ask user for confirmation
-		color.Green("Detection complete. Please review the detected tools above.")
+		color.Green(i18n.T("Detection complete. Please review the detected tools above."))
 		if nonInteractive {
 			if assumeYes {
-				color.Green("Configuration saved.")
+				color.Green(i18n.T("Configuration saved."))
 				detect.SaveConfig(result)
 			}
 			return
 		}
 
-		var response string
-		color.New(color.Bold).Print("Does this look correct? (y/n): ")
-		fmt.Scanln(&response)
-		if response == "y" {
-			color.Green("Configuration saved.")
+		if ok, err := ui.Confirm(i18n.T("Does this look correct?"), false); err == nil && ok {
+			color.Green(i18n.T("Configuration saved."))
 			detect.SaveConfig(result)
 		} else {
-			color.Yellow("Please adjust configuration manually or re-run detection.")
+			color.Yellow(i18n.T("Please adjust configuration manually or re-run detection."))
 		}
 	},
 }
@@ -64,5 +62,4 @@ func init() {
 	rootCmd.AddCommand(detectCmd)
 	detectCmd.Flags().Bool("json", false, "Output results in JSON format")
 	detectCmd.Flags().Bool("yes", false, "Assume 'yes' when saving results (use with --non-interactive)")
-	detectCmd.Flags().Bool("non-interactive", false, "Do not prompt; just print or save if --yes is provided")
 }
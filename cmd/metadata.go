@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/org"
+	"github.com/kajvans/foundry/internal/ui"
+)
+
+// singlePlaceholder matches a metadata value that is exactly one placeholder, e.g. {{OWNER}}
+var singlePlaceholder = regexp.MustCompile(`^\{\{([A-Z][A-Z0-9_]*)\}\}$`)
+
+// metadataVars returns the variables service metadata is filled from: the project's
+// variables plus the built-ins LANGUAGE, FRAMEWORK, TEMPLATE, BUNDLE and RUNTIME
+func metadataVars(tmpl *config.Template, templateName, bundleName string, versions map[string]string, vars map[string]string) map[string]string {
+	all := map[string]string{
+		"LANGUAGE":  tmpl.Language,
+		"FRAMEWORK": tmpl.Framework,
+		"TEMPLATE":  templateName,
+		"BUNDLE":    bundleName,
+	}
+	tools := make([]string, 0, len(versions))
+	for tool := range versions {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	var runtime []string
+	for _, tool := range tools {
+		runtime = append(runtime, tool+" "+versions[tool])
+	}
+	all["RUNTIME"] = strings.Join(runtime, ", ")
+
+	for k, v := range vars {
+		all[k] = v
+	}
+	return all
+}
+
+// askMetadataValues makes sure every required metadata field has a value. Fields whose value is
+// a single placeholder are prompted for, and the answer is stored in vars so later updates reuse it.
+func askMetadataValues(md *org.Metadata, projectName, author string, vars, all map[string]string, nonInteractive bool) error {
+	_, missing := md.Resolve(projectName, author, all)
+	for _, f := range missing {
+		match := singlePlaceholder.FindStringSubmatch(f.Value)
+		if match == nil {
+			return i18n.Errorf("service metadata field '%s' is required but resolves to nothing: %s", f.Name, f.Value)
+		}
+		if nonInteractive {
+			return i18n.Errorf("service metadata field '%s' is required; pass it with --var %s=<value>", f.Name, match[1])
+		}
+		message := f.Prompt
+		if message == "" {
+			message = i18n.Sprintf("Service %s", f.Name)
+		}
+		value, err := ui.Input(message+":", "", true)
+		if err != nil {
+			return i18n.Errorf("input cancelled")
+		}
+		vars[match[1]] = value
+		all[match[1]] = value
+	}
+	return nil
+}
+
+// writeMetadata writes the service metadata file into dir
+func writeMetadata(md *org.Metadata, dir, projectName, author string, all map[string]string) error {
+	values, _ := md.Resolve(projectName, author, all)
+	data, err := md.Render(values)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, filepath.FromSlash(md.Path()))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
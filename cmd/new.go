@@ -5,21 +5,37 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
-	survey "github.com/AlecAivazis/survey/v2"
 	"github.com/fatih/color"
 	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/detect"
+	"github.com/kajvans/foundry/internal/generate"
+	"github.com/kajvans/foundry/internal/gitcache"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/lang"
+	"github.com/kajvans/foundry/internal/license"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/org"
 	"github.com/kajvans/foundry/internal/post"
 	"github.com/kajvans/foundry/internal/project"
+	"github.com/kajvans/foundry/internal/sbom"
+	"github.com/kajvans/foundry/internal/source"
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/kajvans/foundry/internal/template"
+	"github.com/kajvans/foundry/internal/ui"
 	"github.com/kajvans/foundry/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 const (
-	maxBinaryCheckBytes = 8000
-	defaultPageSize     = 10
+	maxReadmeLines    = 40
+	infoOption        = "ℹ Show template info..."
+	defaultCloneDepth = 1
 )
 
 var ignoredDirs = map[string]bool{
@@ -58,187 +74,1422 @@ The command will:
 	# Choose target path explicitly
 	foundry new my-project --language Python --path ~/projects
 
+	# Scaffold into the current (empty or freshly cloned) directory, named after it
+	foundry new . --template go-api
+
+	# Use a golden-path bundle from the org config
+	foundry new my-svc --bundle backend-service
+
+	# Create quietly and change into the new project
+	cd "$(foundry new my-api --template go-service --quiet)"
+
 	# If neither language nor template is provided, Foundry lists options
 	foundry new my-cli`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	// Errors are printed by Execute, translated like those of exitWithError
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Usage only helps with mistakes in the arguments, which cobra reports before this runs
+		cmd.SilenceUsage = true
+		defer runCleanups()
 		projectName := args[0]
 		language, _ := cmd.Flags().GetString("language")
 		templateName, _ := cmd.Flags().GetString("template")
 		gitURL, _ := cmd.Flags().GetString("git")
+		from, _ := cmd.Flags().GetString("from")
+		checksum, _ := cmd.Flags().GetString("sha256")
 		targetPath, _ := cmd.Flags().GetString("path")
 		noGit, _ := cmd.Flags().GetBool("no-git")
 		noPost, _ := cmd.Flags().GetBool("no-post")
-		nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
+		postInDocker, _ := cmd.Flags().GetBool("post-in-docker")
+		noHooks, _ := cmd.Flags().GetBool("no-hooks")
+		validate, _ := cmd.Flags().GetBool("validate")
+		strictVars, _ := cmd.Flags().GetBool("strict-vars")
+		nonInteractive := !canPrompt()
 		varsKV, _ := cmd.Flags().GetStringArray("var")
+		varsStdin, _ := cmd.Flags().GetBool("vars-stdin")
+		varFiles, _ := cmd.Flags().GetStringArray("var-file")
+		inRepo, _ := cmd.Flags().GetString("in-repo")
+		if inRepo != "" && !slices.Contains(inRepoModes, inRepo) {
+			return i18n.Errorf("Unknown --in-repo '%s' (use %s)", inRepo, strings.Join(inRepoModes, ", "))
+		}
+		sbomFormat, _ := cmd.Flags().GetString("sbom")
+		if sbomFormat != "" && !slices.Contains(sbom.Formats, sbomFormat) {
+			return i18n.Errorf("Unknown --sbom '%s' (use %s)", sbomFormat, strings.Join(sbom.Formats, ", "))
+		}
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		components, _ := cmd.Flags().GetStringSlice("with")
+		initialVersion, _ := cmd.Flags().GetString("initial-version")
+		tagVersion, _ := cmd.Flags().GetBool("tag")
+		keepHistory, _ := cmd.Flags().GetBool("keep-history")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+		reproducible, _ := cmd.Flags().GetBool("reproducible")
+		outputArchive, _ := cmd.Flags().GetString("output-archive")
+		bundleName, _ := cmd.Flags().GetString("bundle")
+		noMetadata, _ := cmd.Flags().GetBool("no-metadata")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		scope, _ := cmd.Flags().GetString("scope")
+		// "." scaffolds into the current directory, or the --path one, and is named after it
+		inPlace := projectName == "."
+		if inPlace {
+			name, err := inPlaceName(targetPath)
+			if err != nil {
+				return err
+			}
+			projectName = name
+		}
+		if scope != "" {
+			if err := config.ValidateScope(scope); err != nil {
+				return err
+			}
+		}
+
+		// Quiet runs never prompt and print nothing but the project's path
+		stdout := os.Stdout
+		if quiet {
+			nonInteractive = true
+			ui.Disable()
+			stdout = silenceStdout()
+		}
 
 		cfg, err := config.LoadConfig()
 		if err != nil {
-			exitWithError("Error loading config: %v", err)
+			return i18n.Errorf("Error loading config: %v", err)
+		}
+		// strict_vars in config makes --strict-vars the default
+		if !cmd.Flags().Changed("strict-vars") {
+			strictVars = cfg.StrictVars
+		}
+
+		// Variables piped on stdin leave nothing to answer prompts with
+		var stdinVars map[string]string
+		if varsStdin {
+			if stdinVars, err = utils.ParseVarsJSON(os.Stdin); err != nil {
+				return i18n.Errorf("Error reading --vars-stdin: %v", err)
+			}
+			nonInteractive = true
+			ui.Disable()
+		}
+		// Answers files are merged in order, so a later file overrides an earlier one
+		fileVars := map[string]string{}
+		for _, path := range varFiles {
+			vars, err := utils.ParseVarsFile(path)
+			if err != nil {
+				return i18n.Errorf("Error reading --var-file %s: %v", path, err)
+			}
+			for k, v := range vars {
+				fileVars[k] = v
+			}
+		}
+
+		// Archives are rendered in a temporary directory; only the archive itself is written
+		if outputArchive != "" {
+			if targetPath != "" {
+				return i18n.Errorf("--output-archive and --path cannot be combined")
+			}
+			if !project.IsArchiveName(outputArchive) {
+				return i18n.Errorf("Unsupported archive format: %s (use .tar.gz, .tgz, .tar or .zip)", outputArchive)
+			}
+			if _, err := os.Stat(outputArchive); err == nil {
+				return i18n.Errorf("File '%s' already exists", outputArchive)
+			}
+			noGit, noPost = true, true
+		}
+
+		orgCfg, err := org.Load(cfg)
+		if err != nil {
+			if bundleName != "" {
+				return err
+			}
+			color.Yellow(i18n.T("⚠ %v"), err)
+			orgCfg = &org.Config{}
+		}
+
+		// A bundle brings its own template, components and policies
+		var bundle *org.Bundle
+		if bundleName != "" {
+			if templateName != "" || language != "" || gitURL != "" || from != "" {
+				return i18n.Errorf("--bundle picks the template itself; it cannot be combined with --template, --language, --git or --from")
+			}
+			if bundle, err = loadBundle(orgCfg, bundleName); err != nil {
+				return err
+			}
+			components = appendMissing(components, bundle.Components...)
+			if bundle.Policies.Reproducible {
+				reproducible = true
+			}
+			if bundle.Policies.RequireGit && noGit {
+				return i18n.Errorf("Bundle '%s' requires git; --no-git and --output-archive are not allowed", bundleName)
+			}
+		}
+		// With Docker generation enabled every project gets a .dockerignore for its language
+		if cfg.Docker {
+			components = appendMissing(components, "dockerignore")
+		}
+
+		// Fail fast if the parent directory cannot hold the new project; in place, --path is the
+		// project directory itself and may not exist yet
+		parentDir := targetPath
+		if outputArchive != "" {
+			parentDir = filepath.Dir(outputArchive)
+		} else if inPlace && targetPath != "" {
+			parentDir = filepath.Dir(targetPath)
+		}
+		if parentDir == "" {
+			parentDir = "."
+		}
+		if err := utils.CheckWritableDir(parentDir); err != nil {
+			return i18n.Errorf("Invalid target path: %v", err)
+		}
+
+		// Determine which template to use
+		var src source.Source
+		if bundle != nil {
+			if strings.HasPrefix(bundle.Template, org.SharedPrefix) {
+				templateName = bundle.Template
+			} else if saved, err := config.GetTemplate(bundle.Template); err == nil {
+				templateName = saved.Name
+			} else if src, err = source.Parse(bundle.Template); err != nil {
+				return i18n.Errorf("Bundle '%s': %v", bundleName, err)
+			}
+		} else if gitURL != "" {
+			if err := source.ValidateGitURL(gitURL); err != nil {
+				return i18n.Errorf("Invalid --git URL: %v", err)
+			}
+			src = source.Git(gitURL)
+		} else if from != "" {
+			if src, err = source.Parse(from); err != nil {
+				return err
+			}
+		}
+		if src != nil && src.Kind() == "git" {
+			if err := checkGitHost(orgCfg.GitHosts, src.Location()); err != nil {
+				return err
+			}
+		}
+		// Shared templates from the org config are fetched from their source, like --from
+		var shared *org.SharedTemplate
+		if src == nil && strings.HasPrefix(templateName, org.SharedPrefix) {
+			if shared, err = orgCfg.Shared(templateName); err != nil {
+				return err
+			}
+			if src, err = source.Parse(shared.Source); err != nil {
+				return i18n.Errorf("Shared template '%s': %v", shared.Name, err)
+			}
+			if checksum == "" {
+				checksum = shared.SHA256
+			}
+		}
+		if checksum != "" && (src == nil || !source.SupportsChecksum(src)) {
+			return i18n.Errorf("--sha256 only applies to archive and bucket sources given with --from")
+		}
+		if keepHistory && (src == nil || src.Kind() != "git") {
+			return i18n.Errorf("--keep-history requires a git template source")
+		}
+
+		var tmpl *config.Template
+		templateSource := ""
+		if src != nil {
+			opts := sourceOptions(cfg, noCache)
+			opts.SHA256 = checksum
+			if cmd.Flags().Changed("depth") {
+				opts.Depth, _ = cmd.Flags().GetInt("depth")
+			} else if keepHistory {
+				opts.Depth = 0
+			}
+			if tmpl, err = fetchTemplate(src, opts); err != nil {
+				return err
+			}
+			templateSource = src.Location()
+			if shared != nil {
+				tmpl.Name = org.SharedPrefix + shared.Name
+				tmpl.Description = shared.Description
+				tmpl.Labels = shared.Labels
+				tmpl.Homepage = shared.Homepage
+				tmpl.Maintainer = shared.Maintainer
+				tmpl.MinFoundryVersion = shared.MinFoundryVersion
+				tmpl.Screenshots = shared.Screenshots
+				if shared.Language != "" {
+					tmpl.Language = shared.Language
+				}
+			}
+		} else {
+			if tmpl, err = selectTemplate(cfg, templateName, language, scope, nonInteractive); err != nil {
+				return err
+			}
+			templateSource = tmpl.Path
+		}
+		// Verify template path exists
+		if _, err := os.Stat(tmpl.Path); os.IsNotExist(err) {
+			return i18n.Errorf("Template path no longer exists: %s", tmpl.Path)
+		}
+		// Saved templates edited since they were scanned get their metadata refreshed first
+		if src == nil {
+			rescanIfChanged(tmpl)
+		}
+
+		if err := checkMinFoundryVersion(tmpl.Path, tmpl.MinFoundryVersion); err != nil {
+			return err
+		}
+		if err := checkDeprecation(tmpl, orgCfg.BlockDeprecated); err != nil {
+			return err
+		}
+
+		// Post steps in Docker use the language's official image, so its toolchain need not be installed
+		postImage := ""
+		if postInDocker && !noPost {
+			if !hasDevTool(cfg, "docker") {
+				return i18n.Errorf("--post-in-docker needs docker; install it and run 'foundry detect'")
+			}
+			m, err := manifest.Load(tmpl.Path)
+			if err != nil {
+				return err
+			}
+			if postImage = languageImage(tmpl, m); postImage == "" {
+				return i18n.Errorf("--post-in-docker: no Docker image is known for %s (set docker_image under languages in config)", setupName(tmpl))
+			}
+		}
+
+		projectDir := determineProjectDir(projectName, targetPath)
+		if inPlace {
+			projectDir = inPlaceDir(targetPath)
+		}
+		if outputArchive != "" {
+			if projectDir, err = renderDir(projectName); err != nil {
+				return err
+			}
+		}
+
+		// Check if target directory already exists; one scaffolded in place must be empty
+		ownRepo := false
+		if inPlace && outputArchive == "" {
+			if ownRepo, err = checkInPlaceDir(projectDir); err != nil {
+				return err
+			}
+		} else if _, err := os.Stat(projectDir); err == nil {
+			return i18n.Errorf("Directory '%s' already exists", projectDir)
+		}
+		// A project inside another repository's work tree would nest one repository in the other
+		var repo enclosingRepo
+		if !noGit && !dryRun && !ownRepo {
+			if repo, err = detectEnclosingRepo(projectDir, inRepo, nonInteractive); err != nil {
+				return err
+			}
+		}
+
+		// Parse additional variables
+		extraVars, err := utils.ParseVars(varsKV)
+		if err != nil {
+			return i18n.Errorf("Error parsing --var: %v", err)
+		}
+		// --var overrides the values piped with --vars-stdin, which override --var-file
+		for _, vars := range []map[string]string{stdinVars, fileVars} {
+			for k, v := range vars {
+				if _, ok := extraVars[k]; !ok {
+					extraVars[k] = v
+				}
+			}
+		}
+		if bundle != nil {
+			for k, v := range bundle.Variables {
+				if _, ok := extraVars[k]; !ok {
+					extraVars[k] = v
+				}
+			}
+		}
+		// Values saved with foundry template set-var come under everything given for this run
+		for k, v := range tmpl.Variables {
+			if _, ok := extraVars[k]; !ok {
+				extraVars[k] = v
+			}
+		}
+		// {{VERSION}} defaults to the starting version unless set explicitly
+		if _, ok := extraVars["VERSION"]; !ok {
+			extraVars["VERSION"] = initialVersion
+		}
+
+		m, err := manifest.Load(tmpl.Path)
+		if err != nil {
+			return err
+		}
+		// Templates and hooks also see the machine's environment ({{OS}}, {{SHELL}}, ...), the
+		// template's labels ({{LABEL_TEAM}}, ...), generated values ({{DATE}}, {{UUID}}, ...) and
+		// the environment variables the manifest allows ({{ENV.GITHUB_USER}}); they are not
+		// recorded with the project's variables
+		builtins, err := templateBuiltins(cfg, tmpl, m, nil)
+		if err != nil {
+			return err
+		}
+		if err := checkVarCollisions(m, extraVars, builtins, strictVars); err != nil {
+			return err
+		}
+		warnSecretFlags(m, varsKV)
+		if err := promptManifestVariables(m, extraVars, defaultValues(projectName, cfg.Author, builtins), nonInteractive || !cfg.Interactive); err != nil {
+			return err
+		}
+
+		// Service metadata for internal catalogs, when the org config defines its schema
+		var metadata map[string]string
+		if orgCfg.Metadata != nil && !noMetadata {
+			var declared map[string]string
+			if m != nil {
+				declared = m.Runtimes
+			}
+			metadata = metadataVars(tmpl, tmpl.Name, bundleName, generate.ResolveVersions(tmpl.Language, declared), extraVars)
+			if err := askMetadataValues(orgCfg.Metadata, projectName, cfg.Author, extraVars, metadata, nonInteractive || !cfg.Interactive); err != nil {
+				return err
+			}
+		}
+
+		vars := withBuiltins(extraVars, builtins)
+
+		// Projects get a LICENSE for the configured license unless the template ships its own
+		if !hasLicenseFile(tmpl.Path) {
+			if _, ok := license.Lookup(cfg.License); ok {
+				components = appendMissing(components, "license")
+			} else if cfg.License != "" && !strings.EqualFold(cfg.License, "none") {
+				color.Yellow(i18n.T("⚠ No text known for license %s, so no LICENSE is written (known: %s)"), cfg.License, strings.Join(license.IDs(), ", "))
+			}
+		}
+
+		// Create or preview project
+		if outputArchive != "" {
+			printProjectInfo(projectName, tmpl, outputArchive)
+		} else {
+			printProjectInfo(projectName, tmpl, projectDir)
+		}
+		if dryRun {
+			summary, err := project.PreviewFromTemplate(tmpl, projectName, projectDir, cfg.Author, vars)
+			if err != nil {
+				return i18n.Errorf("Error previewing project: %v", err)
+			}
+			color.Yellow(i18n.T("\nDry run: no files written, no git init."))
+			fmt.Printf(i18n.T("  Would create %d files:\n"), len(summary.Files))
+			// show up to 20 entries
+			maxShow := 20
+			if len(summary.Files) < maxShow {
+				maxShow = len(summary.Files)
+			}
+			for i := 0; i < maxShow; i++ {
+				fmt.Printf("    - %s\n", summary.Files[i])
+			}
+			if len(summary.Files) > maxShow {
+				fmt.Printf(i18n.T("    ... and %d more\n"), len(summary.Files)-maxShow)
+			}
+			printSubstitutions(summary.Substitutions, m)
+			if len(components) > 0 {
+				fmt.Printf(i18n.T("  Would generate: %s\n"), strings.Join(components, ", "))
+			}
+			if metadata != nil {
+				fmt.Printf(i18n.T("  Would write service metadata to %s\n"), orgCfg.Metadata.Path())
+			}
+			if m != nil && len(m.PostCreateHooks(vars)) > 0 && !noHooks {
+				fmt.Printf(i18n.T("  Would run %d post_create hook(s)\n"), len(m.PostCreateHooks(vars)))
+			}
+			return nil
+		}
+		if err := project.CreateFromTemplate(tmpl, projectName, projectDir, cfg.Author, vars); err != nil {
+			return i18n.Errorf("Error creating project: %v", err)
+		}
+		if strictVars {
+			if err := checkUnresolvedPlaceholders(projectDir, project.VerbatimPatterns(tmpl.Path)); err != nil {
+				return err
+			}
+		}
+		if keepHistory {
+			if err := attachTemplateHistory(tmpl.Path, projectDir); err != nil {
+				color.Yellow(i18n.T("⚠ Failed to keep template history: %v"), err)
+			}
+		}
+
+		var genCtx *generate.Context
+		if len(components) > 0 {
+			genCtx = generateContext(cfg, tmpl, projectName, projectDir, extraVars)
+			genCtx.DependencyUpdates = orgCfg.DependencyUpdates
+			if reproducible {
+				genCtx.Date = time.Now().UTC().Format("2006-01-02")
+			}
+			runGenerators(components, genCtx)
+		}
+		if metadata != nil {
+			if err := writeMetadata(orgCfg.Metadata, projectDir, projectName, cfg.Author, metadata); err != nil {
+				color.Yellow(i18n.T("⚠ Failed to write service metadata: %v"), err)
+			} else {
+				color.Green(i18n.T("✓ Service metadata written to %s"), orgCfg.Metadata.Path())
+			}
+		}
+		st := &stamp.Stamp{
+			Source:      templateSource,
+			Language:    tmpl.Language,
+			Framework:   tmpl.Framework,
+			ProjectName: projectName,
+			Variables:   m.Recorded(extraVars),
+			Generated:   generatedVars(builtins),
+			Components:  components,
+			SBOM:        sbomFormat,
+		}
+		// Fetched templates are not saved, so there is no template name to record
+		if src == nil {
+			st.Template = tmpl.Name
+		}
+		if m != nil {
+			st.TemplateVersion = m.Version
+		}
+		st.TemplateCommit = source.Commit(tmpl.Path)
+		if bundle != nil {
+			st.Bundle = bundleName
+			st.Protected = bundle.Policies.Protected
+		}
+		if reproducible {
+			if st.Reproducible, err = pinInputs(tmpl, cfg.Author, genCtx); err != nil {
+				return err
+			}
+			st.Reproducible.Environment = builtins
+		}
+		// Digests are taken before post steps and hooks, so only Foundry's own output is recorded
+		if st.Files, err = project.Digests(projectDir); err != nil {
+			color.Yellow(i18n.T("⚠ Failed to record file digests: %v"), err)
+		} else if err := project.SaveBase(projectDir, projectDir, st.Files); err != nil {
+			color.Yellow(i18n.T("⚠ Failed to save merge base: %v"), err)
+		}
+		writeStamp(projectDir, st)
+
+		// Run post-create language-specific steps unless disabled
+		if !noPost {
+			var err error
+			if postImage != "" {
+				color.Magenta(i18n.T("\nRunning language-specific setup in %s..."), postImage)
+				err = post.RunLanguagePostInContainer(setupName(tmpl), projectDir, postImage, post.Environ(builtins))
+			} else {
+				color.Magenta(i18n.T("\nRunning language-specific setup..."))
+				err = post.RunLanguagePost(setupName(tmpl), projectDir, post.Environ(builtins))
+			}
+			if err != nil {
+				color.Yellow(i18n.T("⚠ Post-create steps failed: %v"), err)
+				if postImage == "" {
+					printInstallHints(post.LanguageSteps(setupName(tmpl), projectDir), cfg)
+				}
+			} else {
+				color.Green(i18n.T("✓ Post-create steps finished."))
+			}
+		} else if outputArchive == "" {
+			color.Yellow(i18n.T("\n⚠ Post-create steps skipped as per --no-post flag."))
+		}
+
+		hookOpts := post.HookOptions{Env: post.Environ(builtins), Policy: cfg.HookPolicy.Combine(orgCfg.HookPolicy)}
+		if hookOpts.Policy.Container {
+			hookOpts.Image = languageImage(tmpl, m)
+		}
+		runManifestHooks(m, projectName, projectDir, cfg, vars, hookOpts, noHooks)
+		if validate {
+			validateProject(setupName(tmpl), projectDir, post.Environ(builtins), project.VerbatimPatterns(tmpl.Path))
+		}
+		if src == nil {
+			_ = config.RecordTemplateUse(tmpl.Name)
+		}
+
+		if outputArchive != "" {
+			if err := project.WriteArchive(projectDir, outputArchive); err != nil {
+				return err
+			}
+			color.Green(i18n.T("\n✓ Project '%s' written to %s"), projectName, outputArchive)
+			if quiet {
+				fmt.Fprintln(stdout, absPath(outputArchive))
+			}
+			return nil
+		}
+
+		printSuccessMessage(projectName, projectDir, setupName(tmpl), noGit, noPost, repo, successMessage(orgCfg, cfg, m, projectName, projectDir, vars))
+		if tagVersion && !noGit && (repo.top == "" || repo.mode == inRepoNested) {
+			tagInitialVersion(projectDir, extraVars["VERSION"])
+		}
+
+		rememberTargetPath(targetPath)
+		if quiet {
+			fmt.Fprintln(stdout, absPath(projectDir))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(newCmd)
+
+	newCmd.Flags().StringP("language", "l", "", "Language/framework to use (uses default template for that language)")
+	newCmd.Flags().StringP("template", "t", "", "Specific template to use; shared/<name> uses a shared template from the org config")
+	newCmd.Flags().String("scope", "", "Only offer personal or shared templates in the template picker")
+	newCmd.Flags().StringP("git", "g", "", "Git repository URL to fetch template from (e.g., https://github.com/user/repo)")
+	newCmd.Flags().String("from", "", "Template source: directory, git URL, archive (.tar.gz, .zip; path or URL), registry:<name> or embedded:<name>")
+	newCmd.Flags().String("sha256", "", "Expected SHA-256 of an archive or bucket --from source; mismatches are refused")
+	newCmd.Flags().Int("depth", defaultCloneDepth, "History depth for --git clones; 0 fetches the full history (default from clone_depth in config)")
+	newCmd.Flags().Bool("keep-history", false, "Keep the --git template's commit history in the new project")
+	newCmd.Flags().Bool("no-cache", false, "Clone --git templates directly instead of through the local mirror cache")
+	newCmd.Flags().StringP("path", "p", "", "Target path for the new project (default: current directory)")
+	newCmd.Flags().Bool("no-git", false, "Skip git initialization")
+	newCmd.Flags().String("in-repo", "", "When the target is inside a git repository: skip (no git init), nested (git init anyway) or branch (commit to a new branch of that repository); prompts by default")
+	newCmd.Flags().Bool("no-post", false, "Skip language-specific post-create commands (npm/pip/go)")
+	newCmd.Flags().Bool("strict-vars", false, "Fail when a variable shadows a built-in or another variable, or a placeholder is left unresolved (default from strict_vars in config)")
+	newCmd.Flags().Bool("validate", false, "Check the new project for leftover placeholders and run the language's validation commands (go vet, npm ls, ...)")
+	newCmd.Flags().Bool("post-in-docker", false, "Run language post-create commands in the language's official Docker image instead of locally")
+	newCmd.Flags().Bool("no-hooks", false, "Skip post_create hooks declared in the template's foundry.yaml")
+	newCmd.Flags().BoolP("quiet", "q", false, "Print only the new project's path on stdout (errors on stderr); implies --non-interactive")
+	newCmd.Flags().StringArray("var", []string{}, "Template variable in key=value form (repeatable)")
+	newCmd.Flags().Bool("vars-stdin", false, "Read template variables from a JSON object on stdin; implies --non-interactive")
+	newCmd.Flags().StringArray("var-file", []string{}, "Read template variables from a YAML or JSON (.json) answers file (repeatable; --var wins)")
+	newCmd.Flags().Bool("dry-run", false, "Preview actions without writing files or initializing git")
+	newCmd.Flags().String("output-archive", "", "Render the project into an archive (.tar.gz, .tgz, .tar, .zip) instead of a directory; implies --no-git and --no-post")
+	newCmd.Flags().String("sbom", "", "Keep an SBOM of what Foundry generated in .foundry/ (spdx or cyclonedx)")
+	newCmd.Flags().Bool("reproducible", false, "Pin template hash, variables and generator inputs in the stamp so 'foundry reproduce' can regenerate the project")
+	newCmd.Flags().String("initial-version", "0.1.0", "Starting project version, exposed as {{VERSION}}")
+	newCmd.Flags().Bool("tag", false, "Tag the initial commit with the starting version (v<initial-version>)")
+	newCmd.Flags().Bool("no-metadata", false, "Skip the service metadata file the org config asks for")
+	newCmd.Flags().String("bundle", "", "Golden-path bundle from the org config: template, components and policies in one name")
+	newCmd.Flags().StringSlice("with", []string{}, fmt.Sprintf("Optional components to generate (%s)", strings.Join(generate.Names(), ", ")))
+
+	_ = newCmd.RegisterFlagCompletionFunc("path", completeTargetPath)
+	_ = newCmd.RegisterFlagCompletionFunc("bundle", completeBundles)
+	_ = newCmd.RegisterFlagCompletionFunc("sbom", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return sbom.Formats, cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = newCmd.RegisterFlagCompletionFunc("with", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return generate.Names(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// checkGitHost refuses url when the org config does not allow cloning templates from its host,
+// or only warns when it is set to
+func checkGitHost(hosts org.GitHosts, url string) error {
+	host, err := source.GitHost(url)
+	if err != nil {
+		return i18n.Errorf("Invalid git URL: %v", err)
+	}
+	if host == "" || hosts.Allows(host) {
+		return nil
+	}
+	if hosts.Warn {
+		color.Yellow(i18n.T("⚠ %s is not an approved git host (approved: %s)"), host, strings.Join(hosts.Allowed, ", "))
+		return nil
+	}
+	return i18n.Errorf("Git host %s is not approved by the org config (approved: %s)", host, strings.Join(hosts.Allowed, ", "))
+}
+
+// loadBundle reads a bundle from the org config
+func loadBundle(orgCfg *org.Config, name string) (*org.Bundle, error) {
+	bundle, err := orgCfg.Bundle(name)
+	if err != nil {
+		return nil, err
+	}
+	color.Cyan(i18n.T("Using bundle '%s'"), name)
+	return bundle, nil
+}
+
+// completeBundles suggests the bundles defined in the org config
+func completeBundles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	orgCfg, err := org.Load(cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return orgCfg.BundleNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// appendMissing appends the items not yet in list
+func appendMissing(list []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, existing := range list {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// templateBuiltins returns the built-in variables for rendering tmpl: the machine's environment,
+// whether Docker generation is enabled, the template's labels, the environment variables its
+// manifest allows, the configured license and its text, and the generated values, which are
+// created afresh when generated is empty
+func templateBuiltins(cfg *config.Config, tmpl *config.Template, m *manifest.Manifest, generated map[string]string) (map[string]string, error) {
+	builtins := config.EnvironmentVars(cfg)
+	builtins["DOCKER"] = strconv.FormatBool(cfg.Docker)
+	for k, v := range tmpl.LabelVars() {
+		builtins[k] = v
+	}
+	for k, v := range m.EnvVars() {
+		builtins[k] = v
+	}
+	if len(generated) == 0 {
+		var err error
+		if generated, err = utils.GeneratedVars(time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range generated {
+		builtins[k] = v
+	}
+	builtins["LICENSE"] = license.Canonical(cfg.License)
+	builtins["LICENSE_TEXT"] = ""
+	if l, ok := license.Lookup(cfg.License); ok {
+		builtins["LICENSE_TEXT"] = l.Text(builtins["YEAR"], cfg.Author)
+	}
+	return builtins, nil
+}
+
+// hasLicenseFile reports whether a license file sits at the root of dir
+func hasLicenseFile(dir string) bool {
+	for _, name := range license.FileNames {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// generatedVars returns the generated built-ins ({{DATE}}, {{UUID}}, ...) among builtins,
+// which the stamp records so foundry update renders the same values again
+func generatedVars(builtins map[string]string) map[string]string {
+	generated := make(map[string]string, len(utils.GeneratedNames))
+	for _, name := range utils.GeneratedNames {
+		if v, ok := builtins[name]; ok {
+			generated[name] = v
+		}
+	}
+	return generated
+}
+
+// withBuiltins returns vars with the built-in variables added; variables set explicitly win
+func withBuiltins(vars, builtins map[string]string) map[string]string {
+	all := make(map[string]string, len(vars)+len(builtins))
+	for k, v := range builtins {
+		all[k] = v
+	}
+	for k, v := range vars {
+		all[k] = v
+	}
+	return all
+}
+
+// renderDir returns a project directory inside a temporary directory, removed when the command finishes
+func renderDir(projectName string) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "foundry-render-")
+	if err != nil {
+		return "", i18n.Errorf("Failed to create temporary directory: %v", err)
+	}
+	cleanups = append(cleanups, func() { os.RemoveAll(tmpDir) })
+	return filepath.Join(tmpDir, projectName), nil
+}
+
+// generateContext describes a new project to the generators
+func generateContext(cfg *config.Config, tmpl *config.Template, projectName, projectDir string, vars map[string]string) *generate.Context {
+	var declared map[string]string
+	if m, err := manifest.Load(tmpl.Path); err == nil && m != nil {
+		declared = m.Runtimes
+	}
+	return &generate.Context{
+		ProjectName: projectName,
+		ProjectDir:  projectDir,
+		Language:    tmpl.Language,
+		Framework:   tmpl.Framework,
+		Version:     vars["VERSION"],
+		Docker:      cfg.Docker,
+		Author:      cfg.Author,
+		License:     cfg.License,
+		Versions:    generate.ResolveVersions(tmpl.Language, declared),
+		Variables:   vars,
+		Tools:       cfg.InstalledDevTools,
+	}
+}
+
+// runGenerators adds the requested optional components to a freshly created project.
+// Failures are reported but do not abort project creation.
+func runGenerators(components []string, ctx *generate.Context) {
+	if len(components) == 0 {
+		return
+	}
+
+	color.Magenta(i18n.T("\nGenerating optional components..."))
+	for _, name := range components {
+		result, err := generate.Run(name, ctx)
+		if err != nil {
+			color.Yellow(i18n.T("⚠ %v"), err)
+			continue
+		}
+		written := result.Written()
+		if len(written) == 0 {
+			color.Yellow(i18n.T("⚠ %s: files already provided by the template, skipped"), name)
+			continue
 		}
+		color.Green(i18n.T("✓ %s: %s"), name, strings.Join(written, ", "))
+	}
+}
+
+// hasDevTool reports whether foundry detect found the named dev tool
+func hasDevTool(cfg *config.Config, name string) bool {
+	for _, tool := range cfg.InstalledDevTools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// languageImage returns the official Docker image of the template's language at the runtime
+// version it targets, or "" when the language has none
+func languageImage(tmpl *config.Template, m *manifest.Manifest) string {
+	l, ok := lang.Resolve(setupName(tmpl))
+	if !ok {
+		return ""
+	}
+	var declared map[string]string
+	if m != nil {
+		declared = m.Runtimes
+	}
+	return l.Image(generate.ResolveVersions(tmpl.Language, declared)[l.Tool])
+}
+
+// setupName returns the framework of a template if known, otherwise its language.
+// Post steps and hints are looked up under this name.
+func setupName(tmpl *config.Template) string {
+	if tmpl.Framework != "" {
+		return tmpl.Framework
+	}
+	return tmpl.Language
+}
+
+// pinInputs records what foundry reproduce needs to regenerate the project byte-for-byte
+func pinInputs(tmpl *config.Template, author string, genCtx *generate.Context) (*stamp.Pin, error) {
+	hash, err := template.Hash(tmpl.Path)
+	if err != nil {
+		return nil, err
+	}
+	pin := &stamp.Pin{
+		TemplateHash:   hash,
+		TemplateCommit: source.Commit(tmpl.Path),
+		Author:         author,
+		Date:           time.Now().UTC().Format("2006-01-02"),
+	}
+	if genCtx != nil {
+		pin.Date = genCtx.Date
+		pin.Versions = genCtx.Versions
+		pin.Docker = genCtx.Docker
+		pin.Tools = genCtx.Tools
+	}
+	return pin, nil
+}
+
+// writeStamp records how the project was generated in .foundry/stamp.yaml.
+// Reproducible projects get no creation time, so regenerating them yields the same stamp.
+func writeStamp(projectDir string, st *stamp.Stamp) {
+	st.FoundryVersion = version
+	if st.Reproducible == nil {
+		st.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	if err := stamp.Save(projectDir, st); err != nil {
+		color.Yellow(i18n.T("⚠ Failed to write project stamp: %v"), err)
+	}
+	writeSBOM(projectDir, st)
+}
+
+// writeSBOM refreshes the project's SBOM after its stamp changed, if the project keeps one
+func writeSBOM(projectDir string, st *stamp.Stamp) {
+	if err := sbom.Write(projectDir, st); err != nil {
+		color.Yellow(i18n.T("⚠ Failed to write SBOM: %v"), err)
+	}
+}
+
+// completeTargetPath suggests configured project roots and recently used paths for --path,
+// falling back to regular directory completion when none match
+func completeTargetPath(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+
+	seen := make(map[string]bool)
+	var suggestions []string
+	for _, p := range append(append([]string{}, cfg.ProjectRoots...), cfg.RecentPaths...) {
+		if seen[p] || !strings.HasPrefix(p, toComplete) {
+			continue
+		}
+		seen[p] = true
+		suggestions = append(suggestions, p)
+	}
+	if len(suggestions) == 0 {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// rememberTargetPath records an explicit --path so completion can offer it next time
+func rememberTargetPath(targetPath string) {
+	if targetPath == "" {
+		return
+	}
+	if abs, err := filepath.Abs(targetPath); err == nil {
+		targetPath = abs
+	}
+	_ = config.AddRecentPath(targetPath)
+}
+
+// cleanups holds functions that must run before the command exits, e.g. removing temporary clones
+var cleanups []func()
+
+// runCleanups runs and clears the registered cleanup functions
+func runCleanups() {
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+	cleanups = nil
+}
+
+// silenceStdout sends what Foundry and the commands it runs print on stdout to the null device
+// and returns the real stdout
+func silenceStdout() *os.File {
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return stdout
+	}
+	os.Stdout = devNull
+	color.Output = devNull
+	return stdout
+}
+
+// absPath returns path made absolute, or path itself if that fails
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// exitWithError prints error and exits with code 1
+func exitWithError(format string, args ...interface{}) {
+	runCleanups()
+	fmt.Fprintf(os.Stderr, i18n.T("Error: ")+i18n.T(format)+"\n", args...)
+	os.Exit(1)
+}
+
+// sourceOptions builds the fetch options for remote template sources from config
+func sourceOptions(cfg *config.Config, noCache bool) source.Options {
+	opts := source.Options{
+		CacheTTL: gitcache.DefaultMaxAge,
+		Depth:    defaultCloneDepth,
+		Warn: func(format string, args ...interface{}) {
+			color.Yellow(i18n.T("⚠ ")+i18n.T(format), args...)
+		},
+	}
+	if cfg.CloneDepth > 0 {
+		opts.Depth = cfg.CloneDepth
+	}
+	if cfg.GitCacheTTL != "" {
+		if d, err := time.ParseDuration(cfg.GitCacheTTL); err != nil {
+			color.Yellow(i18n.T("⚠ Invalid git_cache_ttl '%s', using %s"), cfg.GitCacheTTL, opts.CacheTTL)
+		} else {
+			opts.CacheTTL = d
+		}
+	}
+	if dir, err := config.Dir(); err == nil && !noCache {
+		opts.CacheDir = filepath.Join(dir, "cache")
+	}
+	return opts
+}
+
+// fetchTemplate fetches a template from a source and returns it as an unsaved template.
+// Temporary files are removed when the command finishes.
+func fetchTemplate(src source.Source, opts source.Options) (*config.Template, error) {
+	color.Cyan(i18n.T("Fetching %s template from %s..."), src.Kind(), src.Location())
+	dir, cleanup, err := src.Fetch(opts)
+	cleanups = append(cleanups, cleanup)
+	if err != nil {
+		return nil, err
+	}
+
+	language, _ := template.DetectLanguage(dir)
+	// Name the template after the last element of its location, e.g. repo for git@host:org/repo.git
+	name := filepath.Base(strings.TrimRight(src.Location(), "/"))
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name = name[i+1:]
+	}
+	for _, suffix := range []string{".git", ".tar.gz", ".tgz", ".tar", ".zip"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	return &config.Template{
+		Name:      name,
+		Path:      dir,
+		Language:  language,
+		Framework: lang.DetectFramework(dir),
+	}, nil
+}
+
+// attachTemplateHistory makes the template's commits the history of the new project.
+// The working tree is left as generated; the initial commit is created on top of the template's HEAD.
+func attachTemplateHistory(cloneDir, projectDir string) error {
+	steps := [][]string{
+		{"init", "-q"},
+		{"fetch", "-q", cloneDir, "HEAD"},
+		{"reset", "-q", "--soft", "FETCH_HEAD"},
+	}
+	for _, args := range steps {
+		cmd := exec.Command("git", append([]string{"-C", projectDir}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return i18n.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// checkVarCollisions warns about --var and manifest variables that shadow a built-in such as
+// PROJECT_NAME, or another variable differing only in case, or refuses them when strict is set
+func checkVarCollisions(m *manifest.Manifest, vars, builtins map[string]string, strict bool) error {
+	names := utils.SortedKeys(vars)
+	if m != nil {
+		for _, v := range m.Variables {
+			if _, ok := vars[v.Name]; !ok {
+				names = append(names, v.Name)
+			}
+		}
+	}
+	collisions := utils.VarCollisions(names, utils.SortedKeys(builtins))
+	if len(collisions) == 0 {
+		return nil
+	}
+	if strict {
+		return i18n.Errorf("Conflicting variables: %s", strings.Join(collisions, "; "))
+	}
+	for _, c := range collisions {
+		color.Yellow(i18n.T("⚠ %s"), c)
+	}
+	return nil
+}
+
+// printSubstitutions shows the placeholders of each planned file and the values they would
+// receive, sensitive ones masked, so --var flags can be checked before anything is written
+func printSubstitutions(files []project.FileSubstitutions, m *manifest.Manifest) {
+	if len(files) == 0 {
+		return
+	}
+	fmt.Println(i18n.T("  Substitutions:"))
+	for _, f := range files {
+		fmt.Printf("    %s\n", f.File)
+		for _, s := range f.Substitutions {
+			count := ""
+			if s.Count > 1 {
+				count = fmt.Sprintf(" (%d×)", s.Count)
+			}
+			if s.Resolved {
+				fmt.Printf("      {{%s}} = %s%s\n", s.Name, previewValue(m.Display(s.Name, s.Value)), count)
+			} else {
+				color.Yellow(i18n.T("      {{%s}} has no value%s"), s.Name, count)
+			}
+		}
+	}
+}
+
+// previewValue quotes the first line of a substituted value, shortened to fit a line, and
+// tells how many lines follow
+func previewValue(value string) string {
+	lines := strings.Split(value, "\n")
+	first := []rune(lines[0])
+	if len(first) > 60 {
+		first = append(first[:59], '…')
+	}
+	quoted := strconv.Quote(string(first))
+	if len(lines) > 1 {
+		quoted += i18n.Sprintf(" (+%d lines)", len(lines)-1)
+	}
+	return quoted
+}
 
-		//check if git exists
-		gitExists, err := config.GetConfigValue("git")
+// checkUnresolvedPlaceholders fails with the placeholders left in the freshly rendered project
+// and the files holding them, removing the project rather than leaving broken files behind
+func checkUnresolvedPlaceholders(projectDir string, verbatim []string) error {
+	leftovers, err := project.UnresolvedPlaceholders(projectDir, verbatim)
+	if err != nil {
+		return i18n.Errorf("Cannot search for leftover placeholders: %v", err)
+	}
+	if len(leftovers) == 0 {
+		return nil
+	}
+	locations := map[string][]string{}
+	for _, l := range leftovers {
+		locations[l.Name] = append(locations[l.Name], fmt.Sprintf("%s:%d", l.File, l.Line))
+	}
+	var lines []string
+	for _, name := range utils.SortedKeys(locations) {
+		lines = append(lines, fmt.Sprintf("  {{%s}}: %s", name, strings.Join(locations[name], ", ")))
+	}
+	removeProject(projectDir)
+	return i18n.Errorf("Unresolved placeholders; the project was not created (pass them with --var or declare them in foundry.yaml):\n%s", strings.Join(lines, "\n"))
+}
 
-		if gitURL != "" && gitExists.(bool) {
-			projectDir := determineProjectDir(projectName, targetPath)
+// defaultValues returns what variable defaults can refer to besides other variables: the
+// project name forms, the author and the built-ins
+func defaultValues(projectName, author string, builtins map[string]string) map[string]string {
+	values := utils.ProjectNameVars(projectName)
+	values["AUTHOR"] = author
+	for k, v := range builtins {
+		values[k] = v
+	}
+	return values
+}
 
-			// Check early if the directory already exists
-			if _, err := os.Stat(projectDir); err == nil {
-				exitWithError("Directory '%s' already exists", projectDir)
+// promptManifestVariables fills vars with the variables declared in the template manifest.
+// Values given with --var are checked and kept; the rest are prompted for (from a menu when the
+// variable has choices), or take their default when not interactive. Defaults referring to
+// other variables ({{GITHUB_USER}}) are resolved from vars and known, in dependency order, and
+// computed variables are evaluated last.
+func promptManifestVariables(m *manifest.Manifest, vars, known map[string]string, nonInteractive bool) error {
+	if m == nil {
+		return nil
+	}
+	ordered, err := m.InPromptOrder()
+	if err != nil {
+		return err
+	}
+	shownGroup := ""
+	for _, v := range ordered {
+		if value, ok := vars[v.Name]; ok {
+			if err := v.Check(value); err != nil {
+				return err
 			}
-
-			// Clone repository
-			cmd := exec.Command("git", "clone", gitURL, projectDir)
-			if err := cmd.Run(); err != nil {
-				exitWithError("Failed to clone git repository: %v", err)
+			continue
+		}
+		if len(v.References()) > 0 {
+			v.Default = v.ResolveDefault(withBuiltins(vars, known))
+		}
+		// Variables whose condition does not hold are not asked, whether required or not
+		if !m.Asks(v, withBuiltins(vars, known)) {
+			vars[v.Name] = v.Default
+			continue
+		}
+		// Sensitive values can come from the environment, which keeps them out of shell history
+		if value, ok := os.LookupEnv(v.Name); ok && v.Sensitive {
+			if err := v.Check(value); err != nil {
+				return err
 			}
-		} else {
-			// Determine which template to use
-			tmpl := selectTemplate(cfg, templateName, language, nonInteractive)
+			vars[v.Name] = value
+			continue
+		}
+		if nonInteractive {
+			if v.Required && v.Default == "" && v.Sensitive {
+				return i18n.Errorf("template variable '%s' is required; set the %s environment variable", v.Name, v.Name)
+			}
+			if v.Required && v.Default == "" {
+				return i18n.Errorf("template variable '%s' is required; pass it with --var %s=<value>", v.Name, v.Name)
+			}
+			if err := v.Check(v.Default); err != nil {
+				return i18n.Errorf("variable '%s': default: %w", v.Name, err)
+			}
+			vars[v.Name] = v.Default
+			continue
+		}
 
-			// Verify template path exists
-			if _, err := os.Stat(tmpl.Path); os.IsNotExist(err) {
-				exitWithError("Template path no longer exists: %s", tmpl.Path)
+		if v.Group != shownGroup {
+			if g := m.GroupOf(v.Group); g != nil {
+				printGroupHeader(*g)
 			}
+			shownGroup = v.Group
+		}
+		value, err := promptVariable(v)
+		if err != nil {
+			return i18n.Errorf("input cancelled")
+		}
+		vars[v.Name] = value
+	}
 
-			projectDir := determineProjectDir(projectName, targetPath)
+	// Lists are kept in one form, so "auth, metrics," is recorded as auth,metrics
+	for _, v := range m.Variables {
+		if v.IsList() {
+			vars[v.Name] = strings.Join(utils.SplitList(vars[v.Name]), ",")
+		}
+	}
 
-			// Check if target directory already exists
-			if _, err := os.Stat(projectDir); err == nil {
-				exitWithError("Directory '%s' already exists", projectDir)
-			}
+	// Computed variables follow from the answers, in order, unless given with --var
+	for _, c := range m.Computed {
+		if _, ok := vars[c.Name]; ok {
+			continue
+		}
+		value, err := manifest.EvalExpr(c.Value, withBuiltins(vars, known))
+		if err != nil {
+			return i18n.Errorf("computed variable '%s': %w", c.Name, err)
+		}
+		vars[c.Name] = value
+	}
+	return nil
+}
+
+// warnSecretFlags points out sensitive variables given with --var, which shell history keeps
+func warnSecretFlags(m *manifest.Manifest, varsKV []string) {
+	for _, kv := range varsKV {
+		name, _, _ := strings.Cut(kv, "=")
+		name = strings.TrimSpace(name)
+		if m.IsSensitive(name) {
+			color.Yellow(i18n.T("⚠ %s is sensitive and --var keeps its value in your shell history; set the %s environment variable instead"), name, name)
+		}
+	}
+}
+
+// printGroupHeader introduces the prompts of a variable group
+func printGroupHeader(g manifest.Group) {
+	title := g.Title
+	if title == "" {
+		title = g.Name
+	}
+	color.New(color.Bold).Printf("\n%s\n", title)
+	if g.Description != "" {
+		fmt.Println(g.Description)
+	}
+}
+
+// promptVariable asks for the value of a manifest variable: a menu of its choices (several
+// for a list), or a line of text asked again until it matches the variable's pattern
+func promptVariable(v manifest.Variable) (string, error) {
+	message := v.Prompt
+	if message == "" {
+		message = v.Name
+	}
 
-			// Parse additional variables
-			extraVars, err := utils.ParseVars(varsKV)
+	if len(v.Choices) > 0 && v.IsList() {
+		var defaults []int
+		for _, d := range utils.SplitList(v.Default) {
+			for i, c := range v.Choices {
+				if c == d {
+					defaults = append(defaults, i)
+				}
+			}
+		}
+		for {
+			chosen, err := ui.MultiSelect(message+":", v.Choices, defaults)
 			if err != nil {
-				exitWithError("Error parsing --var: %v", err)
+				return "", err
 			}
+			if len(chosen) == 0 && v.Required {
+				color.Yellow(i18n.T("⚠ Choose at least one"))
+				continue
+			}
+			values := make([]string, len(chosen))
+			for i, c := range chosen {
+				values[i] = v.Choices[c]
+			}
+			return strings.Join(values, ","), nil
+		}
+	}
+	if len(v.Choices) > 0 {
+		def := 0
+		for i, c := range v.Choices {
+			if c == v.Default {
+				def = i
+			}
+		}
+		chosen, err := ui.Select(message+":", v.Choices, def, nil)
+		if err != nil {
+			return "", err
+		}
+		return v.Choices[chosen], nil
+	}
 
-			// Create or preview project
-			printProjectInfo(projectName, tmpl, projectDir)
-			if dryRun {
-				summary, err := project.PreviewFromTemplate(tmpl, projectName, projectDir, cfg.Author, extraVars)
-				if err != nil {
-					exitWithError("Error previewing project: %v", err)
-				}
-				color.Yellow("\nDry run: no files written, no git init.")
-				fmt.Printf("  Would create %d files:\n", len(summary.Files))
-				// show up to 20 entries
-				maxShow := 20
-				if len(summary.Files) < maxShow {
-					maxShow = len(summary.Files)
-				}
-				for i := 0; i < maxShow; i++ {
-					fmt.Printf("    - %s\n", summary.Files[i])
-				}
-				if len(summary.Files) > maxShow {
-					fmt.Printf("    ... and %d more\n", len(summary.Files)-maxShow)
-				}
-				return
-			}
-			if err := project.CreateFromTemplate(tmpl, projectName, projectDir, cfg.Author, extraVars); err != nil {
-				exitWithError("Error creating project: %v", err)
-			}
-
-			// Run post-create language-specific steps unless disabled or dry-run
-			if !dryRun {
-				if !noPost {
-					color.Magenta("\nRunning language-specific setup...")
-					if err := post.RunLanguagePost(tmpl.Language, projectDir); err != nil {
-						color.Yellow("⚠ Post-create steps failed: %v", err)
-					} else {
-						color.Green("✓ Post-create steps finished.")
-					}
-				} else {
-					color.Yellow("\n⚠ Post-create steps skipped as per --no-post flag.")
+	if v.Sensitive {
+		return promptSecret(v, message)
+	}
+
+	if v.IsList() {
+		message += i18n.T(" (comma-separated)")
+	}
+	for {
+		value, err := ui.Input(message+":", v.Default, v.Required)
+		if err != nil {
+			return "", err
+		}
+		if err := v.Check(value); err != nil {
+			color.Yellow(i18n.T("⚠ %v"), err)
+			continue
+		}
+		return value, nil
+	}
+}
+
+// promptSecret asks for a sensitive variable with hidden input. Its default is not shown, but an
+// empty answer takes it.
+func promptSecret(v manifest.Variable, message string) (string, error) {
+	if v.Default != "" {
+		message += i18n.T(" (leave empty for the default)")
+	}
+	for {
+		value, err := ui.Password(message + ":")
+		if err != nil {
+			return "", err
+		}
+		if value == "" {
+			value = v.Default
+		}
+		if value == "" && v.Required {
+			color.Yellow(i18n.T("⚠ A value is required"))
+			continue
+		}
+		if err := v.Check(value); err != nil {
+			color.Yellow(i18n.T("⚠ %v"), err)
+			continue
+		}
+		return value, nil
+	}
+}
+
+// validateProject reports template bugs in a newly created project: placeholders left in its
+// files, except those the template copies verbatim, and failing language validation commands.
+// Problems are reported but do not abort.
+func validateProject(language, projectDir string, env []string, verbatim []string) {
+	color.Magenta(i18n.T("\nValidating project..."))
+	problems := 0
+
+	leftovers, err := project.UnresolvedPlaceholders(projectDir, verbatim)
+	if err != nil {
+		color.Yellow(i18n.T("⚠ Cannot search for leftover placeholders: %v"), err)
+	}
+	for _, l := range leftovers {
+		color.Yellow(i18n.T("⚠ Placeholder {{%s}} left in %s:%d"), l.Name, l.File, l.Line)
+		problems++
+	}
+
+	for _, check := range post.Validate(language, projectDir, env) {
+		switch {
+		case check.Skipped:
+			fmt.Printf(i18n.T("  - %s skipped: its program is not installed\n"), check.Command)
+		case check.Err != nil:
+			color.Yellow(i18n.T("⚠ %s failed: %v"), check.Command, check.Err)
+			for _, line := range strings.Split(check.Output, "\n") {
+				if line != "" {
+					fmt.Printf("    %s\n", line)
 				}
 			}
-
-			printSuccessMessage(projectName, projectDir, tmpl.Language, noGit, noPost)
+			problems++
+		default:
+			color.Green(i18n.T("✓ %s"), check.Command)
 		}
+	}
 
-	},
+	if problems > 0 {
+		color.Yellow(i18n.T("⚠ Validation found %d problem(s); the template may need fixing"), problems)
+	} else {
+		color.Green(i18n.T("✓ Project validated"))
+	}
 }
 
-func init() {
-	rootCmd.AddCommand(newCmd)
+// runManifestHooks runs the template's post_create hooks whose condition holds, with placeholders replaced,
+// as opts allow. Failures are reported but do not abort project creation.
+func runManifestHooks(m *manifest.Manifest, projectName, projectDir string, cfg *config.Config, vars map[string]string, opts post.HookOptions, skip bool) {
+	if m == nil {
+		return
+	}
+	// Hooks scoped to another OS (when: os == windows) are left out
+	hooks := m.PostCreateHooks(vars)
+	if len(hooks) == 0 {
+		return
+	}
+	if skip {
+		color.Yellow(i18n.T("\n⚠ Template hooks skipped as per --no-hooks flag."))
+		return
+	}
 
-	newCmd.Flags().StringP("language", "l", "", "Language/framework to use (uses default template for that language)")
-	newCmd.Flags().StringP("template", "t", "", "Specific template to use")
-	newCmd.Flags().StringP("git", "g", "", "Git repository URL to fetch template from (e.g., https://github.com/user/repo)")
-	newCmd.Flags().StringP("path", "p", "", "Target path for the new project (default: current directory)")
-	newCmd.Flags().Bool("no-git", false, "Skip git initialization")
-	newCmd.Flags().Bool("no-post", false, "Skip language-specific post-create commands (npm/pip/go)")
-	newCmd.Flags().Bool("non-interactive", false, "Do not prompt; require --language or --template")
-	newCmd.Flags().StringArray("var", []string{}, "Template variable in key=value form (repeatable)")
-	newCmd.Flags().Bool("dry-run", false, "Preview actions without writing files or initializing git")
+	for i, h := range hooks {
+		hooks[i].Run = utils.ReplacePlaceholders(h.Run, projectName, cfg.Author, vars)
+		hooks[i].Args = make([]string, len(h.Args))
+		for j, arg := range h.Args {
+			hooks[i].Args[j] = utils.ReplacePlaceholders(arg, projectName, cfg.Author, vars)
+		}
+	}
+	color.Magenta(i18n.T("\nRunning template hooks..."))
+	if err := post.RunHooks(hooks, projectDir, opts); err != nil {
+		color.Yellow(i18n.T("⚠ %v"), err)
+		if !opts.Policy.Container {
+			printInstallHints(hooks, cfg)
+		}
+	} else {
+		color.Green(i18n.T("✓ Template hooks finished."))
+	}
 }
 
-// exitWithError prints error and exits with code 1
-func exitWithError(format string, args ...interface{}) {
-	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
-	os.Exit(1)
+// printInstallHints explains which programs the failed commands need are missing, and how to
+// install them, from what foundry detect found on this machine
+func printInstallHints(commands []manifest.Hook, cfg *config.Config) {
+	seen := map[string]bool{}
+	for _, h := range commands {
+		for _, program := range detect.Missing(post.Programs(h)) {
+			if !seen[program] {
+				seen[program] = true
+				color.Yellow(i18n.T("  → %s"), detect.InstallHint(program, cfg))
+			}
+		}
+	}
 }
 
 // selectTemplate determines which template to use based on flags and interactive mode
-func selectTemplate(cfg *config.Config, templateName, language string, nonInteractive bool) *config.Template {
+func selectTemplate(cfg *config.Config, templateName, language, scope string, nonInteractive bool) (*config.Template, error) {
 	if templateName != "" {
-		return selectByName(templateName)
+		return config.GetTemplate(templateName)
 	}
 	if language != "" {
 		return selectByLanguage(language)
 	}
-	return selectInteractively(cfg, nonInteractive)
-}
-
-// selectByName gets template by explicit name
-func selectByName(name string) *config.Template {
-	tmpl, err := config.GetTemplate(name)
-	if err != nil {
-		exitWithError("%v", err)
-	}
-	return tmpl
+	return selectInteractively(cfg, scope, nonInteractive)
 }
 
 // selectByLanguage gets default template for a language
-func selectByLanguage(language string) *config.Template {
+func selectByLanguage(language string) (*config.Template, error) {
 	defaultTmpl, err := config.GetLanguageDefault(language)
 	if err != nil {
-		exitWithError("%v", err)
+		return nil, err
 	}
 	if defaultTmpl == "" {
-		exitWithError("No default template set for language '%s'\nSet one with: foundry config %s <template-name>\nOr use --template to specify a template directly", language, language)
-	}
-	tmpl, err := config.GetTemplate(defaultTmpl)
-	if err != nil {
-		exitWithError("%v", err)
+		return nil, i18n.Errorf("No default template set for language '%s'\nSet one with: foundry config %s <template-name>\nOr use --template to specify a template directly", language, language)
 	}
-	return tmpl
+	return config.GetTemplate(defaultTmpl)
 }
 
 // selectInteractively shows template selection UI or lists available templates
-func selectInteractively(cfg *config.Config, nonInteractive bool) *config.Template {
+func selectInteractively(cfg *config.Config, scope string, nonInteractive bool) (*config.Template, error) {
 	templates, err := config.ListTemplates()
 	if err != nil {
-		exitWithError("%v", err)
+		return nil, err
 	}
 	if len(templates) == 0 {
-		exitWithError("No templates available. Add one with: foundry template add <name> <path>")
+		return nil, i18n.Errorf("No templates available. Add one with: foundry template add <name> <path>")
+	}
+	if scope != "" {
+		inScope := templates[:0]
+		for _, t := range templates {
+			if t.EffectiveScope() == scope {
+				inScope = append(inScope, t)
+			}
+		}
+		if templates = inScope; len(templates) == 0 {
+			return nil, i18n.Errorf("No templates with scope %s saved", i18n.T(scope))
+		}
 	}
 
 	if nonInteractive || !cfg.Interactive {
-		listTemplatesAndExit(templates)
+		listTemplates(templates)
+		return nil, i18n.Errorf("Please specify --language or --template (or enable interactive mode)")
 	}
 
 	// Interactive mode: two-step selection
-	chosenLang := selectLanguage(templates)
+	chosenLang, err := selectLanguage(templates)
+	if err != nil {
+		return nil, err
+	}
 	return selectTemplateForLanguage(templates, chosenLang)
 }
 
 // selectLanguage shows language selection menu
-func selectLanguage(templates []config.Template) string {
+func selectLanguage(templates []config.Template) (string, error) {
 	langSet := make(map[string]struct{})
 	for _, t := range templates {
 		if t.Language != "" {
@@ -253,23 +1504,18 @@ func selectLanguage(templates []config.Template) string {
 	sort.Strings(langs)
 
 	if len(langs) == 0 {
-		exitWithError("No languages detected from templates")
+		return "", i18n.Errorf("No languages detected from templates")
 	}
 
-	pageSize := utils.Min(len(langs), defaultPageSize)
-	var chosenLang string
-	if err := survey.AskOne(&survey.Select{
-		Message:  "Select a language:",
-		Options:  langs,
-		PageSize: pageSize,
-	}, &chosenLang); err != nil {
-		exitWithError("Selection cancelled")
+	chosen, err := ui.Select(i18n.T("Select a language:"), langs, 0, nil)
+	if err != nil {
+		return "", i18n.Errorf("Selection cancelled")
 	}
-	return chosenLang
+	return langs[chosen], nil
 }
 
 // selectTemplateForLanguage shows template selection menu for chosen language
-func selectTemplateForLanguage(templates []config.Template, language string) *config.Template {
+func selectTemplateForLanguage(templates []config.Template, language string) (*config.Template, error) {
 	var filtered []config.Template
 	for _, t := range templates {
 		if t.Language == language {
@@ -277,55 +1523,130 @@ func selectTemplateForLanguage(templates []config.Template, language string) *co
 		}
 	}
 	if len(filtered) == 0 {
-		exitWithError("No templates available for language '%s'", language)
+		return nil, i18n.Errorf("No templates available for language '%s'", language)
 	}
 
 	labels := make([]string, 0, len(filtered))
 	for _, t := range filtered {
 		label := t.Name
 		if len(config.IsDefaultTemplate(t.Name)) > 0 {
-			label = fmt.Sprintf("%s (default)", t.Name)
+			label = t.Name + i18n.T(" (default)")
+		}
+		if templateDeprecation(&t) != nil {
+			label += i18n.T(" (deprecated)")
 		}
 		labels = append(labels, label)
 	}
 
-	pageSize := utils.Min(len(labels), defaultPageSize)
-	var selectedLabel string
-	if err := survey.AskOne(&survey.Select{
-		Message:  fmt.Sprintf("Select a %s template:", language),
-		Options:  labels,
-		PageSize: pageSize,
-	}, &selectedLabel); err != nil {
-		exitWithError("Selection cancelled")
+	// The trailing info entry lets users read a template's README before committing to it
+	options := append(append([]string{}, labels...), i18n.T(infoOption))
+	describe := func(index int) string {
+		if index < len(filtered) {
+			return templateSummary(filtered[index])
+		}
+		return ""
+	}
+	for {
+		chosen, err := ui.Select(i18n.Sprintf("Select a %s template:", language), options, 0, describe)
+		if err != nil {
+			return nil, i18n.Errorf("Selection cancelled")
+		}
+		if chosen < len(filtered) {
+			return config.GetTemplate(filtered[chosen].Name)
+		}
+		showTemplateInfo(filtered, labels)
+	}
+}
+
+// templateSummary returns a one-line description for a template in the picker
+func templateSummary(t config.Template) string {
+	if t.Description != "" {
+		return t.Description
+	}
+	if m, err := manifest.Load(t.Path); err == nil && m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+// showTemplateInfo asks which template to inspect and prints its manifest description and README
+func showTemplateInfo(templates []config.Template, labels []string) {
+	chosen, err := ui.Select(i18n.T("Show info for which template?"), labels, 0, nil)
+	if err != nil {
+		return
 	}
+	t := templates[chosen]
 
-	// Strip " (default)" suffix
-	baseName := strings.TrimSuffix(selectedLabel, " (default)")
-	for _, t := range filtered {
-		if t.Name == baseName {
-			tmpl, err := config.GetTemplate(t.Name)
-			if err != nil {
-				exitWithError("%v", err)
-			}
-			return tmpl
+	color.New(color.Bold).Printf("\n%s\n", t.Name)
+	if m, err := manifest.Load(t.Path); err == nil && m != nil {
+		if m.LongDescription != "" {
+			fmt.Printf("%s\n", strings.TrimSpace(m.LongDescription))
+		} else if m.Description != "" {
+			fmt.Printf("%s\n", m.Description)
 		}
+	} else if t.Description != "" {
+		fmt.Printf("%s\n", t.Description)
 	}
-	exitWithError("Template not found")
-	return nil
+	if t.Homepage != "" {
+		fmt.Printf(i18n.T("Homepage: %s\n"), t.Homepage)
+	}
+	if t.Maintainer != "" {
+		fmt.Printf(i18n.T("Maintainer: %s\n"), t.Maintainer)
+	}
+
+	readme := template.ReadReadme(t.Path)
+	if readme == "" {
+		color.Yellow(i18n.T("No README found for this template.\n"))
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(readme), "\n")
+	fmt.Println()
+	for i, line := range lines {
+		if i == maxReadmeLines {
+			color.Yellow(i18n.T("... (%d more lines in %s)"), len(lines)-maxReadmeLines, t.Path)
+			break
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
 }
 
-// listTemplatesAndExit lists all templates and exits
-func listTemplatesAndExit(templates []config.Template) {
-	fmt.Println("Available templates:")
+// listTemplates lists all templates with the languages they are the default for
+func listTemplates(templates []config.Template) {
+	fmt.Println(i18n.T("Available templates:"))
 	for i, t := range templates {
 		defaults := config.IsDefaultTemplate(t.Name)
 		defaultInfo := ""
 		if len(defaults) > 0 {
-			defaultInfo = fmt.Sprintf(" (default for: %v)", defaults)
+			defaultInfo = i18n.Sprintf(" (default for: %v)", defaults)
 		}
 		fmt.Printf("  %d. %s - %s%s\n", i+1, t.Name, t.Language, defaultInfo)
 	}
-	exitWithError("Please specify --language or --template (or enable interactive mode)")
+}
+
+// checkMinFoundryVersion refuses templates that need a newer Foundry than this build.
+// The manifest in templateDir wins over the version recorded when the template was added.
+func checkMinFoundryVersion(templateDir, required string) error {
+	m, err := manifest.Load(templateDir)
+	if err != nil {
+		return err
+	}
+	if m != nil && m.MinFoundryVersion != "" {
+		required = m.MinFoundryVersion
+	}
+	// Development builds have no meaningful version to compare against
+	if required == "" || version == "dev" {
+		return nil
+	}
+
+	cmp, err := utils.CompareVersions(version, required)
+	if err != nil {
+		return i18n.Errorf("cannot check min_foundry_version: %w", err)
+	}
+	if cmp < 0 {
+		return i18n.Errorf("this template requires Foundry %s or newer, but you are running %s\nUpgrade from https://github.com/kajvans/foundry/releases and try again", required, version)
+	}
+	return nil
 }
 
 // determineProjectDir calculates the target directory for the project
@@ -336,123 +1657,337 @@ func determineProjectDir(projectName, targetPath string) string {
 	return projectName
 }
 
+// inPlaceDir returns the directory 'foundry new .' scaffolds into: --path, or the current one
+func inPlaceDir(targetPath string) string {
+	if targetPath != "" {
+		return targetPath
+	}
+	return "."
+}
+
+// inPlaceName infers the name of a project scaffolded in place from its directory's name
+func inPlaceName(targetPath string) (string, error) {
+	abs, err := filepath.Abs(inPlaceDir(targetPath))
+	if err != nil {
+		return "", i18n.Errorf("Cannot resolve the project directory: %v", err)
+	}
+	name := filepath.Base(abs)
+	if name == string(filepath.Separator) {
+		return "", i18n.Errorf("Cannot infer a project name from '%s'; pass one instead of '.'", abs)
+	}
+	return name, nil
+}
+
+// checkInPlaceDir fails unless dir is missing or empty but for a .git of a repository cloned
+// first, and reports whether that .git is there
+func checkInPlaceDir(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, i18n.Errorf("Cannot read directory '%s': %v", dir, err)
+	}
+	ownRepo := false
+	for _, e := range entries {
+		if e.Name() != ".git" {
+			return false, i18n.Errorf("Directory '%s' is not empty", dir)
+		}
+		ownRepo = true
+	}
+	return ownRepo, nil
+}
+
+// removeProject removes what was generated in projectDir, keeping a .git that was there
+// before, so a failed 'foundry new .' leaves a cloned repository as it was
+func removeProject(projectDir string) {
+	entries, _ := os.ReadDir(projectDir)
+	for _, e := range entries {
+		if e.Name() != ".git" {
+			os.RemoveAll(filepath.Join(projectDir, e.Name()))
+		}
+	}
+	os.Remove(projectDir)
+}
+
 // printProjectInfo displays project creation details
 func printProjectInfo(projectName string, tmpl *config.Template, projectDir string) {
-	color.Cyan("Creating project '%s' from template '%s'...", projectName, tmpl.Name)
-	fmt.Printf("  Language: %s\n", tmpl.Language)
-	fmt.Printf("  Target: %s\n", projectDir)
+	color.Cyan(i18n.T("Creating project '%s' from template '%s'..."), projectName, tmpl.Name)
+	fmt.Printf(i18n.T("  Language: %s\n"), tmpl.Language)
+	fmt.Printf(i18n.T("  Target: %s\n"), projectDir)
 }
 
 // printSuccessMessage displays success message and next steps
-func printSuccessMessage(projectName, projectDir, language string, noGit bool, noPost bool) {
-	color.Green("\n✓ Project '%s' created successfully!", projectName)
-	fmt.Printf("  Location: %s\n", projectDir)
+func printSuccessMessage(projectName, projectDir, language string, noGit bool, noPost bool, repo enclosingRepo, success manifest.Success) {
+	if success.Message != "" {
+		color.Green(i18n.T("\n✓ %s"), success.Message)
+	} else {
+		color.Green(i18n.T("\n✓ Project '%s' created successfully!"), projectName)
+	}
+	fmt.Printf(i18n.T("  Location: %s\n"), projectDir)
 
 	// Setup git repository
-	setupGitRepo(projectDir, noGit, language)
+	setupGitRepo(projectDir, noGit, language, repo)
 
 	//TODO: Add code here to open project in VS Code if available
 	vscodePath, err := config.GetConfigValue("vscode_path")
 	if err == nil {
 		if pathStr, ok := vscodePath.(string); ok && pathStr != "" {
-			color.Magenta("\nOpening project in VS Code...")
+			color.Magenta(i18n.T("\nOpening project in VS Code..."))
 			cmd := exec.Command(pathStr, projectDir)
 			if err := cmd.Start(); err != nil {
-				color.Red("✗ Failed to open VS Code: %v", err)
+				color.Red(i18n.T("✗ Failed to open VS Code: %v"), err)
 			} else {
-				color.Green("✓ VS Code opened.")
+				color.Green(i18n.T("✓ VS Code opened."))
 			}
 		}
 	}
 
 	//printLanguageSpecificSteps(language)
-	color.New(color.Bold).Println("\nNext steps:")
-	fmt.Printf("  cd %s\n", projectName)
-	if(!noPost){
-		fmt.Printf("  Run the following commands to get started with your %s project:\n", language)
-		printLanguageSpecificSteps(language)
+	color.New(color.Bold).Println(i18n.T("\nNext steps:"))
+	if filepath.Clean(projectDir) != "." {
+		fmt.Printf(i18n.T("  cd %s\n"), projectDir)
 	}
-}
-
-func setupGitRepo(projectDir string, noGit bool, language string) error {
-
-	if !noGit {
-		color.Magenta("\nInitializing git repository...")
-		cmd := exec.Command("git", "init", projectDir)
-		if err := cmd.Run(); err != nil {
-			color.Red("✗ Failed to initialize git repository: %v", err)
-		} else {
-			color.Green("✓ Git repository initialized.")
-		}
-
-		//check if gitignore exists in folder
-		if _, err := os.Stat(filepath.Join(projectDir, ".gitignore")); os.IsNotExist(err) {
-			//download default gitignore for language
-			color.Magenta("Adding default .gitignore for %s...", language)
-			gitignoreContent := getDefaultGitignore(language)
-			if gitignoreContent != "" {
-				gitignorePath := filepath.Join(projectDir, ".gitignore")
-				if err := os.WriteFile(gitignorePath, []byte(gitignoreContent), 0644); err != nil {
-					color.Red("✗ Failed to create .gitignore: %v", err)
-				} else {
-					color.Green("✓ .gitignore created.")
-				}
+	if len(success.NextSteps) > 0 {
+		for _, step := range success.NextSteps {
+			fmt.Printf("  %s\n", step)
+		}
+	} else if !noPost {
+		fmt.Printf(i18n.T("  Run the following commands to get started with your %s project:\n"), language)
+		printLanguageSpecificSteps(language, projectDir)
+	}
+	if len(success.Links) > 0 {
+		color.New(color.Bold).Println(i18n.T("\nLearn more:"))
+		for _, link := range success.Links {
+			if link.Title != "" {
+				fmt.Printf("  %s: %s\n", link.Title, link.URL)
 			} else {
-				color.Yellow("⚠ No default .gitignore available for %s", language)
+				fmt.Printf("  %s\n", link.URL)
 			}
 		}
+	}
+}
 
-		// 3. Run: git add .
+// successMessage returns the success block configured for the new project by the org config,
+// the user config and the template, in that order, in the current locale and with placeholders
+// replaced; {{PROJECT_DIR}} is the project's directory
+func successMessage(orgCfg *org.Config, cfg *config.Config, m *manifest.Manifest, projectName, projectDir string, vars map[string]string) manifest.Success {
+	var fromTemplate *manifest.Success
+	if m != nil {
+		fromTemplate = m.Success
+	}
+	success := manifest.CombineSuccess(i18n.Locale(), orgCfg.Success, cfg.Success, fromTemplate)
+	values := map[string]string{"PROJECT_DIR": projectDir}
+	for k, v := range vars {
+		values[k] = m.Display(k, v)
+	}
+	render := func(text string) string {
+		return utils.ReplacePlaceholders(text, projectName, cfg.Author, values)
+	}
+	success.Message = render(success.Message)
+	steps := make([]string, len(success.NextSteps))
+	for i, step := range success.NextSteps {
+		steps[i] = render(step)
+	}
+	success.NextSteps = steps
+	links := make([]manifest.Link, len(success.Links))
+	for i, link := range success.Links {
+		links[i] = manifest.Link{Title: render(link.Title), URL: render(link.URL)}
+	}
+	success.Links = links
+	return success
+}
 
-		cmd = exec.Command("git", "-C", projectDir, "add", ".")
-		if err := cmd.Run(); err != nil {
-			color.Red("✗ Failed to add files to git: %v", err)
-		} else {
-			color.Green("✓ Files added to git.")
-		}
+func setupGitRepo(projectDir string, noGit bool, language string, repo enclosingRepo) error {
 
-		// 4. Run: git commit -m "Initial commit from Foundry"
-		cmd = exec.Command("git", "-C", projectDir, "commit", "-m", "Initial commit from Foundry")
-		if err := cmd.Run(); err != nil {
-			color.Red("✗ Failed to commit files to git: %v", err)
-		} else {
-			color.Green("✓ Initial commit created.")
-		}
+	if noGit {
+		color.Yellow(i18n.T("\n⚠ Git initialization skipped as per --no-git flag."))
+		return nil
+	}
+	if repo.mode == inRepoSkip {
+		color.Yellow(i18n.T("\n⚠ Git initialization skipped: the project is inside the git repository %s (see --in-repo)."), repo.top)
+		return nil
+	}
+
+	if repo.mode == inRepoBranch {
+		addDefaultGitignore(projectDir, language)
+		commitToNewBranch(repo.top, projectDir)
+		return nil
+	}
+
+	color.Magenta(i18n.T("\nInitializing git repository..."))
+	cmd := exec.Command("git", "init", projectDir)
+	if err := cmd.Run(); err != nil {
+		color.Red(i18n.T("✗ Failed to initialize git repository: %v"), err)
+	} else {
+		color.Green(i18n.T("✓ Git repository initialized."))
+	}
+
+	addDefaultGitignore(projectDir, language)
+
+	// 3. Run: git add .
 
+	cmd = exec.Command("git", "-C", projectDir, "add", ".")
+	if err := cmd.Run(); err != nil {
+		color.Red(i18n.T("✗ Failed to add files to git: %v"), err)
 	} else {
-		color.Yellow("\n⚠ Git initialization skipped as per --no-git flag.")
+		color.Green(i18n.T("✓ Files added to git."))
+	}
+
+	// 4. Run: git commit -m "Initial commit from Foundry"
+	cmd = exec.Command("git", "-C", projectDir, "commit", "-m", "Initial commit from Foundry")
+	if err := cmd.Run(); err != nil {
+		color.Red(i18n.T("✗ Failed to commit files to git: %v"), err)
+	} else {
+		color.Green(i18n.T("✓ Initial commit created."))
 	}
 	return nil
 }
 
-func getDefaultGitignore(language string) string {
+// addDefaultGitignore downloads the language's default .gitignore when the project has none
+func addDefaultGitignore(projectDir, language string) {
+	if _, err := os.Stat(filepath.Join(projectDir, ".gitignore")); !os.IsNotExist(err) {
+		return
+	}
+	color.Magenta(i18n.T("Adding default .gitignore for %s..."), language)
+	gitignoreContent, url := getDefaultGitignore(language)
+	if gitignoreContent == "" {
+		color.Yellow(i18n.T("⚠ No default .gitignore available for %s"), language)
+		return
+	}
+	gitignorePath := filepath.Join(projectDir, ".gitignore")
+	if err := os.WriteFile(gitignorePath, []byte(gitignoreContent), 0644); err != nil {
+		color.Red(i18n.T("✗ Failed to create .gitignore: %v"), err)
+	} else {
+		color.Green(i18n.T("✓ .gitignore created."))
+		recordOrigin(projectDir, ".gitignore", url)
+	}
+}
+
+// recordOrigin notes in the stamp, and the SBOM if kept, where a downloaded file came from
+func recordOrigin(projectDir, rel, url string) {
+	st, err := stamp.Load(projectDir)
+	if err != nil || st == nil {
+		return
+	}
+	if err := st.AddOrigin(projectDir, rel, url); err != nil {
+		return
+	}
+	if err := stamp.Save(projectDir, st); err != nil {
+		color.Yellow(i18n.T("⚠ Failed to write project stamp: %v"), err)
+	}
+	writeSBOM(projectDir, st)
+}
+
+// Ways to place a project created inside the work tree of another git repository (--in-repo)
+const (
+	inRepoSkip   = "skip"   // leave the project to the enclosing repository, without git init
+	inRepoNested = "nested" // run git init anyway, nesting one repository in the other
+	inRepoBranch = "branch" // commit the project to a new branch of the enclosing repository
+)
+
+// inRepoModes lists the valid --in-repo values
+var inRepoModes = []string{inRepoSkip, inRepoNested, inRepoBranch}
+
+// enclosingRepo is the git repository a new project would be created in, and how to place the
+// project there; the zero value means the project is not inside a repository
+type enclosingRepo struct {
+	top  string // the repository's top-level directory
+	mode string
+}
+
+// detectEnclosingRepo finds the git work tree projectDir would be created in and decides how to
+// place the project: as --in-repo says, else as the user picks, else (without prompts) skipping
+// git init so repositories are not nested by accident
+func detectEnclosingRepo(projectDir, mode string, nonInteractive bool) (enclosingRepo, error) {
+	abs, err := filepath.Abs(projectDir)
+	if err != nil {
+		return enclosingRepo{}, nil
+	}
+	parent := filepath.Dir(abs)
+	out, err := exec.Command("git", "-C", parent, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return enclosingRepo{}, nil
+	}
+	repo := enclosingRepo{top: strings.TrimSpace(string(out)), mode: mode}
+	if repo.mode != "" {
+		return repo, nil
+	}
+	repo.mode = inRepoSkip
+	if nonInteractive {
+		return repo, nil
+	}
+	options := []string{
+		i18n.T("Skip git init; the enclosing repository tracks the project"),
+		i18n.T("Commit the project to a new branch of the enclosing repository"),
+		i18n.T("Initialize a nested repository anyway"),
+	}
+	color.Yellow(i18n.T("⚠ %s is inside the git repository %s"), projectDir, repo.top)
+	chosen, err := ui.Select(i18n.T("How should the project be added?"), options, 0, nil)
+	if err != nil {
+		return repo, i18n.Errorf("Selection cancelled: %v", err)
+	}
+	repo.mode = []string{inRepoSkip, inRepoBranch, inRepoNested}[chosen]
+	return repo, nil
+}
+
+// commitToNewBranch commits projectDir to a new branch of the repository at top, named after the
+// project. Switching to a branch created from HEAD keeps the working tree as it is, and only the
+// project's files are committed, whatever else is staged.
+func commitToNewBranch(top, projectDir string) {
+	branch := "foundry/" + utils.Slug(filepath.Base(projectDir))
+	color.Magenta(i18n.T("\nCommitting the project to branch %s of %s..."), branch, top)
+	steps := [][]string{
+		{"switch", "-c", branch},
+		{"add", "--", projectDir},
+		{"commit", "-m", "Add " + filepath.Base(projectDir) + " from Foundry", "--", projectDir},
+	}
+	for _, args := range steps {
+		out, err := exec.Command("git", append([]string{"-C", top}, args...)...).CombinedOutput()
+		if err != nil {
+			color.Red(i18n.T("✗ git %s failed: %v\n%s"), args[0], err, strings.TrimSpace(string(out)))
+			return
+		}
+	}
+	color.Green(i18n.T("✓ Project committed to branch %s."), branch)
+}
+
+// tagInitialVersion tags the initial commit so release tooling has a starting point
+func tagInitialVersion(projectDir, version string) {
+	tag := "v" + strings.TrimPrefix(version, "v")
+	cmd := exec.Command("git", "-C", projectDir, "tag", "-a", tag, "-m", "Initial version "+tag)
+	if err := cmd.Run(); err != nil {
+		color.Red(i18n.T("✗ Failed to tag initial commit: %v"), err)
+	} else {
+		color.Green(i18n.T("✓ Tagged initial commit as %s."), tag)
+	}
+}
+
+// getDefaultGitignore returns github/gitignore's template for language and the URL it is fetched from
+func getDefaultGitignore(language string) (string, string) {
 	//download from this link https://raw.githubusercontent.com/github/gitignore/refs/heads/main/$language.gitignore
 	//make first letter uppercase and rest lowercase
 	langFormatted := utils.CapitalizeFirst(language)
+	if l, ok := lang.Resolve(language); ok {
+		langFormatted = l.GitignoreName()
+	}
 	url := fmt.Sprintf("https://raw.githubusercontent.com/github/gitignore/refs/heads/main/%s.gitignore", langFormatted)
 
-	resp, err := exec.Command("curl", "-sL", url).Output()
+	resp, err := exec.Command("curl", "-fsL", url).Output()
 	if err != nil {
-		return ""
+		return "", url
 	}
-	return string(resp)
+	return string(resp), url
 }
 
 // printLanguageSpecificSteps shows commands for specific language
-func printLanguageSpecificSteps(language string) {
-	switch language {
-	case "Go":
-		fmt.Println("  go mod tidy")
-		fmt.Println("  go build")
-	case "JavaScript", "TypeScript", "React":
-		fmt.Println("  npm install")
-		fmt.Println("  npm run dev")
-	case "Python":
-		fmt.Println("  pip install -r requirements.txt")
-		fmt.Println("  python main.py")
-	case "Rust":
-		fmt.Println("  cargo build")
-		fmt.Println("  cargo run")
+func printLanguageSpecificSteps(language, projectDir string) {
+	l, ok := lang.Resolve(language)
+	if !ok {
+		return
+	}
+	for _, step := range lang.Expand(l, projectDir, l.Hints) {
+		fmt.Printf("  %s\n", step)
 	}
 }
 
@@ -462,11 +1997,11 @@ func printLanguageSpecificSteps(language string) {
 func copyFileWithReplacements(src, dst, projectName, author string, mode os.FileMode, extraVars map[string]string) error {
 	content, err := os.ReadFile(src)
 	if err != nil {
-		return fmt.Errorf("failed to read %s: %w", src, err)
+		return i18n.Errorf("failed to read %s: %w", src, err)
 	}
 
 	// Skip placeholder replacement for binary files
-	if utils.IsBinary(content, maxBinaryCheckBytes) {
+	if utils.IsBinaryFile(src, content) {
 		return os.WriteFile(dst, content, mode)
 	}
 
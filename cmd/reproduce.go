@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/generate"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/org"
+	"github.com/kajvans/foundry/internal/project"
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/kajvans/foundry/internal/template"
+	"github.com/spf13/cobra"
+)
+
+// reproduceCmd regenerates a project from the pins in its stamp
+var reproduceCmd = &cobra.Command{
+	Use:   "reproduce <stamp-file|project-dir>",
+	Short: "Regenerate a project created with --reproducible",
+	Long: `Regenerate a project from a stamp written by 'foundry new --reproducible'.
+
+The template is fetched again (at the pinned commit for git sources) and must match
+the recorded hash. Files are rendered with the recorded variables, author and
+generator inputs, giving the same bytes as the original project before its
+post-create steps, hooks and git commit, which are not replayed.`,
+	Example: `  foundry reproduce ./my-api
+  foundry reproduce my-api/.foundry/stamp.yaml --path /tmp/check`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		targetPath, _ := cmd.Flags().GetString("path")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+
+		st := loadReproducibleStamp(args[0])
+		pin := st.Reproducible
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			exitWithError("Error loading config: %v", err)
+		}
+
+		tmpl := stampTemplate(st, cfg, noCache, pin.TemplateCommit)
+		defer runCleanups()
+
+		hash, err := template.Hash(tmpl.Path)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		if hash != pin.TemplateHash {
+			exitWithError("Template has changed since the project was created\n  expected hash: %s\n  current hash:  %s", pin.TemplateHash, hash)
+		}
+
+		projectDir := determineProjectDir(st.ProjectName, targetPath)
+		if _, err := os.Stat(projectDir); err == nil {
+			exitWithError("Directory '%s' already exists", projectDir)
+		}
+		if st.FoundryVersion != "" && st.FoundryVersion != version {
+			color.Yellow(i18n.T("⚠ Project was created with Foundry %s, this is %s; output may differ"), st.FoundryVersion, version)
+		}
+
+		vars, err := secretVars(tmpl.Path, st.Variables)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+
+		color.Cyan(i18n.T("Reproducing project '%s' into %s..."), st.ProjectName, projectDir)
+		if err := project.CreateFromTemplate(tmpl, st.ProjectName, projectDir, pin.Author, withBuiltins(vars, pin.Environment)); err != nil {
+			exitWithError("Error creating project: %v", err)
+		}
+
+		orgCfg, orgErr := org.Load(cfg)
+		if orgErr != nil {
+			orgCfg = &org.Config{}
+		}
+		runGenerators(st.Components, &generate.Context{
+			ProjectName:       st.ProjectName,
+			ProjectDir:        projectDir,
+			Language:          st.Language,
+			Framework:         st.Framework,
+			Version:           st.Variables["VERSION"],
+			Docker:            pin.Docker,
+			Author:            pin.Author,
+			License:           pin.Environment["LICENSE"],
+			Versions:          pin.Versions,
+			Variables:         vars,
+			Tools:             pin.Tools,
+			Date:              pin.Date,
+			DependencyUpdates: orgCfg.DependencyUpdates,
+		})
+		if orgErr != nil {
+			color.Yellow(i18n.T("⚠ %v"), orgErr)
+		} else if md := orgCfg.Metadata; md != nil && st.Files[md.Path()] != "" {
+			all := metadataVars(tmpl, tmpl.Name, st.Bundle, pin.Versions, vars)
+			if err := writeMetadata(md, projectDir, st.ProjectName, pin.Author, all); err != nil {
+				color.Yellow(i18n.T("⚠ Failed to write service metadata: %v"), err)
+			}
+		}
+
+		if err := project.SaveBase(projectDir, projectDir, st.Files); err != nil {
+			exitWithError("Failed to save merge base: %v", err)
+		}
+		// The stamp is copied as-is so it matches the original byte-for-byte
+		if err := stamp.Save(projectDir, st); err != nil {
+			exitWithError("Failed to write project stamp: %v", err)
+		}
+		writeSBOM(projectDir, st)
+		color.Green(i18n.T("\n✓ Project '%s' reproduced in %s"), st.ProjectName, projectDir)
+	},
+}
+
+// secretVars returns the recorded variables with the values of sensitive ones, which the stamp
+// records as references, read from the environment
+func secretVars(templateDir string, recorded map[string]string) (map[string]string, error) {
+	m, err := manifest.Load(templateDir)
+	if err != nil {
+		return nil, err
+	}
+	vars := map[string]string{}
+	for k, v := range recorded {
+		vars[k] = v
+	}
+	if unset := m.ResolveSecrets(vars); len(unset) > 0 {
+		return nil, i18n.Errorf("Sensitive variables are not recorded in the stamp; set %s in the environment", strings.Join(unset, ", "))
+	}
+	return vars, nil
+}
+
+// loadReproducibleStamp reads a stamp from a file or project directory and checks it has pins
+func loadReproducibleStamp(path string) *stamp.Stamp {
+	var st *stamp.Stamp
+	var err error
+	if info, statErr := os.Stat(path); statErr == nil && info.IsDir() {
+		st, err = stamp.Load(path)
+		if err == nil && st == nil {
+			exitWithError("%s is not a Foundry project (no %s)", path, stamp.Path(path))
+		}
+	} else {
+		st, err = stamp.LoadFile(path)
+	}
+	if err != nil {
+		exitWithError("Error reading stamp: %v", err)
+	}
+	if st.Reproducible == nil {
+		exitWithError("Project was not created with --reproducible; nothing is pinned to reproduce it from")
+	}
+	return st
+}
+
+func init() {
+	rootCmd.AddCommand(reproduceCmd)
+
+	reproduceCmd.Flags().StringP("path", "p", "", "Parent directory for the regenerated project (default: current directory)")
+	reproduceCmd.Flags().Bool("no-cache", false, "Clone git templates directly instead of through the local mirror cache")
+}
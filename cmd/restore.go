@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/kajvans/foundry/internal/backup"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// restoreCmd brings back files Foundry overwrote
+var restoreCmd = &cobra.Command{
+	Use:   "restore [backup] [file...]",
+	Short: "Restore files from a backup taken before Foundry overwrote them",
+	Long: `Restore files that foundry update or foundry add overwrote.
+
+Before overwriting a file, Foundry saves its previous version under
+.foundry/backup/<timestamp>/ in the project. Without arguments the most recent
+backup is restored in full; name a backup to pick an older one, and list files
+to restore only those. The versions being replaced are backed up again first,
+so a restore can be undone the same way.`,
+	Example: `  foundry restore --list
+  foundry restore
+  foundry restore 20261016T120000Z docker-compose.yml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		projectDir, _ := cmd.Flags().GetString("path")
+		list, _ := cmd.Flags().GetBool("list")
+
+		names, err := backup.List(projectDir)
+		if err != nil {
+			exitWithError("Error reading backups: %v", err)
+		}
+		if len(names) == 0 {
+			color.Yellow(i18n.T("No backups in %s"), backup.Root(projectDir))
+			return
+		}
+
+		if list {
+			for i := len(names) - 1; i >= 0; i-- {
+				files, err := backup.Files(projectDir, names[i])
+				if err != nil {
+					exitWithError("%v", err)
+				}
+				color.Cyan("%s", names[i])
+				for _, f := range files {
+					fmt.Printf("  %s\n", f)
+				}
+			}
+			return
+		}
+
+		name := names[len(names)-1]
+		if len(args) > 0 {
+			name, args = args[0], args[1:]
+		}
+		current := backup.New(projectDir)
+		restored, err := backup.Restore(projectDir, name, args, current)
+		for _, f := range restored {
+			color.Green(i18n.T("✓ Restored %s"), f)
+		}
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		printBackup(current)
+	},
+}
+
+// printBackup tells the user where overwritten files went, if any were saved
+func printBackup(bk *backup.Session) {
+	if len(bk.Saved()) == 0 {
+		return
+	}
+	color.Cyan(i18n.T("Previous versions of %s saved to backup %s (undo with: foundry restore %s)"),
+		strings.Join(bk.Saved(), ", "), bk.Name(), bk.Name())
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().StringP("path", "p", ".", "Project directory to restore files in")
+	restoreCmd.Flags().BoolP("list", "l", false, "List backups and the files they hold, newest first")
+}
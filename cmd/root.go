@@ -4,11 +4,18 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/lang"
+	"github.com/kajvans/foundry/internal/project"
+	"github.com/kajvans/foundry/internal/ui"
+	"github.com/kajvans/foundry/internal/utils"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
@@ -31,6 +38,14 @@ Color output:
   - Use --color to force colors (overrides NO_COLOR environment variable)
   - Set NO_COLOR environment variable to disable colors globally
 
+Prompts:
+  - Use --non-interactive (or set FOUNDRY_NON_INTERACTIVE=1) to never prompt; this is
+    automatic when stdin is not a terminal
+
+Accessibility:
+  - Use --plain (or set FOUNDRY_PLAIN=1) for plain-text output without color or symbols,
+    suited to screen readers; it is on by default when TERM=dumb
+
 Examples:
 
   # Create a new Go project using the default Go template
@@ -45,6 +60,9 @@ Examples:
   # View current configuration
   foundry config Go
 
+  # Define a shortcut: 'foundry web my-app' then runs 'foundry new --template react-starter my-app'
+  foundry config --alias web="new --template react-starter"
+
   # Disable colored output
   foundry template list --no-color
 `,
@@ -75,8 +93,26 @@ func Execute() {
 		}
 	}
 
-	err := rootCmd.Execute()
+	// Plain mode also covers output printed while flags are parsed
+	for _, arg := range os.Args {
+		if arg == "--plain" {
+			setPlain(true)
+		}
+	}
+
+	// Command aliases from the config are expanded before cobra sees the arguments
+	args, err := resolveAliases(os.Args[1:])
 	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+		os.Exit(1)
+	}
+	rootCmd.SetArgs(args)
+
+	// Commands returning errors with SilenceErrors set leave printing them to here
+	if c, err := rootCmd.ExecuteC(); err != nil {
+		if c.SilenceErrors {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+		}
 		os.Exit(1)
 	}
 }
@@ -89,11 +125,19 @@ func init() {
 	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
 	rootCmd.PersistentFlags().Bool("color", false, "Force colored output (overrides NO_COLOR env)")
 	rootCmd.PersistentFlags().String("config", "", "Path to config file (overrides default)")
+	rootCmd.PersistentFlags().Bool("non-interactive", false, "Never prompt; use defaults or fail when an answer is required")
+	rootCmd.PersistentFlags().Bool("plain", false, "Plain-text output without color or symbols, for screen readers and dumb terminals")
 
 	// Respect NO_COLOR environment variable unless explicitly overridden
 	if v, ok := os.LookupEnv("NO_COLOR"); ok && strings.TrimSpace(v) != "" {
 		color.NoColor = true
 	}
+	// Plain mode follows FOUNDRY_PLAIN, and dumb terminals cannot show more
+	if v := strings.TrimSpace(os.Getenv("FOUNDRY_PLAIN")); v != "" && v != "0" && v != "false" {
+		setPlain(true)
+	} else if os.Getenv("TERM") == "dumb" {
+		setPlain(true)
+	}
 
 	// Use PersistentPreRun to apply global flags before any command runs
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
@@ -103,6 +147,16 @@ func init() {
 			color.NoColor = nc
 		}
 
+		noPrompts = promptsDisabled(cmd)
+		if noPrompts {
+			ui.Disable()
+		}
+
+		if cmd.Flags().Changed("plain") {
+			on, _ := cmd.Flags().GetBool("plain")
+			setPlain(on)
+		}
+
 		// config path override
 		if cmd.Flags().Changed("config") {
 			path, _ := cmd.Flags().GetString("config")
@@ -110,6 +164,54 @@ func init() {
 				config.SetConfigPathOverride(path)
 			}
 		}
+
+		// user-defined languages and package manager preferences extend the built-in registry
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			i18n.SetLocale(i18n.Detect(""))
+			return
+		}
+		i18n.SetLocale(i18n.Detect(cfg.Locale))
+		lang.Apply(cfg.Languages)
+		lang.SetPackageManagerPreferences(cfg.PackageManagers)
+		if cfg.GlobalGitignore {
+			utils.SetGlobalIgnores(utils.LoadGlobalGitignore())
+		}
+		project.SkipJunk = !cfg.KeepJunkFiles
+		project.FollowSymlinks = cfg.FollowSymlinks
+		project.LineEndings = cfg.LineEndings
+		utils.SetFileExtensions(cfg.BinaryExtensions, cfg.TextExtensions)
+	}
+}
+
+// noPrompts is set when Foundry must not prompt: --non-interactive, FOUNDRY_NON_INTERACTIVE
+// or a stdin that is not a terminal
+var noPrompts bool
+
+// promptsDisabled reports whether the run must not prompt
+func promptsDisabled(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("non-interactive") {
+		off, _ := cmd.Flags().GetBool("non-interactive")
+		return off
+	}
+	if v := strings.TrimSpace(os.Getenv("FOUNDRY_NON_INTERACTIVE")); v != "" && v != "0" && v != "false" {
+		return true
+	}
+	return !isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
+
+// canPrompt reports whether commands may ask the user questions
+func canPrompt() bool {
+	return !noPrompts
+}
+
+// setPlain switches plain mode: messages spell out their symbols and nothing is colored,
+// including prompts
+func setPlain(on bool) {
+	i18n.SetPlain(on)
+	if on {
+		color.NoColor = true
+		ui.DisableColor()
 	}
 }
 
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/sbom"
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/spf13/cobra"
+)
+
+// sbomCmd describes what Foundry generated for a project
+var sbomCmd = &cobra.Command{
+	Use:   "sbom",
+	Short: "Print an SBOM of what Foundry generated for a project",
+	Long: `Print a software bill of materials for a Foundry project, built from its stamp.
+
+The document records the project's provenance: the template it was generated
+from (with its source, version and commit), the components Foundry's generators
+wrote (with the Foundry version), third-party files Foundry downloaded such as
+the default .gitignore (with their source URLs), and the SHA-256 of every file
+as Foundry wrote it.
+
+Two formats are supported: spdx (SPDX 2.3 JSON) and cyclonedx (CycloneDX 1.5
+JSON). Projects created with 'foundry new --sbom <format>' keep the document in
+.foundry/ and have it refreshed by foundry update and foundry add.`,
+	Example: `  foundry sbom
+  foundry sbom --format cyclonedx --output sbom.cdx.json
+  foundry sbom --path ./my-api`,
+	Run: func(cmd *cobra.Command, args []string) {
+		projectDir, _ := cmd.Flags().GetString("path")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		st, err := stamp.Load(projectDir)
+		if err != nil {
+			exitWithError("Error reading project stamp: %v", err)
+		}
+		if st == nil {
+			exitWithError("%s is not a Foundry project (no %s)", projectDir, stamp.Path(projectDir))
+		}
+		if format == "" {
+			format = st.SBOM
+		}
+		if format == "" {
+			format = sbom.FormatSPDX
+		}
+		if !slices.Contains(sbom.Formats, format) {
+			exitWithError("Unknown --format '%s' (use %s)", format, strings.Join(sbom.Formats, ", "))
+		}
+
+		data, err := sbom.Generate(st, format)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		if output == "" || output == "-" {
+			os.Stdout.Write(data)
+			return
+		}
+		if err := os.WriteFile(output, data, 0644); err != nil {
+			exitWithError("Failed to write %s: %v", output, err)
+		}
+		color.Green(i18n.T("✓ SBOM written to %s"), output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sbomCmd)
+
+	sbomCmd.Flags().StringP("path", "p", ".", "Project directory to describe")
+	sbomCmd.Flags().StringP("format", "f", "", "Document format: spdx or cyclonedx (default: the project's --sbom format, else spdx)")
+	sbomCmd.Flags().StringP("output", "o", "", "Write the document to a file instead of stdout")
+
+	_ = sbomCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return sbom.Formats, cobra.ShellCompDirectiveNoFileComp
+	})
+}
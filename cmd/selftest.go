@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/selftest"
+	"github.com/spf13/cobra"
+)
+
+// selftestCmd runs Foundry's main flows end to end in a scratch directory
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Run an end-to-end smoke test of Foundry's main flows",
+	Long: `Exercise the main flows end to end: set the author, save a fixture template,
+preview and create projects from it with variables, and update a project to a new
+template version while keeping a local edit.
+
+Every step runs this foundry binary (or --binary) in a temporary directory with its
+own config and home directory, so your config, templates and projects are never
+touched. No network access, git or language toolchains are needed. The run stops
+at the first failing step and exits with status 1.`,
+	Example: `  foundry selftest
+  foundry selftest --keep
+  foundry selftest --binary ./dist/foundry`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		binary, _ := cmd.Flags().GetString("binary")
+		keep, _ := cmd.Flags().GetBool("keep")
+		list, _ := cmd.Flags().GetBool("list")
+
+		if list {
+			for _, name := range selftest.Steps() {
+				fmt.Println(name)
+			}
+			return
+		}
+		if binary == "" {
+			exe, err := os.Executable()
+			if err != nil {
+				exitWithError("%v", err)
+			}
+			binary = exe
+		}
+
+		h, err := selftest.New(binary)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		if keep {
+			color.Cyan(i18n.T("Self-test directory: %s"), h.Dir)
+		} else {
+			defer h.Close()
+		}
+
+		failed := false
+		h.Run(func(r selftest.Result) {
+			if r.Err != nil {
+				failed = true
+				color.Red(i18n.T("✗ %s"), r.Name)
+				for _, line := range strings.Split(r.Err.Error(), "\n") {
+					fmt.Printf("    %s\n", line)
+				}
+				return
+			}
+			color.Green(i18n.T("✓ %s (%.1fs)"), r.Name, r.Duration.Seconds())
+		})
+		if failed {
+			if !keep {
+				h.Close()
+			}
+			exitWithError("Self-test failed")
+		}
+		color.Green(i18n.T("\n✓ All %d steps passed"), len(selftest.Steps()))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(selftestCmd)
+	selftestCmd.Flags().String("binary", "", "foundry binary to test (default: this one)")
+	selftestCmd.Flags().Bool("keep", false, "Keep the temporary directory for inspection")
+	selftestCmd.Flags().Bool("list", false, "List the steps without running them")
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/kajvans/foundry/internal/backup"
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/snippet"
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/kajvans/foundry/internal/ui"
+	"github.com/kajvans/foundry/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// snippetCmd groups the commands managing the snippet library
+var snippetCmd = &cobra.Command{
+	Use:   "snippet",
+	Short: "Manage small reusable file snippets",
+	Long: `Manage a library of small snippets, such as license headers, Makefile targets
+or GitHub Actions jobs, and insert them into existing files.
+
+Snippets use the same {{PLACEHOLDERS}} as templates. {{PROJECT_NAME}}, {{AUTHOR}}
+and {{YEAR}} are filled in automatically, as are the variables recorded for a
+Foundry project; others are given with --var or prompted for.`,
+}
+
+// snippetAddCmd saves a snippet
+var snippetAddCmd = &cobra.Command{
+	Use:   "add <name> [file]",
+	Short: "Save a snippet from a file or stdin",
+	Example: `  foundry snippet add mit-header ./header.txt --description "MIT license header"
+  printf 'docker-build:\n\tdocker build -t {{PROJECT_NAME}} .\n' | foundry snippet add docker-target`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		description, _ := cmd.Flags().GetString("description")
+		force, _ := cmd.Flags().GetBool("force")
+
+		if err := snippet.ValidateName(name); err != nil {
+			exitWithError("%v", err)
+		}
+		if snippet.Exists(name) && !force {
+			exitWithError("Snippet '%s' already exists (use --force to replace it)", name)
+		}
+
+		var content []byte
+		var err error
+		if len(args) == 2 && args[1] != "-" {
+			content, err = os.ReadFile(args[1])
+		} else {
+			content, err = io.ReadAll(os.Stdin)
+		}
+		if err != nil {
+			exitWithError("Cannot read snippet: %v", err)
+		}
+		if strings.TrimSpace(string(content)) == "" {
+			exitWithError("Snippet '%s' is empty", name)
+		}
+
+		s := &snippet.Snippet{Name: name, Description: description, Content: string(content)}
+		if err := snippet.Save(s); err != nil {
+			exitWithError("Failed to save snippet: %v", err)
+		}
+		color.Green(i18n.T("✓ Snippet '%s' saved"), name)
+		if placeholders := s.Placeholders(); len(placeholders) > 0 {
+			fmt.Printf(i18n.T("  Placeholders: %s\n"), strings.Join(placeholders, ", "))
+		}
+	},
+}
+
+// snippetListCmd lists the saved snippets
+var snippetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved snippets",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		snippets, err := snippet.List()
+		if err != nil {
+			exitWithError("Error reading snippets: %v", err)
+		}
+		if len(snippets) == 0 {
+			color.Yellow(i18n.T("No snippets saved. Add one with: foundry snippet add <name> <file>"))
+			return
+		}
+		for _, s := range snippets {
+			color.Cyan("%s", s.Name)
+			if s.Description != "" {
+				fmt.Printf("  %s\n", s.Description)
+			}
+			if placeholders := s.Placeholders(); len(placeholders) > 0 {
+				fmt.Printf(i18n.T("  Placeholders: %s\n"), strings.Join(placeholders, ", "))
+			}
+		}
+	},
+}
+
+// snippetInsertCmd inserts a snippet into an existing file
+var snippetInsertCmd = &cobra.Command{
+	Use:   "insert <name> <file>",
+	Short: "Insert a snippet into an existing file",
+	Long: `Insert a snippet into an existing file, with its placeholders filled in.
+
+The snippet is appended to the file, or placed after the first line containing
+--marker (before it with --before). A file that already contains the rendered
+snippet is left as is, so inserting twice changes nothing. The previous version
+of the file is backed up, and foundry restore brings it back.`,
+	Example: `  foundry snippet insert mit-header main.go --marker "package main" --before
+  foundry snippet insert docker-target Makefile
+  foundry snippet insert lint-job .github/workflows/ci.yml --marker "jobs:" --var GO_VERSION=1.22
+  foundry snippet insert mit-header --stdout`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectDir, _ := cmd.Flags().GetString("path")
+		marker, _ := cmd.Flags().GetString("marker")
+		before, _ := cmd.Flags().GetBool("before")
+		toStdout, _ := cmd.Flags().GetBool("stdout")
+		varsKV, _ := cmd.Flags().GetStringArray("var")
+		if len(args) < 2 && !toStdout {
+			exitWithError("Name the file to insert the snippet into, or use --stdout")
+		}
+		if before && marker == "" {
+			exitWithError("--before needs --marker")
+		}
+
+		s, err := snippet.Load(args[0])
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		vars, err := utils.ParseVars(varsKV)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		text, err := renderSnippet(s, projectDir, vars)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		if toStdout {
+			fmt.Print(text)
+			return
+		}
+
+		rel := args[1]
+		file := filepath.Join(projectDir, rel)
+		existing, err := os.ReadFile(file)
+		if err != nil {
+			exitWithError("Cannot read %s: %v", rel, err)
+		}
+		if strings.Contains(string(existing), strings.TrimSuffix(text, "\n")) {
+			color.Green(i18n.T("✓ %s already contains snippet '%s'"), rel, s.Name)
+			return
+		}
+		content, err := snippet.Insert(string(existing), text, marker, before)
+		if err != nil {
+			exitWithError("%s: %v", rel, err)
+		}
+
+		bk := backup.New(projectDir)
+		if err := bk.Save(rel); err != nil {
+			exitWithError("%v", err)
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		if err := os.WriteFile(file, []byte(content), info.Mode().Perm()); err != nil {
+			exitWithError("Failed to write %s: %v", rel, err)
+		}
+		color.Green(i18n.T("✓ Inserted snippet '%s' into %s"), s.Name, rel)
+		printBackup(bk)
+	},
+}
+
+// renderSnippet fills in the snippet's placeholders. Values come from vars, then the project
+// stamp and config; the rest are prompted for, or are an error when prompting is not possible.
+func renderSnippet(s *snippet.Snippet, projectDir string, vars map[string]string) (string, error) {
+	values := map[string]string{"YEAR": strconv.Itoa(time.Now().Year())}
+	absDir, _ := filepath.Abs(projectDir)
+	projectName := filepath.Base(absDir)
+	if st, err := stamp.Load(projectDir); err == nil && st != nil {
+		projectName = st.ProjectName
+		// Sensitive values are recorded as references and are asked for instead
+		for k, v := range st.Variables {
+			if v != manifest.SecretRef(k) {
+				values[k] = v
+			}
+		}
+	}
+	for k, v := range vars {
+		values[k] = v
+	}
+	author := ""
+	if cfg, err := config.LoadConfig(); err == nil {
+		author = cfg.Author
+	}
+
+	// Generated values ({{DATE}}, {{UUID}}, ...) are fresh for every insert
+	generated, err := utils.GeneratedVars(time.Now())
+	if err != nil {
+		return "", err
+	}
+	for k, v := range generated {
+		if _, ok := values[k]; !ok {
+			values[k] = v
+		}
+	}
+
+	builtins := utils.ProjectNameVars(projectName)
+	builtins["AUTHOR"] = author
+	for _, name := range s.Placeholders() {
+		_, builtin := builtins[name]
+		if _, ok := values[name]; ok || builtin {
+			continue
+		}
+		if !canPrompt() {
+			return "", i18n.Errorf("snippet variable '%s' is required; pass it with --var %s=<value>", name, name)
+		}
+		value, err := ui.Input(name+":", "", true)
+		if err != nil {
+			return "", i18n.Errorf("input cancelled")
+		}
+		values[name] = value
+	}
+	return utils.ReplacePlaceholders(s.Content, projectName, author, values), nil
+}
+
+func init() {
+	rootCmd.AddCommand(snippetCmd)
+	snippetCmd.AddCommand(snippetAddCmd)
+	snippetCmd.AddCommand(snippetListCmd)
+	snippetCmd.AddCommand(snippetInsertCmd)
+
+	snippetAddCmd.Flags().StringP("description", "d", "", "Short description shown by snippet list")
+	snippetAddCmd.Flags().BoolP("force", "f", false, "Replace an existing snippet with the same name")
+
+	snippetInsertCmd.Flags().StringP("path", "p", ".", "Directory the file path is relative to")
+	snippetInsertCmd.Flags().StringP("marker", "m", "", "Insert after the first line containing this text instead of appending")
+	snippetInsertCmd.Flags().Bool("before", false, "Insert before the marker line instead of after it")
+	snippetInsertCmd.Flags().Bool("stdout", false, "Print the rendered snippet instead of inserting it")
+	snippetInsertCmd.Flags().StringArray("var", []string{}, "Snippet variable in key=value form (repeatable)")
+}
@@ -5,12 +5,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/convert"
+	"github.com/kajvans/foundry/internal/export"
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/index"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/org"
+	"github.com/kajvans/foundry/internal/source"
 	"github.com/kajvans/foundry/internal/template"
+	"github.com/kajvans/foundry/internal/ui"
+	"github.com/kajvans/foundry/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -26,11 +37,14 @@ You can add, list, and remove templates.`,
 
 // templateAddCmd adds a new template
 var templateAddCmd = &cobra.Command{
-	Use:   "add <name> <path>",
-	Short: "Add a new template from a directory",
+	Use:   "add <name> <path|url>",
+	Short: "Add a new template from a directory or remote source",
 	Long: `Scan a directory and save it as a reusable template.
 	The language will be automatically detected based on file extensions.
 
+	Instead of a directory you can give a git URL or an archive (.tar.gz, .zip; path or URL).
+	Foundry keeps a copy under ~/.foundry/templates and 'template update' refreshes it.
+
 	You can override the detected language tag with --language to label frameworks like React or Vue.
 
 	Example:
@@ -38,33 +52,41 @@ var templateAddCmd = &cobra.Command{
 	foundry template add react-starter ~/templates/react-app --description "React with TypeScript" --language React`,
 	Args: cobra.MinimumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
+		journalChanges()
 		name := args[0]
-		path := args[1]
-
-		// Validate that 'path' exists and is a directory
-		if info, err := os.Stat(path); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: cannot access path: %v\n", err)
-			os.Exit(1)
-		} else if !info.IsDir() {
-			fmt.Fprintf(os.Stderr, "Error: path is not a directory: %s\n", path)
-			os.Exit(1)
-		}
-
+		location := args[1]
 		description, _ := cmd.Flags().GetString("description")
 		overrideLang, _ := cmd.Flags().GetString("language")
 
 		// Validate template name
 		if err := template.ValidateName(name); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+
+		// Remote sources are copied into Foundry's own template directory
+		src, err := source.Parse(location)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		checksum, _ := cmd.Flags().GetString("sha256")
+		if checksum != "" && !source.SupportsChecksum(src) {
+			fmt.Fprintln(os.Stderr, i18n.T("Error: --sha256 only applies to archive and bucket sources"))
+			os.Exit(1)
+		}
+		path, err := templateDir(src, name, checksum)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
 			os.Exit(1)
 		}
 
 		// TODO: Support an optional ignore file (e.g., .foundryignore) when scanning to exclude files/dirs.
 		// Scan and create template
-		color.Cyan("Scanning template directory: %s", path)
+		color.Cyan(i18n.T("Scanning template directory: %s"), path)
 		tmpl, err := template.ScanTemplate(name, path, description)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error scanning template: %v\n", err)
+			fmt.Fprintf(os.Stderr, i18n.T("Error scanning template: %v\n"), err)
 			os.Exit(1)
 		}
 
@@ -73,28 +95,549 @@ var templateAddCmd = &cobra.Command{
 			tmpl.Language = strings.TrimSpace(overrideLang)
 		}
 
-		color.Green("✓ Detected language: %s", tmpl.Language)
-		color.Green("✓ Found %d files", len(tmpl.Files))
+		// Flags take precedence over manifest metadata
+		if homepage, _ := cmd.Flags().GetString("homepage"); homepage != "" {
+			tmpl.Homepage = homepage
+		}
+		if maintainer, _ := cmd.Flags().GetString("maintainer"); maintainer != "" {
+			tmpl.Maintainer = maintainer
+		}
+		if screenshots, _ := cmd.Flags().GetStringArray("screenshot"); len(screenshots) > 0 {
+			tmpl.Screenshots = screenshots
+		}
+		labels, err := labelFlags(cmd, tmpl.Labels)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		scope, err := scopeFlag(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		engine, err := engineFlag(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+
+		color.Green(i18n.T("✓ Detected language: %s"), tmpl.Language)
+		if tmpl.Framework != "" {
+			color.Green(i18n.T("✓ Detected framework: %s"), tmpl.Framework)
+		}
+		color.Green(i18n.T("✓ Found %d files"), len(tmpl.Files))
 
 		// Save to config
-		configTmpl := config.Template{
-			Name:        tmpl.Name,
-			Path:        tmpl.Path,
-			Language:    tmpl.Language,
-			Description: tmpl.Description,
-			Files:       tmpl.Files,
-		}
+		configTmpl := savedTemplate(tmpl, src, checksum)
+		configTmpl.Labels = labels
+		configTmpl.Scope = scope
+		configTmpl.Engine = engine
+		configTmpl.PinnedLanguage = strings.TrimSpace(overrideLang) != ""
 
 		if err := config.AddTemplate(configTmpl); err != nil {
-			fmt.Fprintf(os.Stderr, "Error saving template: %v\n", err)
+			fmt.Fprintf(os.Stderr, i18n.T("Error saving template: %v\n"), err)
+			os.Exit(1)
+		}
+
+		color.Green(i18n.T("\n✓ Template '%s' saved successfully!"), name)
+		fmt.Printf(i18n.T("  Path: %s\n"), tmpl.Path)
+		fmt.Printf(i18n.T("  Language: %s\n"), tmpl.Language)
+		if tmpl.Description != "" {
+			fmt.Printf(i18n.T("  Description: %s\n"), tmpl.Description)
+		}
+
+		setDefault, _ := cmd.Flags().GetBool("set-default")
+		offerLanguageDefault(name, tmpl.Language, setDefault)
+	},
+}
+
+// savedTemplate returns the config entry for a scanned template fetched from src
+func savedTemplate(tmpl *template.Template, src source.Source, checksum string) config.Template {
+	return config.Template{
+		Name:        tmpl.Name,
+		Path:        tmpl.Path,
+		Language:    tmpl.Language,
+		Description: tmpl.Description,
+		Framework:   tmpl.Framework,
+		Files:       tmpl.Files,
+		Source:      remoteLocation(src),
+		SHA256:      checksum,
+
+		Homepage:          tmpl.Homepage,
+		Maintainer:        tmpl.Maintainer,
+		MinFoundryVersion: tmpl.MinFoundryVersion,
+		Screenshots:       tmpl.Screenshots,
+		Labels:            tmpl.Labels,
+		Deprecated:        tmpl.Deprecated,
+		Fingerprint:       tmpl.Fingerprint,
+	}
+}
+
+// templateDir returns the directory a template from src is scanned in.
+// Remote sources are installed into the managed templates directory first.
+func templateDir(src source.Source, name, checksum string) (string, error) {
+	if !source.IsRemote(src) {
+		dir, _, err := src.Fetch(source.Options{})
+		return dir, err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	dir, err := managedTemplateDir(name)
+	if err != nil {
+		return "", err
+	}
+	opts := sourceOptions(cfg, false)
+	opts.SHA256 = checksum
+	color.Cyan(i18n.T("Fetching %s template from %s..."), src.Kind(), src.Location())
+	if err := source.Install(src, dir, opts); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// managedTemplateDir returns where Foundry keeps its copy of a remote template
+func managedTemplateDir(name string) (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "templates"), 0755); err != nil {
+		return "", i18n.Errorf("cannot create templates directory: %w", err)
+	}
+	return filepath.Join(dir, "templates", name), nil
+}
+
+// remoteLocation returns the location to record for a template, empty for local directories
+func remoteLocation(src source.Source) string {
+	if source.IsRemote(src) {
+		return src.Location()
+	}
+	return ""
+}
+
+// offerLanguageDefault sets the new template as its language's default when requested,
+// or asks the user when that language has no default yet
+func offerLanguageDefault(name, language string, setDefault bool) {
+	if language == "" || language == "Unknown" {
+		return
+	}
+
+	if !setDefault {
+		current, err := config.GetLanguageDefault(language)
+		if err != nil || current != "" {
+			return
+		}
+		cfg, err := config.LoadConfig()
+		if err != nil || !cfg.Interactive || !canPrompt() {
+			color.Yellow(i18n.T("\nTip: no default template for %s yet. Set this one with: foundry config %s %s"), language, language, name)
+			return
+		}
+		if setDefault, err = ui.Confirm(i18n.Sprintf("No default template for %s yet. Use '%s' as the default?", language, name), true); err != nil || !setDefault {
+			return
+		}
+	}
+
+	if err := config.SetLanguageDefault(language, name); err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Error setting default for %s: %v\n"), language, err)
+		os.Exit(1)
+	}
+	color.Green(i18n.T("✓ Set default template for %s: %s"), language, name)
+}
+
+// templateUpdateCmd refreshes a saved template from its source
+var templateUpdateCmd = &cobra.Command{
+	Use:   "update <name>",
+	Short: "Refresh a saved template from its source",
+	Long: `Fetch a remote template (git, archive, ...) again and rescan it, or rescan a
+local template directory so its file list, framework and manifest metadata are current.
+
+The language tag and description you set are kept, and so are labels set with --label
+unless foundry.yaml now sets the same key.
+
+Afterwards the files added and removed since the previous scan are listed, and for remote
+templates also the files whose contents changed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		journalChanges()
+		name := args[0]
+
+		saved, err := config.GetTemplate(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+
+		// Remote templates are fetched over the old copy, so its contents are read first;
+		// of a local directory only the stored file list is known
+		before := map[string]string{}
+		for _, rel := range saved.Files {
+			before[rel] = ""
+		}
+		if saved.Source != "" {
+			before = template.Digests(saved.Path, saved.Files)
+			src, err := source.Parse(saved.Source)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+				os.Exit(1)
+			}
+			// A new pin replaces the stored one once the download matches it
+			if cmd.Flags().Changed("sha256") {
+				saved.SHA256, _ = cmd.Flags().GetString("sha256")
+			}
+			if _, err := templateDir(src, name, saved.SHA256); err != nil {
+				fmt.Fprintf(os.Stderr, i18n.T("Error updating template: %v\n"), err)
+				os.Exit(1)
+			}
+		}
+
+		color.Cyan(i18n.T("Scanning template directory: %s"), saved.Path)
+		tmpl, err := template.ScanTemplate(name, saved.Path, saved.Description)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error scanning template: %v\n"), err)
+			os.Exit(1)
+		}
+
+		after := map[string]string{}
+		for _, rel := range tmpl.Files {
+			after[rel] = ""
+		}
+		if saved.Source != "" {
+			after = template.Digests(tmpl.Path, tmpl.Files)
+		}
+		changes := template.CompareFiles(before, after)
+
+		applyScan(saved, tmpl)
+		if saved.Labels, err = labelFlags(cmd, saved.Labels); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if cmd.Flags().Changed("scope") {
+			if saved.Scope, err = scopeFlag(cmd); err != nil {
+				fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+				os.Exit(1)
+			}
+		}
+		if cmd.Flags().Changed("engine") {
+			if saved.Engine, err = engineFlag(cmd); err != nil {
+				fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+				os.Exit(1)
+			}
+		}
+
+		if err := config.AddTemplate(*saved); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error saving template: %v\n"), err)
+			os.Exit(1)
+		}
+		color.Green(i18n.T("✓ Template '%s' updated (%d files)"), name, len(saved.Files))
+		printFileChanges(changes, saved.Source != "")
+	},
+}
+
+// printFileChanges summarizes how a template's files changed in an update and lists them.
+// Changed contents are only known when contentsKnown is set.
+func printFileChanges(c template.FileChanges, contentsKnown bool) {
+	if c.Empty() {
+		if contentsKnown {
+			fmt.Println(i18n.T("  No files added, removed or changed"))
+		} else {
+			fmt.Println(i18n.T("  No files added or removed"))
+		}
+		return
+	}
+	if contentsKnown {
+		fmt.Printf(i18n.T("  %d added, %d removed, %d changed\n"), len(c.Added), len(c.Removed), len(c.Changed))
+	} else {
+		fmt.Printf(i18n.T("  %d added, %d removed\n"), len(c.Added), len(c.Removed))
+	}
+	for _, rel := range c.Added {
+		color.Green("    + %s", filepath.ToSlash(rel))
+	}
+	for _, rel := range c.Removed {
+		color.Red("    - %s", filepath.ToSlash(rel))
+	}
+	for _, rel := range c.Changed {
+		color.Yellow("    ~ %s", filepath.ToSlash(rel))
+	}
+}
+
+// applyScan refreshes a saved template with what a rescan of its directory found.
+// The language tag stays as the user set it, and labels set with --label are kept
+// unless the manifest now sets the same key.
+func applyScan(saved *config.Template, tmpl *template.Template) {
+	saved.Description = tmpl.Description
+	saved.Framework = tmpl.Framework
+	saved.Files = tmpl.Files
+	if tmpl.Homepage != "" {
+		saved.Homepage = tmpl.Homepage
+	}
+	if tmpl.Maintainer != "" {
+		saved.Maintainer = tmpl.Maintainer
+	}
+	if len(tmpl.Screenshots) > 0 {
+		saved.Screenshots = tmpl.Screenshots
+	}
+	saved.MinFoundryVersion = tmpl.MinFoundryVersion
+	saved.Deprecated = tmpl.Deprecated
+	saved.Fingerprint = tmpl.Fingerprint
+	for k, v := range tmpl.Labels {
+		if saved.Labels == nil {
+			saved.Labels = map[string]string{}
+		}
+		saved.Labels[k] = v
+	}
+}
+
+// rescanIfChanged rescans a saved template whose directory changed since it was last scanned,
+// so its file list, language and manifest metadata do not go stale after edits. Templates saved
+// before fingerprints were recorded are rescanned quietly once and keep their language.
+func rescanIfChanged(tmpl *config.Template) {
+	fingerprint, err := template.Fingerprint(tmpl.Path)
+	if err != nil || fingerprint == tmpl.Fingerprint {
+		return
+	}
+	scanned, err := template.ScanTemplate(tmpl.Name, tmpl.Path, tmpl.Description)
+	if err != nil {
+		color.Yellow(i18n.T("⚠ Template '%s' changed but could not be rescanned: %v"), tmpl.Name, err)
+		return
+	}
+
+	known := tmpl.Fingerprint != ""
+	applyScan(tmpl, scanned)
+	if known && !tmpl.PinnedLanguage {
+		tmpl.Language = scanned.Language
+	}
+	if err := config.AddTemplate(*tmpl); err != nil {
+		color.Yellow(i18n.T("⚠ Failed to save rescanned template: %v"), err)
+		return
+	}
+	if known {
+		color.Cyan(i18n.T("Template '%s' changed since it was last scanned; refreshed its metadata (%s, %d files)"), tmpl.Name, tmpl.Language, len(tmpl.Files))
+	}
+}
+
+// templateMoveCmd relocates a template directory, or re-points a template at its new location
+var templateMoveCmd = &cobra.Command{
+	Use:   "move <name> <new-path>",
+	Short: "Move a template's directory, or point a template at where it now lives",
+	Long: `Move the directory of a saved template to new-path, then rescan it there so its
+file list, framework and manifest metadata are current.
+
+If the directory is no longer at its registered path, because you reorganized your
+templates folder yourself, the template is only re-pointed to new-path, which must
+exist. --repoint does that even when the old directory is still there.
+
+Templates added from a remote source live in Foundry's own template directory and
+cannot be moved.
+
+	Example:
+  foundry template move go-api ~/templates/backend/go-api
+  foundry template move go-api ~/templates/backend/go-api --repoint`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		journalChanges()
+		name := args[0]
+		repoint, _ := cmd.Flags().GetBool("repoint")
+
+		saved, err := config.GetTemplate(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if saved.Source != "" {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: '%s' is fetched from %s and kept in Foundry's template directory; it cannot be moved\n"), name, saved.Source)
+			os.Exit(1)
+		}
+		newPath, err := filepath.Abs(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if newPath == saved.Path {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: template '%s' is already at %s\n"), name, newPath)
+			os.Exit(1)
+		}
+
+		// A directory that is already gone was moved by hand, so only the path changes
+		moved := false
+		if _, err := os.Stat(saved.Path); err == nil && !repoint {
+			if err := template.Move(saved.Path, newPath); err != nil {
+				fmt.Fprintf(os.Stderr, i18n.T("Error moving template: %v\n"), err)
+				os.Exit(1)
+			}
+			moved = true
+			color.Green(i18n.T("✓ Moved %s to %s"), saved.Path, newPath)
+		} else if info, err := os.Stat(newPath); err != nil || !info.IsDir() {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %s is not a directory\n"), newPath)
+			os.Exit(1)
+		}
+
+		color.Cyan(i18n.T("Scanning template directory: %s"), newPath)
+		tmpl, err := template.ScanTemplate(name, newPath, saved.Description)
+		if err != nil {
+			// Put the directory back so the saved template keeps working
+			if moved {
+				if undoErr := template.Move(newPath, saved.Path); undoErr != nil {
+					color.Red(i18n.T("✗ Failed to move the template back to %s: %v"), saved.Path, undoErr)
+				}
+			}
+			fmt.Fprintf(os.Stderr, i18n.T("Error scanning template: %v\n"), err)
+			os.Exit(1)
+		}
+		saved.Path = tmpl.Path
+		applyScan(saved, tmpl)
+
+		if err := config.AddTemplate(*saved); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error saving template: %v\n"), err)
+			os.Exit(1)
+		}
+		color.Green(i18n.T("✓ Template '%s' now points to %s (%d files)"), name, saved.Path, len(saved.Files))
+	},
+}
+
+// labelFlags applies the --label key=value flags to labels and returns the result.
+// An empty value removes the label.
+func labelFlags(cmd *cobra.Command, labels map[string]string) (map[string]string, error) {
+	kvs, _ := cmd.Flags().GetStringArray("label")
+	set, err := utils.ParseVars(kvs)
+	if err != nil {
+		return nil, err
+	}
+	return mergePairs(labels, set), nil
+}
+
+// mergePairs returns values with set applied, where an empty value removes the key; nil when
+// nothing is left
+func mergePairs(values, set map[string]string) map[string]string {
+	if len(set) == 0 {
+		return values
+	}
+	result := make(map[string]string, len(values)+len(set))
+	for k, v := range values {
+		result[k] = v
+	}
+	for k, v := range set {
+		if v == "" {
+			delete(result, k)
+		} else {
+			result[k] = v
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// scopeFlag returns the validated --scope flag as stored in the config: personal, the default,
+// is stored as empty
+func scopeFlag(cmd *cobra.Command) (string, error) {
+	scope, _ := cmd.Flags().GetString("scope")
+	if err := config.ValidateScope(scope); err != nil {
+		return "", err
+	}
+	if scope == config.ScopePersonal {
+		return "", nil
+	}
+	return scope, nil
+}
+
+// engineFlag returns the validated --engine flag, with aliases such as gotemplate resolved; empty
+// leaves the choice to foundry.yaml
+func engineFlag(cmd *cobra.Command) (string, error) {
+	engine, _ := cmd.Flags().GetString("engine")
+	engine = utils.EngineName(engine)
+	if engine != "" && !slices.Contains(utils.Engines, engine) {
+		return "", i18n.Errorf("unknown engine '%s' (use %s)", engine, strings.Join(utils.Engines, ", "))
+	}
+	return engine, nil
+}
+
+// printLabels prints a template's labels as key=value, sorted by key
+func printLabels(format string, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, k := range utils.SortedKeys(labels) {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+	fmt.Printf(i18n.T(format), strings.Join(pairs, ", "))
+}
+
+// templateSetVarCmd saves values for a template's variables in the config
+var templateSetVarCmd = &cobra.Command{
+	Use:   "set-var <name> [key=value...]",
+	Short: "Save values for a template's variables, used by foundry new",
+	Long: `Save values for variables of a saved template in the config, such as your email
+or company name, so foundry new uses them instead of asking. Values given with --var,
+--vars-stdin, --var-file or by a bundle still win.
+
+An empty value (key=) removes a saved value; without key=value pairs the saved values
+are listed. Values are checked against the template's foundry.yaml, and sensitive
+variables cannot be saved: set their environment variable instead.`,
+	Example: `  foundry template set-var react-starter author_email=me@corp.com
+  foundry template set-var react-starter author_email=
+  foundry template set-var react-starter`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		saved, err := config.GetTemplate(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
 			os.Exit(1)
 		}
+		if len(args) == 1 {
+			if len(saved.Variables) == 0 {
+				fmt.Printf(i18n.T("No saved variables for '%s'\n"), name)
+				return
+			}
+			for _, k := range utils.SortedKeys(saved.Variables) {
+				fmt.Printf("%s=%s\n", k, saved.Variables[k])
+			}
+			return
+		}
 
-		color.Green("\n✓ Template '%s' saved successfully!", name)
-		fmt.Printf("  Path: %s\n", tmpl.Path)
-		fmt.Printf("  Language: %s\n", tmpl.Language)
-		if description != "" {
-			fmt.Printf("  Description: %s\n", description)
+		set, err := utils.ParseVars(args[1:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		m, err := manifest.Load(saved.Path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if m != nil {
+			for _, v := range m.Variables {
+				value, ok := set[v.Name]
+				if !ok || value == "" {
+					continue
+				}
+				if v.Sensitive {
+					fmt.Fprintf(os.Stderr, i18n.T("Error: %s is sensitive and is not saved in the config; set the %s environment variable instead\n"), v.Name, v.Name)
+					os.Exit(1)
+				}
+				if err := v.Check(value); err != nil {
+					fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+					os.Exit(1)
+				}
+			}
+		}
+
+		journalChanges()
+		if err := config.SetTemplateVariables(name, mergePairs(saved.Variables, set)); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error saving template: %v\n"), err)
+			os.Exit(1)
+		}
+		for _, k := range utils.SortedKeys(set) {
+			if set[k] == "" {
+				color.Green(i18n.T("✓ Removed saved value of %s"), k)
+			} else {
+				color.Green(i18n.T("✓ Saved %s=%s for '%s'"), k, set[k], name)
+			}
 		}
 	},
 }
@@ -103,20 +646,57 @@ var templateAddCmd = &cobra.Command{
 var templateListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all saved templates",
-	Long:  `Display all templates that have been saved and are available for use with 'foundry new'.`,
+	Long: `Display all templates that have been saved and are available for use with 'foundry new',
+together with the shared templates from the org config (listed as shared/<name>).
+
+Use --scope personal or --scope shared to list only one kind, and --filter key=value to list
+only templates with that label; repeat --filter to require several.
+
+	Example:
+  foundry template list --filter team=payments --filter tier=1
+  foundry template list --scope shared`,
 	Run: func(cmd *cobra.Command, args []string) {
-		templates, err := config.ListTemplates()
+		scope, _ := cmd.Flags().GetString("scope")
+		if scope != "all" {
+			if err := config.ValidateScope(scope); err != nil {
+				fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+				os.Exit(1)
+			}
+		}
+
+		saved, err := config.ListTemplates()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading templates: %v\n", err)
+			fmt.Fprintf(os.Stderr, i18n.T("Error loading templates: %v\n"), err)
 			os.Exit(1)
 		}
+		templates := append(saved, sharedTemplates()...)
 
 		if len(templates) == 0 {
-			fmt.Println("No templates saved yet.")
-			fmt.Println("\nAdd a template with: foundry template add <name> <path>")
+			fmt.Println(i18n.T("No templates saved yet."))
+			fmt.Println(i18n.T("\nAdd a template with: foundry template add <name> <path>"))
 			return
 		}
 
+		filters, _ := cmd.Flags().GetStringArray("filter")
+		filter, err := utils.ParseVars(filters)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(filter) > 0 || scope != "all" {
+			matched := templates[:0]
+			for _, t := range templates {
+				if t.MatchLabels(filter) && (scope == "all" || t.EffectiveScope() == scope) {
+					matched = append(matched, t)
+				}
+			}
+			templates = matched
+			if len(templates) == 0 {
+				fmt.Println(i18n.T("No templates match the filter."))
+				return
+			}
+		}
+
 		// Sorting and quiet options
 		sortBy, _ := cmd.Flags().GetString("sort")
 		quiet, _ := cmd.Flags().GetBool("quiet")
@@ -141,67 +721,170 @@ var templateListCmd = &cobra.Command{
 			return
 		}
 
-		color.New(color.Bold).Printf("Saved Templates (%d):\n\n", len(templates))
+		color.New(color.Bold).Printf(i18n.T("Saved Templates (%d):\n\n"), len(templates))
 		for i, t := range templates {
 			fmt.Printf("%d. %s\n", i+1, t.Name)
-			fmt.Printf("   Language: %s\n", t.Language)
-			fmt.Printf("   Path: %s\n", t.Path)
+			fmt.Printf(i18n.T("   Language: %s\n"), t.Language)
+			if t.Framework != "" {
+				fmt.Printf(i18n.T("   Framework: %s\n"), t.Framework)
+			}
+			if t.Path != "" {
+				fmt.Printf(i18n.T("   Path: %s\n"), t.Path)
+			}
+			if t.Source != "" {
+				fmt.Printf(i18n.T("   Source: %s\n"), t.Source)
+			}
+			if t.Scope != "" {
+				fmt.Printf(i18n.T("   Scope: %s\n"), i18n.T(t.Scope))
+			}
 			if t.Description != "" {
-				fmt.Printf("   Description: %s\n", t.Description)
+				fmt.Printf(i18n.T("   Description: %s\n"), t.Description)
 			}
-			fmt.Printf("   Files: %d\n", len(t.Files))
+			printLabels("   Labels: %s\n", t.Labels)
+
+			// Shared templates from the org config are fetched when used; there is nothing local to check
+			if t.Path == "" {
+				fmt.Println()
+				continue
+			}
+			fmt.Printf(i18n.T("   Files: %d\n"), len(t.Files))
 
 			// Check if this is a default template for any language
 			defaultLangs := config.IsDefaultTemplate(t.Name)
 			if len(defaultLangs) > 0 {
-				color.Cyan("   ⭐ Default for: %v", defaultLangs)
+				color.Cyan(i18n.T("   ⭐ Default for: %v"), defaultLangs)
+			}
+			if d := templateDeprecation(&t); d != nil {
+				color.Yellow(i18n.T("   ⚠  Deprecated: %s"), deprecationNotice(d))
 			}
 
 			// Check if path still exists
 			if _, err := os.Stat(t.Path); os.IsNotExist(err) {
-				color.Yellow("   ⚠  Warning: Path no longer exists")
+				color.Yellow(i18n.T("   ⚠  Warning: Path no longer exists"))
 			}
 			fmt.Println()
 		}
 	},
 }
 
+// lookupTemplate returns the saved template called name, or for shared/<name> the shared
+// template from the org config
+func lookupTemplate(name string) (*config.Template, error) {
+	if !strings.HasPrefix(name, org.SharedPrefix) {
+		return config.GetTemplate(name)
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	orgCfg, err := org.Load(cfg)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := orgCfg.Shared(name)
+	if err != nil {
+		return nil, err
+	}
+	tmpl := shared.Template()
+	return &tmpl, nil
+}
+
+// sharedTemplates returns the shared templates of the org config; a config that cannot be
+// loaded is reported and treated as having none
+func sharedTemplates() []config.Template {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil
+	}
+	orgCfg, err := org.Load(cfg)
+	if err != nil {
+		color.Yellow(i18n.T("⚠ %v"), err)
+		return nil
+	}
+	templates := make([]config.Template, 0, len(orgCfg.Templates))
+	for _, s := range orgCfg.Templates {
+		templates = append(templates, s.Template())
+	}
+	return templates
+}
+
 // templateRemoveCmd removes a template
 var templateRemoveCmd = &cobra.Command{
 	Use:   "remove <name>",
 	Short: "Remove a saved template",
-	Long:  `Remove a template from the saved templates list. This does not delete the actual files.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Remove a template from the saved templates list. This does not delete the actual files,
+except for Foundry's own copy of a template added from a remote source.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		journalChanges()
 		name := args[0]
 		// Warn if template is default for any language
+		// Languages with a ranked fallback simply move on to the next template
 		force, _ := cmd.Flags().GetBool("force")
-		if langs := config.IsDefaultTemplate(name); len(langs) > 0 && !force {
-			fmt.Fprintf(os.Stderr, "Error: template '%s' is the default for: %v\nUse --force to remove it anyway.\n", name, langs)
+		var stranded []string
+		for _, lang := range config.IsDefaultTemplate(name) {
+			if next := fallbackAfter(lang, name); next != "" {
+				color.Yellow(i18n.T("Default for %s will fall back to '%s'"), lang, next)
+			} else {
+				stranded = append(stranded, lang)
+			}
+		}
+		if len(stranded) > 0 && !force {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: template '%s' is the default for: %v\nUse --force to remove it anyway.\n"), name, stranded)
 			os.Exit(1)
 		}
 
+		tmpl, err := config.GetTemplate(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
 		if err := config.RemoveTemplate(name); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
 			os.Exit(1)
 		}
 
-		color.Green("✓ Template '%s' removed successfully", name)
+		// Copies of remote templates belong to Foundry, so they go as well
+		if tmpl.Source != "" {
+			if dir, err := managedTemplateDir(name); err == nil && dir == tmpl.Path {
+				os.RemoveAll(dir)
+			}
+		}
+
+		color.Green(i18n.T("✓ Template '%s' removed successfully"), name)
 	},
 }
 
+// fallbackAfter returns the next existing template in a language's ranked defaults, skipping name
+func fallbackAfter(language, name string) string {
+	defaults, err := config.GetLanguageDefaults(language)
+	if err != nil {
+		return ""
+	}
+	for _, candidate := range defaults {
+		if candidate == name {
+			continue
+		}
+		if _, err := config.GetTemplate(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
 // templateShowCmd shows details of a specific template
 var templateShowCmd = &cobra.Command{
 	Use:   "show <name>",
 	Short: "Show details of a specific template",
-	Long:  `Display detailed information about a saved template, including all files.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Display detailed information about a saved template, including all files.
+Shared templates from the org config are shown with shared/<name>; they have no local files.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		name := args[0]
 
-		tmpl, err := config.GetTemplate(name)
+		tmpl, err := lookupTemplate(name)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
 			os.Exit(1)
 		}
 
@@ -218,24 +901,64 @@ var templateShowCmd = &cobra.Command{
 		}
 
 		if !filesOnly {
-			fmt.Printf("Template: %s\n", tmpl.Name)
-			fmt.Printf("Language: %s\n", tmpl.Language)
-			fmt.Printf("Path: %s\n", tmpl.Path)
+			fmt.Printf(i18n.T("Template: %s\n"), tmpl.Name)
+			fmt.Printf(i18n.T("Language: %s\n"), tmpl.Language)
+			if tmpl.Framework != "" {
+				fmt.Printf(i18n.T("Framework: %s\n"), tmpl.Framework)
+			}
+			if tmpl.Path != "" {
+				fmt.Printf(i18n.T("Path: %s\n"), tmpl.Path)
+			}
+			if tmpl.Source != "" {
+				fmt.Printf(i18n.T("Source: %s\n"), tmpl.Source)
+			}
+			if tmpl.Scope != "" {
+				fmt.Printf(i18n.T("Scope: %s\n"), i18n.T(tmpl.Scope))
+			}
+			if tmpl.Engine != "" {
+				fmt.Printf(i18n.T("Engine: %s\n"), tmpl.Engine)
+			}
+			if tmpl.SHA256 != "" {
+				fmt.Printf(i18n.T("SHA-256: %s\n"), tmpl.SHA256)
+			}
 			if tmpl.Description != "" {
-				fmt.Printf("Description: %s\n", tmpl.Description)
+				fmt.Printf(i18n.T("Description: %s\n"), tmpl.Description)
+			}
+			if tmpl.Homepage != "" {
+				fmt.Printf(i18n.T("Homepage: %s\n"), tmpl.Homepage)
+			}
+			if tmpl.Maintainer != "" {
+				fmt.Printf(i18n.T("Maintainer: %s\n"), tmpl.Maintainer)
+			}
+			if tmpl.MinFoundryVersion != "" {
+				fmt.Printf(i18n.T("Min Foundry version: %s\n"), tmpl.MinFoundryVersion)
+			}
+			printLabels("Labels: %s\n", tmpl.Labels)
+			printLabels("Variables: %s\n", tmpl.Variables)
+			if d := templateDeprecation(tmpl); d != nil {
+				color.Yellow(i18n.T("Deprecated: %s"), deprecationNotice(d))
+			}
+			if len(tmpl.Screenshots) > 0 {
+				fmt.Println(i18n.T("Screenshots:"))
+				for _, s := range tmpl.Screenshots {
+					fmt.Printf("  - %s\n", s)
+				}
 			}
 		}
 
 		// Check if this is a default template for any language
 		defaultLangs := config.IsDefaultTemplate(name)
 		if len(defaultLangs) > 0 {
-			color.Cyan("Default for: %v\n", defaultLangs)
+			color.Cyan(i18n.T("Default for: %v\n"), defaultLangs)
+		}
+		if tmpl.Path == "" {
+			return
 		}
 
 		// Check if path exists
 		if !filesOnly {
 			if _, err := os.Stat(tmpl.Path); os.IsNotExist(err) {
-				color.Yellow("\n⚠  Warning: Template path no longer exists")
+				color.Yellow(i18n.T("\n⚠  Warning: Template path no longer exists"))
 			}
 		}
 
@@ -243,7 +966,7 @@ var templateShowCmd = &cobra.Command{
 			return
 		}
 
-		fmt.Printf("\nFiles (%d):\n", len(tmpl.Files))
+		fmt.Printf(i18n.T("\nFiles (%d):\n"), len(tmpl.Files))
 
 		// Group files by directory
 		dirMap := make(map[string][]string)
@@ -255,9 +978,11 @@ var templateShowCmd = &cobra.Command{
 			dirMap[dir] = append(dirMap[dir], filepath.Base(f))
 		}
 
-		// Print grouped files
-		for dir, files := range dirMap {
+		// Print grouped files, directories and files sorted; (root) sorts first
+		for _, dir := range utils.SortedKeys(dirMap) {
 			fmt.Printf("\n  %s/\n", dir)
+			files := dirMap[dir]
+			sort.Strings(files)
 			for _, file := range files {
 				fmt.Printf("    - %s\n", file)
 			}
@@ -265,6 +990,472 @@ var templateShowCmd = &cobra.Command{
 	},
 }
 
+// templateExportCmd converts a saved template for use in other platforms
+var templateExportCmd = &cobra.Command{
+	Use:   "export <name>",
+	Short: "Export a template for use in other platforms",
+	Long: `Export a saved template in another scaffolding format.
+
+With --backstage, writes a Backstage Software Template: template.yaml with parameters for the
+project name, owner and the template's variables, and a skeleton/ directory with the template
+files. Foundry placeholders become Backstage expressions (e.g. {{PROJECT_NAME}} becomes
+${{ values.name }}), and a catalog-info.yaml is added when the template has none.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		backstage, _ := cmd.Flags().GetBool("backstage")
+		if !backstage {
+			exitWithError("choose an export format (--backstage)")
+		}
+
+		tmpl, err := config.GetTemplate(name)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		m, err := manifest.Load(tmpl.Path)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" {
+			output = name + "-backstage"
+		}
+		if _, err := os.Stat(output); err == nil {
+			exitWithError("output directory %s already exists", output)
+		}
+		if err := export.Backstage(tmpl, m, output); err != nil {
+			os.RemoveAll(output)
+			exitWithError("export failed: %v", err)
+		}
+
+		color.Green(i18n.T("✓ Exported '%s' as a Backstage Software Template to %s"), name, output)
+		fmt.Println(i18n.T("  Register template.yaml in your Backstage catalog to offer it in the scaffolder"))
+	},
+}
+
+// templateConvertCmd turns a cookiecutter template or yeoman generator into a Foundry template
+var templateConvertCmd = &cobra.Command{
+	Use:   "convert <path>",
+	Short: "Convert a cookiecutter template or yeoman generator into a Foundry template",
+	Long: `Convert a template written for another scaffolder into a native Foundry template
+with a generated foundry.yaml, ready for 'foundry template add'.
+
+Cookiecutter templates (cookiecutter.json next to a {{cookiecutter.project_slug}} directory):
+the contents of that directory become the template, {{ cookiecutter.name }} placeholders in
+files and paths become {{NAME}}, and cookiecutter.json entries become variables, with lists
+as choices. Common names map to built-ins: project_name to {{PROJECT_NAME}}, project_slug to
+{{PROJECT_NAME_SNAKE}}, author and full_name to {{AUTHOR}}.
+
+Yeoman generators (generators/app/templates): the templates become the template, <%= name %>
+placeholders become {{NAME}}, a leading underscore is dropped from file names (_package.json)
+and the prompts in generators/app/index.js become variables.
+
+Jinja or EJS logic such as conditionals and filters, and cookiecutter hooks, are copied as
+they are and listed at the end so you can rewrite them by hand.`,
+	Example: `  foundry template convert ./cookiecutter-pypackage
+  foundry template convert ./generator-webapp --output ~/templates/webapp`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" {
+			output = filepath.Base(filepath.Clean(path)) + "-foundry"
+		}
+		if _, err := os.Stat(output); err == nil {
+			exitWithError("output directory %s already exists", output)
+		}
+
+		result, err := convert.Convert(path, output)
+		if err != nil {
+			os.RemoveAll(output)
+			exitWithError("conversion failed: %v", err)
+		}
+
+		color.Green(i18n.T("✓ Converted %s template with %d files to %s"), result.Kind, result.Files, output)
+		if len(result.Manifest.Variables) > 0 {
+			names := make([]string, len(result.Manifest.Variables))
+			for i, v := range result.Manifest.Variables {
+				names[i] = v.Name
+			}
+			fmt.Printf(i18n.T("  Variables: %s\n"), strings.Join(names, ", "))
+		}
+		if len(result.Skipped) > 0 {
+			color.Yellow(i18n.T("⚠ Variables without a Foundry equivalent were left out: %s"), strings.Join(result.Skipped, ", "))
+		}
+		if len(result.Manual) > 0 {
+			color.Yellow(i18n.T("⚠ These paths still hold %s logic Foundry cannot convert; rewrite them by hand:"), result.Kind)
+			for _, p := range result.Manual {
+				fmt.Printf("  - %s\n", p)
+			}
+		}
+		fmt.Printf(i18n.T("  Review %s, then save the template with: foundry template add %s %s\n"),
+			filepath.Join(output, manifest.FileName), result.Manifest.Name, output)
+	},
+}
+
+// templatePublishCmd adds a saved template to the shared templates of the org config
+var templatePublishCmd = &cobra.Command{
+	Use:   "publish <name>",
+	Short: "Publish a saved template to the org config's shared templates",
+	Long: `Add a saved template to the shared templates of the org config, so everyone using that
+config can create projects from it as shared/<name>. Publishing again replaces the entry.
+
+Only templates marked shared can be published, so personal templates do not end up in the
+shared registry by accident; mark one with 'foundry template update <name> --scope shared'.
+The template must come from a source others can fetch (git URL, archive or bucket), and the
+org config must be a local file, e.g. a checkout of the repository it is shared from.
+
+	Example:
+  foundry template update go-api --scope shared
+  foundry template publish go-api --to ~/src/platform/foundry-org.yaml`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		tmpl, err := config.GetTemplate(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if tmpl.EffectiveScope() != config.ScopeShared {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: '%s' is a personal template; if it is meant for everyone, mark it with: foundry template update %s --scope shared\n"), name, name)
+			os.Exit(1)
+		}
+		if tmpl.Source == "" {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: '%s' is a local directory others cannot fetch; add the template from its git URL or archive to publish it\n"), name)
+			os.Exit(1)
+		}
+
+		location, _ := cmd.Flags().GetString("to")
+		if location == "" {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, i18n.T("Error loading config: %v\n"), err)
+				os.Exit(1)
+			}
+			location = org.Location(cfg)
+		}
+		if location == "" {
+			fmt.Fprintln(os.Stderr, i18n.T("Error: no org config is set up; pass --to <file> or run foundry config --org-config <path>"))
+			os.Exit(1)
+		}
+
+		shared := org.SharedTemplate{
+			Name:        tmpl.Name,
+			Source:      tmpl.Source,
+			SHA256:      tmpl.SHA256,
+			Language:    tmpl.Language,
+			Description: tmpl.Description,
+			Labels:      tmpl.Labels,
+
+			Homepage:          tmpl.Homepage,
+			Maintainer:        tmpl.Maintainer,
+			MinFoundryVersion: tmpl.MinFoundryVersion,
+			Screenshots:       tmpl.Screenshots,
+		}
+		if err := org.Publish(location, shared); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		color.Green(i18n.T("✓ Published '%s' to %s as %s"), name, location, org.SharedPrefix+name)
+	},
+}
+
+// templateBrowseCmd lists the templates of a static template index and registers one
+var templateBrowseCmd = &cobra.Command{
+	Use:   "browse [name]",
+	Short: "Browse a template index and register templates from it",
+	Long: `Browse a static template index: a YAML or JSON file, served by any web server or kept in a
+git repository as foundry-index.yaml, listing templates with their sources and metadata.
+
+Without a name you pick a template from the index, or in a non-interactive shell the index
+is listed. With a name that template is registered directly, like 'foundry template add'
+with the source, checksum and metadata the index gives. Use --as to save it under another name.
+
+The index is given with --index or set once with 'foundry config --template-index <url>'.
+
+	Example:
+  foundry template browse --index https://templates.example.com/foundry-index.yaml
+  foundry template browse go-api --index git@github.com:acme/templates.git
+  foundry template browse react-starter --as react`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error loading config: %v\n"), err)
+			os.Exit(1)
+		}
+		location, _ := cmd.Flags().GetString("index")
+		if location == "" {
+			location = cfg.TemplateIndex
+		}
+		if location == "" {
+			fmt.Fprintln(os.Stderr, i18n.T("Error: no template index is set up; pass --index <url> or run foundry config --template-index <url>"))
+			os.Exit(1)
+		}
+
+		idx, err := index.Load(location, sourceOptions(cfg, false))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(idx.Templates) == 0 {
+			fmt.Println(i18n.T("The index lists no templates."))
+			return
+		}
+
+		var entry *index.Entry
+		if len(args) == 1 {
+			if entry, err = idx.Entry(args[0]); err != nil {
+				fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+				os.Exit(1)
+			}
+		} else if !canPrompt() {
+			printIndex(idx)
+			return
+		} else {
+			names := make([]string, len(idx.Templates))
+			for i, e := range idx.Templates {
+				names[i] = e.Name
+			}
+			choice, err := ui.Select(i18n.T("Template to register:"), names, 0, func(i int) string {
+				e := idx.Templates[i]
+				if e.Language == "" {
+					return e.Description
+				}
+				return strings.TrimSpace(e.Language + "  " + e.Description)
+			})
+			if err != nil {
+				fmt.Fprintln(os.Stderr, i18n.T("Error: selection cancelled"))
+				os.Exit(1)
+			}
+			entry = &idx.Templates[choice]
+		}
+
+		name, _ := cmd.Flags().GetString("as")
+		if name == "" {
+			name = entry.Name
+		}
+		if err := template.ValidateName(name); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if _, err := config.GetTemplate(name); err == nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: template '%s' already exists; save it under another name with --as\n"), name)
+			os.Exit(1)
+		}
+		journalChanges()
+
+		src, err := source.Parse(entry.Source)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if entry.SHA256 != "" && !source.SupportsChecksum(src) {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: the index pins a sha256 for '%s', but only archive and bucket sources can be checked\n"), entry.Name)
+			os.Exit(1)
+		}
+		path, err := templateDir(src, name, entry.SHA256)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		tmpl, err := template.ScanTemplate(name, path, entry.Description)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error scanning template: %v\n"), err)
+			os.Exit(1)
+		}
+
+		// The index fills in what the template's own foundry.yaml leaves out
+		saved := savedTemplate(tmpl, src, entry.SHA256)
+		if entry.Language != "" {
+			saved.Language = entry.Language
+			saved.PinnedLanguage = true
+		}
+		if saved.Homepage == "" {
+			saved.Homepage = entry.Homepage
+		}
+		if saved.Maintainer == "" {
+			saved.Maintainer = entry.Maintainer
+		}
+		for k, v := range entry.Labels {
+			if _, ok := saved.Labels[k]; !ok {
+				if saved.Labels == nil {
+					saved.Labels = map[string]string{}
+				}
+				saved.Labels[k] = v
+			}
+		}
+		if err := config.AddTemplate(saved); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error saving template: %v\n"), err)
+			os.Exit(1)
+		}
+
+		color.Green(i18n.T("✓ Template '%s' saved from the index"), name)
+		fmt.Printf(i18n.T("  Source: %s\n"), entry.Source)
+		fmt.Printf(i18n.T("  Language: %s\n"), saved.Language)
+		if saved.Description != "" {
+			fmt.Printf(i18n.T("  Description: %s\n"), saved.Description)
+		}
+		setDefault, _ := cmd.Flags().GetBool("set-default")
+		offerLanguageDefault(name, saved.Language, setDefault)
+	},
+}
+
+// printIndex lists the templates of an index
+func printIndex(idx *index.Index) {
+	color.New(color.Bold).Printf(i18n.T("Templates in the index (%d):\n\n"), len(idx.Templates))
+	for _, e := range idx.Templates {
+		fmt.Println(e.Name)
+		if e.Language != "" {
+			fmt.Printf(i18n.T("   Language: %s\n"), e.Language)
+		}
+		fmt.Printf(i18n.T("   Source: %s\n"), e.Source)
+		if e.Description != "" {
+			fmt.Printf(i18n.T("   Description: %s\n"), e.Description)
+		}
+		printLabels("   Labels: %s\n", e.Labels)
+		fmt.Println()
+	}
+	fmt.Println(i18n.T("Register one with: foundry template browse <name>"))
+}
+
+// templateStatsCmd summarizes the saved templates
+var templateStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize the template collection for cleanup",
+	Long: `Summarize the saved templates: how many there are per language and which language has no
+default, how much disk space they take, which paths no longer exist, and which templates are
+used most, least or never. Usage is counted by 'foundry new' from the moment it is recorded.
+
+	Example:
+  foundry template stats
+  foundry template stats --top 10`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		top, _ := cmd.Flags().GetInt("top")
+		if top < 1 {
+			fmt.Fprintln(os.Stderr, i18n.T("Error: --top must be at least 1"))
+			os.Exit(1)
+		}
+
+		templates, err := config.ListTemplates()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Error loading templates: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(templates) == 0 {
+			fmt.Println(i18n.T("No templates saved yet."))
+			return
+		}
+
+		var total int64
+		var broken []config.Template
+		byLanguage := map[string][]string{}
+		for _, t := range templates {
+			language := t.Language
+			if language == "" {
+				language = i18n.T("(unknown)")
+			}
+			byLanguage[language] = append(byLanguage[language], t.Name)
+			if _, err := os.Stat(t.Path); err != nil {
+				broken = append(broken, t)
+				continue
+			}
+			total += dirSize(t.Path)
+		}
+
+		color.New(color.Bold).Printf(i18n.T("Templates: %d\n"), len(templates))
+		fmt.Printf(i18n.T("Disk usage: %s\n"), formatSize(total))
+
+		color.Cyan(i18n.T("\nBy language:"))
+		for _, language := range utils.SortedKeys(byLanguage) {
+			def, _ := config.GetLanguageDefault(language)
+			if def == "" {
+				def = i18n.T("no default")
+			} else {
+				def = i18n.Sprintf("default %s", def)
+			}
+			fmt.Printf("  %-14s %3d  (%s)\n", language, len(byLanguage[language]), def)
+		}
+
+		if len(broken) > 0 {
+			color.Yellow(i18n.T("\n⚠ Paths that no longer exist (%d):"), len(broken))
+			for _, t := range broken {
+				fmt.Printf("  %s: %s\n", t.Name, t.Path)
+			}
+		}
+
+		var used, unused []config.Template
+		for _, t := range templates {
+			if t.Uses > 0 {
+				used = append(used, t)
+			} else {
+				unused = append(unused, t)
+			}
+		}
+		sort.SliceStable(used, func(i, j int) bool {
+			if used[i].Uses != used[j].Uses {
+				return used[i].Uses > used[j].Uses
+			}
+			return used[i].LastUsed > used[j].LastUsed
+		})
+		if len(used) > 0 {
+			color.Cyan(i18n.T("\nMost used:"))
+			printTemplateUses(used[:utils.Min(top, len(used))])
+		}
+		// The least used are the tail of the ranking, least used first, without repeating the most used
+		if len(used) > top {
+			var least []config.Template
+			for i := len(used) - 1; i >= top && len(least) < top; i-- {
+				least = append(least, used[i])
+			}
+			color.Cyan(i18n.T("\nLeast used:"))
+			printTemplateUses(least)
+		}
+		if len(unused) > 0 {
+			color.Cyan(i18n.T("\nNever used (%d):"), len(unused))
+			for _, t := range unused {
+				fmt.Printf("  %s\n", t.Name)
+			}
+		}
+	},
+}
+
+// printTemplateUses lists templates with how often and when they were last used
+func printTemplateUses(templates []config.Template) {
+	for _, t := range templates {
+		fmt.Printf(i18n.T("  %s: %d project(s), last on %s\n"), t.Name, t.Uses, t.LastUsed)
+	}
+}
+
+// dirSize returns the total size of the regular files under dir
+func dirSize(dir string) int64 {
+	var size int64
+	_ = fsys.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// formatSize renders a byte count for people: 512 B, 1.5 KB, 3.2 MB
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 func init() {
 	rootCmd.AddCommand(templateCmd)
 
@@ -273,17 +1464,50 @@ func init() {
 	templateCmd.AddCommand(templateListCmd)
 	templateCmd.AddCommand(templateRemoveCmd)
 	templateCmd.AddCommand(templateShowCmd)
+	templateCmd.AddCommand(templateUpdateCmd)
+	templateCmd.AddCommand(templateExportCmd)
+	templateCmd.AddCommand(templatePublishCmd)
+	templateCmd.AddCommand(templateConvertCmd)
+	templateCmd.AddCommand(templateMoveCmd)
+	templateCmd.AddCommand(templateBrowseCmd)
+	templateCmd.AddCommand(templateStatsCmd)
+	templateCmd.AddCommand(templateSetVarCmd)
+
+	templateBrowseCmd.Flags().String("index", "", "Template index to read: URL of a .yaml/.json file, git repository or path (default: template_index from config)")
+	templateBrowseCmd.Flags().String("as", "", "Save the template under this name instead of its name in the index")
+	templateBrowseCmd.Flags().Bool("set-default", false, "Make the template the default for its language")
+	templateStatsCmd.Flags().Int("top", 5, "How many templates to list as most and least used")
 
 	// Flags for add command
 	templateAddCmd.Flags().StringP("description", "d", "", "Description of the template")
 	templateAddCmd.Flags().StringP("language", "l", "", "Override detected language/framework tag (e.g., React, Vue)")
+	templateAddCmd.Flags().String("sha256", "", "Expected SHA-256 of an archive or bucket source, checked on add and update")
+	templateUpdateCmd.Flags().String("sha256", "", "Pin a new SHA-256 for the template's archive or bucket source")
+	templateAddCmd.Flags().Bool("set-default", false, "Make this template the default for its language")
+	templateAddCmd.Flags().String("homepage", "", "Homepage URL for the template (overrides foundry.yaml)")
+	templateAddCmd.Flags().String("maintainer", "", "Maintainer of the template (overrides foundry.yaml)")
+	templateAddCmd.Flags().StringArray("screenshot", []string{}, "Screenshot or asset reference (repeatable, overrides foundry.yaml)")
+	templateAddCmd.Flags().StringArray("label", []string{}, "Label the template with key=value, e.g. team=payments (repeatable, overrides foundry.yaml)")
+	templateUpdateCmd.Flags().StringArray("label", []string{}, "Set a label with key=value; an empty value removes it (repeatable)")
+	templateAddCmd.Flags().String("scope", config.ScopePersonal, "Visibility: personal, or shared to allow publishing it to the org config")
+	templateUpdateCmd.Flags().String("scope", config.ScopePersonal, "Change the visibility: personal or shared")
+	templateAddCmd.Flags().String("engine", "", "Render with this engine instead of the one in foundry.yaml: placeholders, go or handlebars (for templates from other scaffolding tools)")
+	templateUpdateCmd.Flags().String("engine", "", "Change the saved rendering engine: placeholders, go or handlebars; empty uses foundry.yaml's")
 	// Flags for show command
 	templateShowCmd.Flags().Bool("files-only", false, "Only print the file list")
 	templateShowCmd.Flags().Bool("summary", false, "Only print template metadata (no files)")
 	templateShowCmd.Flags().Bool("json", false, "Output template details in JSON format")
+	templateExportCmd.Flags().Bool("backstage", false, "Export as a Backstage Software Template")
+	templateExportCmd.Flags().StringP("output", "o", "", "Directory to write the export to (default: <name>-backstage)")
+
+	templateConvertCmd.Flags().StringP("output", "o", "", "Directory to write the Foundry template to (default: <dir>-foundry)")
 	templateRemoveCmd.Flags().Bool("force", false, "Remove even if this template is set as default for a language")
 
 	// Flags for list command
 	templateListCmd.Flags().String("sort", "name", "Sort templates by: name or language")
 	templateListCmd.Flags().Bool("quiet", false, "Only print template names (one per line)")
+	templatePublishCmd.Flags().String("to", "", "Org config file to publish to (default: the configured org config)")
+	templateMoveCmd.Flags().Bool("repoint", false, "Only change the registered path; leave the directory where it is")
+	templateListCmd.Flags().String("scope", "all", "Only list personal or shared templates (all, personal, shared)")
+	templateListCmd.Flags().StringArray("filter", []string{}, "Only list templates with label key=value (repeatable; all must match)")
 }
@@ -0,0 +1,463 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/kajvans/foundry/internal/backup"
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/diff"
+	"github.com/kajvans/foundry/internal/generate"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/org"
+	"github.com/kajvans/foundry/internal/project"
+	"github.com/kajvans/foundry/internal/source"
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/kajvans/foundry/internal/ui"
+	"github.com/kajvans/foundry/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// recordedAnswers returns the variables recorded in the stamp that the template still allows,
+// and the names of the variables left to ask for: those the template declared since, and those
+// whose recorded answer no longer passes its checks, such as a choice that was removed.
+// Computed variables are left out, so they are evaluated again with the template's expressions.
+func recordedAnswers(m *manifest.Manifest, recorded map[string]string) (map[string]string, []string) {
+	vars := map[string]string{}
+	for k, v := range recorded {
+		vars[k] = v
+	}
+	if m == nil {
+		return vars, nil
+	}
+	for _, c := range m.Computed {
+		delete(vars, c.Name)
+	}
+	if unset := m.ResolveSecrets(vars); len(unset) > 0 {
+		color.Yellow(i18n.T("⚠ Sensitive variables are read from the environment, which does not set %s"), strings.Join(unset, ", "))
+	}
+	var missing []string
+	for _, v := range m.Variables {
+		value, ok := vars[v.Name]
+		if !ok {
+			missing = append(missing, v.Name)
+			continue
+		}
+		if err := v.Check(value); err != nil {
+			color.Yellow(i18n.T("⚠ The recorded value '%s' of %s is no longer allowed: %v"), m.Display(v.Name, value), v.Name, err)
+			delete(vars, v.Name)
+			missing = append(missing, v.Name)
+		}
+	}
+	if len(missing) > 0 {
+		color.Cyan(i18n.T("Variables to answer for this template version: %s"), strings.Join(missing, ", "))
+	}
+	return vars, missing
+}
+
+// updateCmd re-renders a project's template and applies the changes to the project
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Bring a Foundry project up to date with its template",
+	Long: `Render the project's template again, with the variables and components recorded
+in .foundry/stamp.yaml, and apply the result to the project.
+
+Files are compared by content digest against what Foundry last wrote:
+  - identical files are left alone (unchanged)
+  - files the project does not have yet are created
+  - files not edited since Foundry wrote them are updated
+  - files edited in the project are kept when the template did not change them;
+    when it did, both changes are merged (JSON/YAML deep merge, missing lines
+    appended to .gitignore-style files, three-way merge for other text) and
+    whatever cannot be merged cleanly is a conflict
+  - files deleted from the project are not recreated
+  - paths matching the stamp's protected globs are never created or changed
+
+Before anything is written, the template's changes since the project was created
+or last updated are shown (changelog entries from foundry.yaml, or the git log
+between the recorded and the current commit) and you confirm the update.
+
+The answers recorded when the project was created are reused. Only variables the
+template added since, or whose recorded answer it no longer allows, are asked for;
+without a terminal they get their defaults.
+
+Each conflict is shown as a diff and you choose: keep yours, take the template's,
+edit a merge of both, skip, or always keep yours for that file (remembered in the
+stamp). Without a terminal, or with --non-interactive, conflicts are left alone.
+
+Running update twice in a row is a no-op. Post-create steps and hooks are not run.`,
+	Example: `  foundry update
+  foundry update --path ./my-api --dry-run`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		projectDir, _ := cmd.Flags().GetString("path")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+
+		st, err := stamp.Load(projectDir)
+		if err != nil {
+			exitWithError("Error reading project stamp: %v", err)
+		}
+		if st == nil {
+			exitWithError("%s is not a Foundry project (no %s)", projectDir, stamp.Path(projectDir))
+		}
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			exitWithError("Error loading config: %v", err)
+		}
+		interactive := cfg.Interactive && canPrompt()
+
+		tmpl := stampTemplate(st, cfg, noCache, "")
+		defer runCleanups()
+
+		if err := checkMinFoundryVersion(tmpl.Path, ""); err != nil {
+			exitWithError("%v", err)
+		}
+		m, err := manifest.Load(tmpl.Path)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		// Updating stays possible, but the project should plan a move to the replacement
+		if m != nil && m.Deprecated != nil {
+			color.Yellow(i18n.T("⚠ Template '%s' is deprecated: %s"), tmpl.Name, deprecationNotice(m.Deprecated))
+		}
+		commit := source.Commit(tmpl.Path)
+		if printTemplateChanges(st, m, tmpl.Path, commit) && interactive && !dryRun {
+			if apply, err := ui.Confirm(i18n.T("Apply the update?"), true); err != nil || !apply {
+				color.Yellow(i18n.T("Update cancelled"))
+				return
+			}
+		}
+
+		vars, missing := recordedAnswers(m, st.Variables)
+		author := cfg.Author
+		if st.Reproducible != nil {
+			author = st.Reproducible.Author
+		}
+		// Values generated at creation ({{DATE}}, {{UUID}}, ...) stay as they were; projects
+		// created before they existed get them now. Allowed environment variables are read again.
+		builtins, err := templateBuiltins(cfg, tmpl, m, st.Generated)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+
+		// Variables the template declared since the project was created
+		if err := promptManifestVariables(m, vars, defaultValues(st.ProjectName, author, builtins), !interactive); err != nil {
+			exitWithError("%v", err)
+		}
+		if !interactive {
+			for _, name := range missing {
+				fmt.Printf("  %s = %q\n", name, m.Display(name, vars[name]))
+			}
+		}
+		tmpDir, err := os.MkdirTemp("", "foundry-update-")
+		if err != nil {
+			exitWithError("Failed to create temporary directory: %v", err)
+		}
+		cleanups = append(cleanups, func() { os.RemoveAll(tmpDir) })
+		rendered := filepath.Join(tmpDir, st.ProjectName)
+
+		color.Cyan(i18n.T("Rendering template for '%s'..."), st.ProjectName)
+		if err := project.CreateFromTemplate(tmpl, st.ProjectName, rendered, author, withBuiltins(vars, builtins)); err != nil {
+			exitWithError("Error rendering template: %v", err)
+		}
+		orgCfg, orgErr := org.Load(cfg)
+		genCtx := generateContext(cfg, tmpl, st.ProjectName, rendered, vars)
+		if orgErr == nil {
+			genCtx.DependencyUpdates = orgCfg.DependencyUpdates
+		}
+		for _, name := range st.Components {
+			if _, err := generate.Run(name, genCtx); err != nil {
+				color.Yellow(i18n.T("⚠ %v"), err)
+			}
+		}
+		// Service metadata is refreshed only in projects created with it
+		if orgErr != nil {
+			color.Yellow(i18n.T("⚠ %v"), orgErr)
+		} else if md := orgCfg.Metadata; md != nil && st.Files[md.Path()] != "" {
+			all := metadataVars(tmpl, tmpl.Name, st.Bundle, genCtx.Versions, vars)
+			if err := writeMetadata(md, rendered, st.ProjectName, author, all); err != nil {
+				color.Yellow(i18n.T("⚠ Failed to render service metadata: %v"), err)
+			}
+		}
+
+		bk := backup.New(projectDir)
+		opts := project.SyncOptions{Previous: st.Files, Protected: st.Protected, DryRun: dryRun, Backup: bk}
+		if m != nil {
+			opts.Merge = m.Merge
+		}
+		result, err := project.Sync(rendered, projectDir, opts)
+		if err != nil {
+			exitWithError("Error updating project: %v", err)
+		}
+		resolveConflicts(st, result, rendered, projectDir, bk, interactive && !dryRun)
+		printSyncResult(result, dryRun)
+		printBackup(bk)
+		if dryRun {
+			fmt.Printf(i18n.T("\nDry run: %s\n"), result.Summary())
+			return
+		}
+
+		st.Variables = m.Recorded(vars)
+		st.Generated = generatedVars(builtins)
+		st.Files = result.Digests
+		st.TemplateVersion = ""
+		if m != nil {
+			st.TemplateVersion = m.Version
+		}
+		st.TemplateCommit = commit
+		st.FoundryVersion = version
+		if err := stamp.Save(projectDir, st); err != nil {
+			color.Yellow(i18n.T("⚠ Failed to update project stamp: %v"), err)
+		}
+		writeSBOM(projectDir, st)
+		if err := project.SaveBase(rendered, projectDir, st.Files); err != nil {
+			color.Yellow(i18n.T("⚠ Failed to save merge base: %v"), err)
+		}
+		if result.Count(project.Conflict) > 0 {
+			color.Yellow(i18n.T("\n⚠ Project updated with conflicts: %s"), result.Summary())
+			return
+		}
+		color.Green(i18n.T("\n✓ Project updated: %s"), result.Summary())
+	},
+}
+
+// printTemplateChanges shows what changed in the template since the project was created or last
+// updated: the manifest changelog when both versions are known, else the git log between the
+// recorded and the current commit. It reports whether there was anything to show.
+func printTemplateChanges(st *stamp.Stamp, m *manifest.Manifest, templateDir, commit string) bool {
+	if m != nil && m.Version != "" && st.TemplateVersion != "" {
+		if m.Version == st.TemplateVersion {
+			return false
+		}
+		color.Magenta(i18n.T("\nTemplate changes %s → %s:"), st.TemplateVersion, m.Version)
+		entries := m.ChangesSince(st.TemplateVersion)
+		if len(entries) == 0 {
+			fmt.Println(i18n.T("  (no changelog entries)"))
+		}
+		for _, e := range entries {
+			color.Cyan("  %s", e.Version)
+			for _, c := range e.Changes {
+				fmt.Printf("    - %s\n", c)
+			}
+		}
+		return true
+	}
+
+	if st.TemplateCommit == "" || commit == "" || commit == st.TemplateCommit {
+		return false
+	}
+	commits, err := source.Log(templateDir, st.TemplateCommit)
+	if err != nil {
+		color.Yellow(i18n.T("⚠ Cannot list template changes: %v"), err)
+		return false
+	}
+	color.Magenta(i18n.T("\nTemplate commits since %.7s:"), st.TemplateCommit)
+	for _, c := range commits {
+		fmt.Printf("  %s\n", c)
+	}
+	return true
+}
+
+// printSyncResult lists every file sync touched or refused to touch
+func printSyncResult(result *project.SyncResult, dryRun bool) {
+	prefix := ""
+	if dryRun {
+		prefix = "would be "
+	}
+	for _, rel := range result.Files[project.Created] {
+		color.Green(i18n.T("  + %s (%screated)"), rel, prefix)
+	}
+	for _, rel := range result.Files[project.Updated] {
+		color.Green(i18n.T("  ~ %s (%supdated)"), rel, prefix)
+	}
+	for _, rel := range result.Files[project.Merged] {
+		color.Green(i18n.T("  ~ %s (%smerged with your changes)"), rel, prefix)
+	}
+	for _, rel := range result.Files[project.Kept] {
+		fmt.Printf(i18n.T("  = %s (edited locally, kept)\n"), rel)
+	}
+	for _, rel := range result.Files[project.Removed] {
+		fmt.Printf(i18n.T("  - %s (deleted locally, not recreated)\n"), rel)
+	}
+	for _, rel := range result.Files[project.Protected] {
+		fmt.Printf(i18n.T("  # %s (protected, not touched)\n"), rel)
+	}
+	for _, rel := range result.Files[project.Conflict] {
+		color.Yellow(i18n.T("  ! %s (changed locally and in the template, left alone)"), rel)
+	}
+}
+
+// Answers offered for each conflicting file
+const (
+	choiceOurs   = "Keep my version"
+	choiceTheirs = "Take the template version"
+	choiceEdit   = "Edit a merge of both"
+	choiceSkip   = "Skip for now"
+	choiceAlways = "Always keep my version of this file"
+)
+
+// resolveConflicts settles files changed both in the project and in the template.
+// Paths marked keep_ours in the stamp are resolved silently; the rest are asked about
+// when interactive and otherwise stay conflicts.
+func resolveConflicts(st *stamp.Stamp, result *project.SyncResult, renderedDir, projectDir string, bk *backup.Session, interactive bool) {
+	for _, rel := range append([]string{}, result.Files[project.Conflict]...) {
+		theirs, err := os.ReadFile(filepath.Join(renderedDir, filepath.FromSlash(rel)))
+		if err != nil {
+			color.Yellow(i18n.T("⚠ %v"), err)
+			continue
+		}
+		// Recording the template's digest marks its change as seen, so the file counts as kept next time
+		digest := utils.Digest(theirs)
+		if st.KeepsOurs(rel) {
+			result.Resolve(rel, project.Kept, digest)
+			continue
+		}
+		if !interactive {
+			continue
+		}
+		ours, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(rel)))
+		if err != nil {
+			color.Yellow(i18n.T("⚠ %v"), err)
+			continue
+		}
+
+		color.Magenta(i18n.T("\nConflict: %s changed in your project and in the template"), rel)
+		choices := []string{choiceOurs, choiceTheirs, choiceEdit, choiceSkip, choiceAlways}
+		if utils.IsBinaryFile(rel, ours) || utils.IsBinaryFile(rel, theirs) {
+			fmt.Println(i18n.T("  (binary file, no diff shown)"))
+			choices = []string{choiceOurs, choiceTheirs, choiceSkip, choiceAlways}
+		} else {
+			printDiff(diff.Unified(rel+" (yours)", rel+" (template)", string(ours), string(theirs), 3))
+		}
+
+		options := make([]string, len(choices))
+		for i, choice := range choices {
+			options[i] = i18n.T(choice)
+		}
+		chosen, err := ui.Select(i18n.Sprintf("Resolve %s:", rel), options, 0, nil)
+		if err != nil {
+			color.Yellow(i18n.T("⚠ Resolution cancelled; remaining conflicts left as they are"))
+			return
+		}
+		switch choices[chosen] {
+		case choiceAlways:
+			st.AddKeepOurs(rel)
+			result.Resolve(rel, project.Kept, digest)
+		case choiceOurs:
+			result.Resolve(rel, project.Kept, digest)
+		case choiceTheirs:
+			if err := project.Apply(renderedDir, projectDir, rel, bk); err != nil {
+				color.Yellow(i18n.T("⚠ %v"), err)
+				continue
+			}
+			result.Resolve(rel, project.Updated, digest)
+		case choiceEdit:
+			if err := editMerged(projectDir, rel, ours, theirs, bk); err != nil {
+				color.Yellow(i18n.T("⚠ %v"), err)
+				continue
+			}
+			result.Resolve(rel, project.Updated, digest)
+		}
+	}
+}
+
+// editMerged writes both versions of a file with conflict markers and opens it in the user's editor
+func editMerged(projectDir, rel string, ours, theirs []byte, bk *backup.Session) error {
+	if err := bk.Save(rel); err != nil {
+		return err
+	}
+	path := filepath.Join(projectDir, filepath.FromSlash(rel))
+	merged := diff.Markers(string(ours), string(theirs), "yours", "template")
+	if err := os.WriteFile(path, []byte(merged), 0644); err != nil {
+		return err
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := append(strings.Fields(editor), path)
+	c := exec.Command(args[0], args[1:]...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return i18n.Errorf("editor %s failed: %w", editor, err)
+	}
+
+	if data, err := os.ReadFile(path); err == nil && strings.Contains(string(data), "<<<<<<< yours") {
+		color.Yellow(i18n.T("⚠ %s still contains conflict markers"), rel)
+	}
+	return nil
+}
+
+// printDiff prints a unified diff with removed lines in red and added lines in green
+func printDiff(text string) {
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			fmt.Println(line)
+		case strings.HasPrefix(line, "@@"):
+			color.Cyan("%s", line)
+		case strings.HasPrefix(line, "-"):
+			color.Red("%s", line)
+		case strings.HasPrefix(line, "+"):
+			color.Green("%s", line)
+		default:
+			fmt.Println(line)
+		}
+	}
+}
+
+// stampTemplate resolves the template a stamp was created from: the saved template of that
+// name, or its recorded source fetched again (at ref, for git sources, when set)
+func stampTemplate(st *stamp.Stamp, cfg *config.Config, noCache bool, ref string) *config.Template {
+	tmpl := &config.Template{
+		Name:      st.Template,
+		Language:  st.Language,
+		Framework: st.Framework,
+	}
+	if st.Template != "" {
+		saved, err := config.GetTemplate(st.Template)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		tmpl.Path = saved.Path
+		return tmpl
+	}
+	if st.Source == "" {
+		exitWithError("Project stamp records no template or source")
+	}
+
+	src, err := source.Parse(st.Source)
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	opts := sourceOptions(cfg, noCache)
+	opts.Ref = ref
+	// Listing the commits since the recorded one needs the full history
+	if ref == "" && st.TemplateCommit != "" {
+		opts.Depth = 0
+	}
+	fetched, err := fetchTemplate(src, opts)
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	tmpl.Path = fetched.Path
+	return tmpl
+}
+
+func init() {
+	rootCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().StringP("path", "p", ".", "Project directory to update")
+	updateCmd.Flags().Bool("dry-run", false, "Show what would change without writing files")
+	updateCmd.Flags().Bool("no-cache", false, "Clone git templates directly instead of through the local mirror cache")
+}
@@ -0,0 +1,198 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/stamp"
+)
+
+// DirName is the directory inside a project's .foundry directory that holds backups
+const DirName = "backup"
+
+// timeFormat names backup directories; it sorts chronologically
+const timeFormat = "20060102T150405Z"
+
+// Session collects the files one command overwrites into .foundry/backup/<timestamp>/.
+// The directory is only created once the first file is saved.
+type Session struct {
+	projectDir string
+	name       string
+	saved      []string
+}
+
+// New starts a backup session for the project in projectDir.
+// Sessions started within the same second get a numeric suffix so they never share a directory.
+func New(projectDir string) *Session {
+	base := time.Now().UTC().Format(timeFormat)
+	name := base
+	for i := 2; ; i++ {
+		if _, err := fsys.Stat(filepath.Join(Root(projectDir), name)); os.IsNotExist(err) {
+			break
+		}
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	return &Session{projectDir: projectDir, name: name}
+}
+
+// Root returns the directory holding all backups of a project
+func Root(projectDir string) string {
+	return filepath.Join(projectDir, stamp.Dir, DirName)
+}
+
+// Name returns the timestamp the session's files are saved under
+func (s *Session) Name() string {
+	return s.name
+}
+
+// Saved returns the relative paths saved so far
+func (s *Session) Saved() []string {
+	return s.saved
+}
+
+// Save stashes the current content of the project file rel before it is overwritten.
+// Files that do not exist, or were already saved in this session, are ignored.
+// A nil session saves nothing, so callers can make backups optional.
+func (s *Session) Save(rel string) error {
+	if s == nil {
+		return nil
+	}
+	rel = filepath.ToSlash(rel)
+	for _, existing := range s.saved {
+		if existing == rel {
+			return nil
+		}
+	}
+	src := filepath.Join(s.projectDir, filepath.FromSlash(rel))
+	info, err := fsys.Stat(src)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err := ensureRoot(s.projectDir); err != nil {
+		return err
+	}
+	dst := filepath.Join(Root(s.projectDir), s.name, filepath.FromSlash(rel))
+	if err := copyFile(src, dst, info.Mode().Perm()); err != nil {
+		return i18n.Errorf("failed to back up %s: %w", rel, err)
+	}
+	s.saved = append(s.saved, rel)
+	return nil
+}
+
+// List returns the names of a project's backups, oldest first
+func List(projectDir string) ([]string, error) {
+	entries, err := fsys.ReadDir(Root(projectDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Files returns the relative paths stored in a backup, sorted
+func Files(projectDir, name string) ([]string, error) {
+	dir := filepath.Join(Root(projectDir), name)
+	if info, err := fsys.Stat(dir); err != nil || !info.IsDir() {
+		return nil, i18n.Errorf("no backup named '%s'", name)
+	}
+	var files []string
+	err := fsys.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// Restore copies the given files (all files when empty) from backup name back into the project.
+// Current versions are saved into the session current first, so a restore can itself be undone.
+func Restore(projectDir, name string, paths []string, current *Session) ([]string, error) {
+	stored, err := Files(projectDir, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		paths = stored
+	}
+
+	var restored []string
+	for _, rel := range paths {
+		rel = filepath.ToSlash(filepath.Clean(rel))
+		if !contains(stored, rel) {
+			return restored, i18n.Errorf("backup '%s' does not contain %s", name, rel)
+		}
+		if err := current.Save(rel); err != nil {
+			return restored, err
+		}
+		src := filepath.Join(Root(projectDir), name, filepath.FromSlash(rel))
+		info, err := fsys.Stat(src)
+		if err != nil {
+			return restored, err
+		}
+		if err := copyFile(src, filepath.Join(projectDir, filepath.FromSlash(rel)), info.Mode().Perm()); err != nil {
+			return restored, i18n.Errorf("failed to restore %s: %w", rel, err)
+		}
+		restored = append(restored, rel)
+	}
+	return restored, nil
+}
+
+// ensureRoot creates the backup directory with a .gitignore, so backups stay out of commits
+func ensureRoot(projectDir string) error {
+	root := Root(projectDir)
+	if err := fsys.MkdirAll(root, 0755); err != nil {
+		return i18n.Errorf("cannot create backup directory: %w", err)
+	}
+	ignore := filepath.Join(root, ".gitignore")
+	if _, err := fsys.Stat(ignore); os.IsNotExist(err) {
+		return fsys.WriteFile(ignore, []byte("*\n"), 0644)
+	}
+	return nil
+}
+
+// copyFile copies src to dst, creating the parent directory
+func copyFile(src, dst string, mode os.FileMode) error {
+	data, err := fsys.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := fsys.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return fsys.WriteFile(dst, data, mode)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+// Package bench measures how fast Foundry scaffolds projects. It generates a synthetic template
+// of a chosen size and creates projects from it through the same code path as foundry new,
+// once copying files verbatim and once rendering placeholders, each with one worker and with
+// several, so a report shows where time goes on a given machine.
+package bench
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/project"
+)
+
+// Workloads a benchmark runs
+const (
+	WorkloadCopy   = "copy"   // binary files, written verbatim
+	WorkloadRender = "render" // text files full of placeholders
+)
+
+// Options sizes the synthetic template and the runs
+type Options struct {
+	Files    int `json:"files"`     // number of files in the template
+	FileSize int `json:"file_size"` // bytes per file
+	PerDir   int `json:"per_dir"`   // files per directory
+	Workers  int `json:"workers"`   // workers for the parallel runs
+	Runs     int `json:"runs"`      // runs per measurement; the median is reported
+}
+
+// Measurement is the outcome of one workload with one worker count
+type Measurement struct {
+	Workload    string        `json:"workload"`
+	Workers     int           `json:"workers"`
+	Median      time.Duration `json:"median_ns"`
+	Fastest     time.Duration `json:"fastest_ns"`
+	FilesPerSec float64       `json:"files_per_sec"`
+	MBPerSec    float64       `json:"mb_per_sec"`
+}
+
+// Report describes the machine, the options and the measurements
+type Report struct {
+	FoundryVersion string        `json:"foundry_version"`
+	GoVersion      string        `json:"go_version"`
+	OS             string        `json:"os"`
+	Arch           string        `json:"arch"`
+	CPUs           int           `json:"cpus"`
+	Options        Options       `json:"options"`
+	Measurements   []Measurement `json:"measurements"`
+}
+
+// Run generates the synthetic templates in a temporary directory, measures every workload
+// sequentially and with opts.Workers workers, and removes the directory again. progress, when
+// not nil, is called before each measurement.
+func Run(version string, opts Options, progress func(workload string, workers int)) (*Report, error) {
+	if opts.Files < 1 || opts.FileSize < 1 || opts.PerDir < 1 || opts.Workers < 1 || opts.Runs < 1 {
+		return nil, i18n.Errorf("files, file size, files per directory, workers and runs must all be at least 1")
+	}
+	dir, err := os.MkdirTemp("", "foundry-bench-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	report := &Report{
+		FoundryVersion: version,
+		GoVersion:      runtime.Version(),
+		OS:             runtime.GOOS,
+		Arch:           runtime.GOARCH,
+		CPUs:           runtime.NumCPU(),
+		Options:        opts,
+	}
+	workerCounts := []int{1}
+	if opts.Workers > 1 {
+		workerCounts = append(workerCounts, opts.Workers)
+	}
+
+	previous := project.Workers
+	defer func() { project.Workers = previous }()
+	for _, workload := range []string{WorkloadCopy, WorkloadRender} {
+		tmplDir := filepath.Join(dir, workload)
+		if err := writeTemplate(tmplDir, workload, opts); err != nil {
+			return nil, i18n.Errorf("cannot write the synthetic template: %w", err)
+		}
+		tmpl := &config.Template{Name: "bench-" + workload, Path: tmplDir}
+		for _, workers := range workerCounts {
+			if progress != nil {
+				progress(workload, workers)
+			}
+			project.Workers = workers
+			times := make([]time.Duration, 0, opts.Runs)
+			for run := 0; run < opts.Runs; run++ {
+				target := filepath.Join(dir, fmt.Sprintf("out-%s-%d-%d", workload, workers, run))
+				start := time.Now()
+				if err := project.CreateFromTemplate(tmpl, "bench-project", target, "Bench", nil); err != nil {
+					return nil, err
+				}
+				times = append(times, time.Since(start))
+				os.RemoveAll(target)
+			}
+			report.Measurements = append(report.Measurements, measure(workload, workers, times, opts))
+		}
+	}
+	return report, nil
+}
+
+// measure summarizes the durations of the runs of one workload
+func measure(workload string, workers int, times []time.Duration, opts Options) Measurement {
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	median := times[len(times)/2]
+	m := Measurement{Workload: workload, Workers: workers, Median: median, Fastest: times[0]}
+	if secs := median.Seconds(); secs > 0 {
+		m.FilesPerSec = float64(opts.Files) / secs
+		m.MBPerSec = float64(opts.Files) * float64(opts.FileSize) / secs / (1 << 20)
+	}
+	return m
+}
+
+// writeTemplate writes a template of opts.Files files of opts.FileSize bytes, opts.PerDir to
+// a directory. Copy files hold a NUL byte, so Foundry copies them without rendering.
+func writeTemplate(dir, workload string, opts Options) error {
+	line := "{{PROJECT_NAME}} by {{AUTHOR}}: {{PROJECT_NAME_KEBAB}} {{PROJECT_NAME_SNAKE}} text\n"
+	if workload == WorkloadCopy {
+		line = "\x00binary payload 0123456789abcdef\n"
+	}
+	content := strings.Repeat(line, opts.FileSize/len(line)+1)[:opts.FileSize]
+	for i := 0; i < opts.Files; i++ {
+		sub := filepath.Join(dir, fmt.Sprintf("dir%03d", i/opts.PerDir))
+		if err := os.MkdirAll(sub, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(sub, fmt.Sprintf("file%05d.txt", i)), []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,7 +4,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-
+	"strings"
+	"time"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/lang"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -14,7 +21,113 @@ type Template struct {
 	Path        string   `yaml:"path"`
 	Language    string   `yaml:"language"`
 	Description string   `yaml:"description"`
+	Framework   string   `yaml:"framework,omitempty"`
 	Files       []string `yaml:"files,omitempty"`
+
+	// Remote location the template was fetched from (git URL, archive, ...); Path then holds Foundry's copy
+	Source string `yaml:"source,omitempty"`
+	SHA256 string `yaml:"sha256,omitempty"` // Pinned checksum of an archive or bucket source
+
+	// Optional registry metadata, usually sourced from the template's foundry.yaml
+	Homepage          string   `yaml:"homepage,omitempty"`
+	Maintainer        string   `yaml:"maintainer,omitempty"`
+	MinFoundryVersion string   `yaml:"min_foundry_version,omitempty"`
+	Screenshots       []string `yaml:"screenshots,omitempty"`
+
+	// Free-form key-value labels (team, tier, ...), filterable in template list and exposed as {{LABEL_<KEY>}}
+	Labels map[string]string `yaml:"labels,omitempty"`
+
+	// Values for the template's variables, set with template set-var; foundry new uses them
+	// unless the variable is given with --var, --vars-stdin, --var-file or by a bundle
+	Variables map[string]string `yaml:"variables,omitempty"`
+
+	// Rendering engine set with template add --engine; overrides the engine in foundry.yaml, so
+	// templates imported from other scaffolding tools render without one
+	Engine string `yaml:"engine,omitempty"`
+
+	// Set when the template's foundry.yaml marks it deprecated
+	Deprecated *manifest.Deprecation `yaml:"deprecated,omitempty"`
+
+	// Visibility: personal (the default) or shared; only shared templates may be published to the org config
+	Scope string `yaml:"scope,omitempty"`
+
+	// Fingerprint of the directory at the last scan; foundry new rescans the template when it changes
+	Fingerprint string `yaml:"fingerprint,omitempty"`
+
+	// Set when the language was given with --language, so rescans keep it instead of detecting it again
+	PinnedLanguage bool `yaml:"pinned_language,omitempty"`
+
+	// Usage recorded by foundry new and summarized by template stats
+	Uses     int    `yaml:"uses,omitempty"`
+	LastUsed string `yaml:"last_used,omitempty"` // date of the last project created from it
+}
+
+// Template visibility scopes
+const (
+	ScopePersonal = "personal"
+	ScopeShared   = "shared"
+)
+
+// Scopes lists the valid template scopes
+var Scopes = []string{ScopePersonal, ScopeShared}
+
+// ValidateScope checks scope is one of Scopes
+func ValidateScope(scope string) error {
+	for _, s := range Scopes {
+		if s == scope {
+			return nil
+		}
+	}
+	return i18n.Errorf("unknown scope '%s' (use %s)", scope, strings.Join(Scopes, ", "))
+}
+
+// EffectiveScope returns the template's scope, personal when none is set
+func (t *Template) EffectiveScope() string {
+	if t.Scope == "" {
+		return ScopePersonal
+	}
+	return t.Scope
+}
+
+// LabelVars returns the template's labels as built-in variables: team becomes {{LABEL_TEAM}},
+// compliance-level {{LABEL_COMPLIANCE_LEVEL}}
+func (t *Template) LabelVars() map[string]string {
+	vars := make(map[string]string, len(t.Labels))
+	for key, value := range t.Labels {
+		vars["LABEL_"+labelVarName(key)] = value
+	}
+	return vars
+}
+
+// labelVarName upper-cases a label key and replaces everything but letters and digits with _
+func labelVarName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// MatchLabels reports whether the template carries every label in filter. Keys and values
+// compare case-insensitively.
+func (t *Template) MatchLabels(filter map[string]string) bool {
+	for key, want := range filter {
+		found := false
+		for k, v := range t.Labels {
+			if strings.EqualFold(k, key) && strings.EqualFold(v, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
 
 type Config struct {
@@ -30,11 +143,106 @@ type Config struct {
 	InstalledDevTools        []string `yaml:"installed_dev_tools"`
 	VSCodePath               string   `yaml:"vscode_path,omitempty"`
 
+	// Detected OS, shells and container runtimes; templates see them as {{OS}}, {{SHELL}}, ...
+	Environment *Environment `yaml:"environment,omitempty"`
+
+	// Directories offered when completing --path for foundry new
+	ProjectRoots []string `yaml:"project_roots,omitempty"`
+	RecentPaths  []string `yaml:"recent_paths,omitempty"`
+
+	// History depth used when cloning --git templates (0 uses the default of 1)
+	CloneDepth int `yaml:"clone_depth,omitempty"`
+
+	// How long a cached --git mirror is used before fetching again, as a Go duration (default 1h)
+	GitCacheTTL string `yaml:"git_cache_ttl,omitempty"`
+
+	// Organization config shared by a team (file path or http(s) URL), e.g. bundles
+	OrgConfig string `yaml:"org_config,omitempty"`
+
+	// Static template index foundry template browse reads (URL, git repository or file)
+	TemplateIndex string `yaml:"template_index,omitempty"`
+
+	// Makes foundry new --strict-vars the default: variable collisions and placeholders left in
+	// the rendered project stop project creation
+	StrictVars bool `yaml:"strict_vars,omitempty"`
+
+	// Leaves files matched by the user's global gitignore (core.excludesfile), such as .DS_Store
+	// or editor swap files, out of template scans and new projects
+	GlobalGitignore bool `yaml:"global_gitignore,omitempty"`
+
+	// Copies OS and editor junk files (.DS_Store, Thumbs.db, desktop.ini, *.swp) from templates
+	// into new projects; by default they are left out
+	KeepJunkFiles bool `yaml:"keep_junk_files,omitempty"`
+
+	// Copies the files and directories template symlinks point to, instead of re-creating the
+	// links in new projects
+	FollowSymlinks bool `yaml:"follow_symlinks,omitempty"`
+
+	// File extensions always copied without placeholder replacement (.psd), or always rendered
+	// as text (.svg), in addition to the built-in binary extensions such as .png, .pdf and .woff2
+	BinaryExtensions []string `yaml:"binary_extensions,omitempty"`
+	TextExtensions   []string `yaml:"text_extensions,omitempty"`
+
+	// Line endings of rendered text files (lf, crlf or auto for the platform's own) for templates
+	// whose foundry.yaml does not set line_endings; by default they are kept as in the template
+	LineEndings string `yaml:"line_endings,omitempty"`
+
+	// Programs template hooks may run, and whether they run in a container
+	HookPolicy HookPolicy `yaml:"hook_policy,omitempty"`
+
+	// Language of Foundry's messages (en, nl); empty follows LANG
+	Locale string `yaml:"locale,omitempty"`
+
+	// What foundry new prints once a project is created: message, next steps and links
+	Success *manifest.Success `yaml:"success,omitempty"`
+
 	// Saved templates
 	Templates []Template `yaml:"templates,omitempty"`
 
-	// Default templates per language (e.g., "Go": "my-go-template")
-	LanguageDefaults map[string]string `yaml:"language_defaults,omitempty"`
+	// Preferred package managers per ecosystem, most preferred first (e.g. "javascript": ["pnpm", "yarn", "npm"])
+	PackageManagers map[string][]string `yaml:"package_managers,omitempty"`
+
+	// Per-user additions and overrides for the built-in language registry
+	Languages []lang.Language `yaml:"languages,omitempty"`
+
+	// Default templates per language as ranked fallbacks (e.g., "Go": ["go-service", "go-minimal"])
+	LanguageDefaults map[string]DefaultList `yaml:"language_defaults,omitempty"`
+
+	// Command shortcuts expanded like git aliases (e.g. "web": "new --template react-starter --with auth")
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+}
+
+// DefaultList is an ordered list of template names; the first one that still exists wins.
+// It is stored as a plain string when it holds a single entry, keeping older configs valid.
+type DefaultList []string
+
+// UnmarshalYAML accepts either a single template name or a list of names
+func (d *DefaultList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		var name string
+		if err := value.Decode(&name); err != nil {
+			return err
+		}
+		*d = DefaultList{}
+		if name != "" {
+			*d = DefaultList{name}
+		}
+		return nil
+	}
+	var names []string
+	if err := value.Decode(&names); err != nil {
+		return err
+	}
+	*d = DefaultList(names)
+	return nil
+}
+
+// MarshalYAML writes a single-entry list as a plain string
+func (d DefaultList) MarshalYAML() (interface{}, error) {
+	if len(d) == 1 {
+		return d[0], nil
+	}
+	return []string(d), nil
 }
 
 // configPathOverride allows overriding the default config file path.
@@ -51,13 +259,13 @@ func InitConfig() {
 	// Ensure config directory exists on initialization
 	_, err := getConfigPath()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		fmt.Fprintf(os.Stderr, i18n.T("Warning: %v\n"), err)
 	}
 
 	//create a default config if none exists
 	cfg, err := LoadConfig()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		fmt.Fprintf(os.Stderr, i18n.T("Warning: %v\n"), err)
 		return
 	}
 	if cfg == nil {
@@ -71,19 +279,19 @@ func InitConfig() {
 			InstalledPackageManagers: []string{},
 			InstalledDevTools:        []string{},
 			Templates:                []Template{},
-			LanguageDefaults:         make(map[string]string),
+			LanguageDefaults:         make(map[string]DefaultList),
 			VSCodePath:               "",
 		}
 		if err := SaveConfig(defaultCfg); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			fmt.Fprintf(os.Stderr, i18n.T("Warning: %v\n"), err)
 		}
 	}
 	// Ensure config file exists
 	configPath, err := getConfigPath()
 	if err == nil {
-		if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		if _, err := fsys.Stat(configPath); os.IsNotExist(err) {
 			if err := SaveConfig(&Config{}); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				fmt.Fprintf(os.Stderr, i18n.T("Warning: %v\n"), err)
 			}
 		}
 	}
@@ -104,17 +312,26 @@ func getConfigPath() (string, error) {
 	if h, err := os.UserHomeDir(); err == nil {
 		home = h
 	} else {
-		return "", fmt.Errorf("cannot determine home directory: %w", err)
+		return "", i18n.Errorf("cannot determine home directory: %w", err)
 	}
 
 	configDir := filepath.Join(home, ".foundry")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return "", fmt.Errorf("cannot create config directory: %w", err)
+	if err := fsys.MkdirAll(configDir, 0755); err != nil {
+		return "", i18n.Errorf("cannot create config directory: %w", err)
 	}
 
 	return filepath.Join(configDir, "config.yaml"), nil
 }
 
+// Dir returns the directory holding the config file; caches are kept next to it
+func Dir() (string, error) {
+	path, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(path), nil
+}
+
 // LoadConfig reads the config file from disk, or returns default if missing
 func LoadConfig() (*Config, error) {
 	path, err := getConfigPath()
@@ -132,11 +349,11 @@ func LoadConfig() (*Config, error) {
 		InstalledPackageManagers: []string{},
 		InstalledDevTools:        []string{},
 		Templates:                []Template{},
-		LanguageDefaults:         make(map[string]string),
+		LanguageDefaults:         make(map[string]DefaultList),
 		VSCodePath:               "",
 	}
 
-	file, err := os.Open(path)
+	file, err := fsys.Open(path)
 	if os.IsNotExist(err) {
 		// file doesn't exist, return default
 		return cfg, nil
@@ -147,7 +364,7 @@ func LoadConfig() (*Config, error) {
 
 	decoder := yaml.NewDecoder(file)
 	if err := decoder.Decode(cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config: %w", err)
+		return nil, i18n.Errorf("failed to parse config: %w", err)
 	}
 
 	return cfg, nil
@@ -159,17 +376,23 @@ func SaveConfig(cfg *Config) error {
 	if err != nil {
 		return err
 	}
+	if journalCommand != "" && !journaled {
+		if err := recordJournal(path); err != nil {
+			return err
+		}
+		journaled = true
+	}
 
-	file, err := os.Create(path)
+	file, err := fsys.Create(path)
 	if err != nil {
-		return fmt.Errorf("cannot create config file: %w", err)
+		return i18n.Errorf("cannot create config file: %w", err)
 	}
 	defer file.Close()
 
 	encoder := yaml.NewEncoder(file)
 	encoder.SetIndent(2)
 	if err := encoder.Encode(cfg); err != nil {
-		return fmt.Errorf("failed to write config: %w", err)
+		return i18n.Errorf("failed to write config: %w", err)
 	}
 
 	return nil
@@ -218,8 +441,64 @@ func SetConfigValue(key string, value interface{}) error {
 		if v, ok := value.(string); ok {
 			cfg.VSCodePath = v
 		}
+	case "project_roots":
+		if v, ok := value.([]string); ok {
+			cfg.ProjectRoots = v
+		}
+	case "clone_depth":
+		if v, ok := value.(int); ok {
+			cfg.CloneDepth = v
+		}
+	case "org_config":
+		if v, ok := value.(string); ok {
+			cfg.OrgConfig = v
+		}
+	case "template_index":
+		if v, ok := value.(string); ok {
+			cfg.TemplateIndex = v
+		}
+	case "strict_vars":
+		if v, ok := value.(bool); ok {
+			cfg.StrictVars = v
+		}
+	case "global_gitignore":
+		if v, ok := value.(bool); ok {
+			cfg.GlobalGitignore = v
+		}
+	case "keep_junk_files":
+		if v, ok := value.(bool); ok {
+			cfg.KeepJunkFiles = v
+		}
+	case "follow_symlinks":
+		if v, ok := value.(bool); ok {
+			cfg.FollowSymlinks = v
+		}
+	case "binary_extensions":
+		if v, ok := value.([]string); ok {
+			cfg.BinaryExtensions = v
+		}
+	case "text_extensions":
+		if v, ok := value.([]string); ok {
+			cfg.TextExtensions = v
+		}
+	case "line_endings":
+		if v, ok := value.(string); ok {
+			cfg.LineEndings = v
+		}
+	case "locale":
+		if v, ok := value.(string); ok {
+			cfg.Locale = v
+		}
+	case "environment":
+		if v, ok := value.(*Environment); ok {
+			cfg.Environment = v
+		}
+	case "hook_policy":
+		if v, ok := value.(HookPolicy); ok {
+			cfg.HookPolicy = v
+		}
 	default:
-		return fmt.Errorf("unknown config key: %s", key)
+		return i18n.Errorf("unknown config key: %s", key)
 	}
 
 	return SaveConfig(cfg)
@@ -258,35 +537,215 @@ func GetConfigValue(key string) (interface{}, error) {
 		return false, nil
 	case "vscode_path":
 		return cfg.VSCodePath, nil
+	case "project_roots":
+		return cfg.ProjectRoots, nil
+	case "recent_paths":
+		return cfg.RecentPaths, nil
+	case "clone_depth":
+		return cfg.CloneDepth, nil
+	case "org_config":
+		return cfg.OrgConfig, nil
+	case "template_index":
+		return cfg.TemplateIndex, nil
+	case "strict_vars":
+		return cfg.StrictVars, nil
+	case "global_gitignore":
+		return cfg.GlobalGitignore, nil
+	case "keep_junk_files":
+		return cfg.KeepJunkFiles, nil
+	case "follow_symlinks":
+		return cfg.FollowSymlinks, nil
+	case "binary_extensions":
+		return cfg.BinaryExtensions, nil
+	case "text_extensions":
+		return cfg.TextExtensions, nil
+	case "line_endings":
+		return cfg.LineEndings, nil
+	case "environment":
+		return cfg.Environment, nil
+	case "hook_policy":
+		return cfg.HookPolicy, nil
+	case "locale":
+		return cfg.Locale, nil
 	default:
-		return nil, fmt.Errorf("unknown config key: %s", key)
+		return nil, i18n.Errorf("unknown config key: %s", key)
 	}
 }
 
 func PrintConfig() {
 	cfg, err := LoadConfig()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		fmt.Fprintf(os.Stderr, i18n.T("Error loading config: %v\n"), err)
 		return
 	}
 
-	fmt.Printf("Author: %s\n", cfg.Author)
-	fmt.Printf("License: %s\n", cfg.License)
-	fmt.Printf("Default Language: %s\n", cfg.DefaultLanguage)
-	fmt.Printf("Docker: %t\n", cfg.Docker)
-	fmt.Printf("Interactive: %t\n", cfg.Interactive)
-	fmt.Printf("Installed Languages: %v\n", cfg.InstalledLanguages)
-	fmt.Printf("Installed Package Managers: %v\n", cfg.InstalledPackageManagers)
-	fmt.Printf("Installed Dev Tools: %v\n", cfg.InstalledDevTools)
-	fmt.Printf("Templates: %d saved\n", len(cfg.Templates))
+	fmt.Printf(i18n.T("Author: %s\n"), cfg.Author)
+	fmt.Printf(i18n.T("License: %s\n"), cfg.License)
+	fmt.Printf(i18n.T("Default Language: %s\n"), cfg.DefaultLanguage)
+	fmt.Printf(i18n.T("Docker: %t\n"), cfg.Docker)
+	fmt.Printf(i18n.T("Interactive: %t\n"), cfg.Interactive)
+	fmt.Printf(i18n.T("Installed Languages: %v\n"), cfg.InstalledLanguages)
+	fmt.Printf(i18n.T("Installed Package Managers: %v\n"), cfg.InstalledPackageManagers)
+	fmt.Printf(i18n.T("Installed Dev Tools: %v\n"), cfg.InstalledDevTools)
+	if env := cfg.Environment; env != nil {
+		platform := env.OS + "/" + env.Arch
+		if env.Distro != "" {
+			platform += " (" + strings.TrimSpace(env.Distro+" "+env.DistroVersion) + ")"
+		}
+		if env.WSL {
+			platform += " on WSL"
+		}
+		fmt.Printf(i18n.T("Environment: %s\n"), platform)
+		fmt.Printf(i18n.T("Shells: %v\n"), env.Shells)
+		fmt.Printf(i18n.T("Container Runtimes: %v\n"), env.ContainerRuntimes)
+	}
+	fmt.Printf(i18n.T("Templates: %d saved\n"), len(cfg.Templates))
+	if len(cfg.ProjectRoots) > 0 {
+		fmt.Printf(i18n.T("Project Roots: %v\n"), cfg.ProjectRoots)
+	}
+	if cfg.CloneDepth > 0 {
+		fmt.Printf(i18n.T("Clone Depth: %d\n"), cfg.CloneDepth)
+	}
+	if cfg.OrgConfig != "" {
+		fmt.Printf(i18n.T("Org Config: %s\n"), cfg.OrgConfig)
+	}
+	if cfg.TemplateIndex != "" {
+		fmt.Printf(i18n.T("Template Index: %s\n"), cfg.TemplateIndex)
+	}
+	if cfg.StrictVars {
+		fmt.Printf(i18n.T("Strict Variables: %t\n"), cfg.StrictVars)
+	}
+	if cfg.GlobalGitignore {
+		fmt.Printf(i18n.T("Global Gitignore: %t\n"), cfg.GlobalGitignore)
+	}
+	if cfg.KeepJunkFiles {
+		fmt.Printf(i18n.T("Keep Junk Files: %t\n"), cfg.KeepJunkFiles)
+	}
+	if cfg.FollowSymlinks {
+		fmt.Printf(i18n.T("Follow Symlinks: %t\n"), cfg.FollowSymlinks)
+	}
+	if len(cfg.BinaryExtensions) > 0 {
+		fmt.Printf(i18n.T("Binary Extensions: %v\n"), cfg.BinaryExtensions)
+	}
+	if len(cfg.TextExtensions) > 0 {
+		fmt.Printf(i18n.T("Text Extensions: %v\n"), cfg.TextExtensions)
+	}
+	if cfg.LineEndings != "" {
+		fmt.Printf(i18n.T("Line Endings: %s\n"), cfg.LineEndings)
+	}
+	if cfg.Locale != "" {
+		fmt.Printf(i18n.T("Locale: %s\n"), cfg.Locale)
+	}
+	if p := cfg.HookPolicy; !p.IsZero() {
+		if len(p.Allow) > 0 {
+			fmt.Printf(i18n.T("Hooks Allowed: %v\n"), p.Allow)
+		}
+		if len(p.Deny) > 0 {
+			fmt.Printf(i18n.T("Hooks Denied: %v\n"), p.Deny)
+		}
+		if p.Container {
+			image := p.Image
+			if image == "" {
+				image = "language image"
+			}
+			fmt.Printf(i18n.T("Hooks In Container: %s\n"), image)
+		}
+	}
+	if len(cfg.PackageManagers) > 0 {
+		fmt.Printf(i18n.T("\nPreferred Package Managers:\n"))
+		for _, ecosystem := range utils.SortedKeys(cfg.PackageManagers) {
+			fmt.Printf("  %s: %s\n", ecosystem, strings.Join(cfg.PackageManagers[ecosystem], " > "))
+		}
+	}
+	if len(cfg.Aliases) > 0 {
+		fmt.Printf(i18n.T("\nAliases:\n"))
+		for _, name := range utils.SortedKeys(cfg.Aliases) {
+			fmt.Printf("  %s = %s\n", name, cfg.Aliases[name])
+		}
+	}
 
-	// Show language defaults if any are set
+	// Show language defaults if any are set, fallbacks in ranked order
 	if len(cfg.LanguageDefaults) > 0 {
-		fmt.Printf("\nLanguage Defaults:\n")
-		for lang, tmpl := range cfg.LanguageDefaults {
-			fmt.Printf("  %s: %s\n", lang, tmpl)
+		fmt.Printf(i18n.T("\nLanguage Defaults:\n"))
+		for _, lang := range utils.SortedKeys(cfg.LanguageDefaults) {
+			fmt.Printf("  %s: %s\n", lang, strings.Join(cfg.LanguageDefaults[lang], " > "))
+		}
+	}
+}
+
+// SetPackageManagerPreference sets the preferred package managers for an ecosystem.
+// An empty list removes the preference.
+func SetPackageManagerPreference(ecosystem string, managers []string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	if cfg.PackageManagers == nil {
+		cfg.PackageManagers = make(map[string][]string)
+	}
+	ecosystem = strings.ToLower(ecosystem)
+	if len(managers) == 0 {
+		delete(cfg.PackageManagers, ecosystem)
+	} else {
+		cfg.PackageManagers[ecosystem] = managers
+	}
+	return SaveConfig(cfg)
+}
+
+// SetAlias defines the command line a command alias expands to. An empty expansion removes the alias.
+func SetAlias(name, expansion string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	if expansion == "" {
+		delete(cfg.Aliases, name)
+	} else {
+		if cfg.Aliases == nil {
+			cfg.Aliases = make(map[string]string)
+		}
+		cfg.Aliases[name] = expansion
+	}
+	return SaveConfig(cfg)
+}
+
+// maxRecentPaths bounds how many recently used --path values are remembered
+const maxRecentPaths = 10
+
+// AddRecentPath records a target path used by foundry new, most recent first
+func AddRecentPath(path string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	recent := []string{path}
+	for _, p := range cfg.RecentPaths {
+		if p != path && len(recent) < maxRecentPaths {
+			recent = append(recent, p)
 		}
 	}
+	cfg.RecentPaths = recent
+	return SaveConfig(cfg)
+}
+
+// RecordTemplateUse counts a project created from the saved template name; other names are ignored
+func RecordTemplateUse(name string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	for i := range cfg.Templates {
+		if cfg.Templates[i].Name == name {
+			cfg.Templates[i].Uses++
+			cfg.Templates[i].LastUsed = time.Now().UTC().Format("2006-01-02")
+			return SaveConfig(cfg)
+		}
+	}
+	return nil
 }
 
 // AddTemplate adds a new template to the config
@@ -299,7 +758,11 @@ func AddTemplate(tmpl Template) error {
 	// Check if template with same name already exists
 	for i, t := range cfg.Templates {
 		if t.Name == tmpl.Name {
-			// Replace existing template
+			// Replace existing template; its usage and saved variables carry over to the new version
+			tmpl.Uses, tmpl.LastUsed = t.Uses, t.LastUsed
+			if tmpl.Variables == nil {
+				tmpl.Variables = t.Variables
+			}
 			cfg.Templates[i] = tmpl
 			return SaveConfig(cfg)
 		}
@@ -310,6 +773,22 @@ func AddTemplate(tmpl Template) error {
 	return SaveConfig(cfg)
 }
 
+// SetTemplateVariables replaces the saved variable values of the template name
+func SetTemplateVariables(name string, vars map[string]string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	for i := range cfg.Templates {
+		if cfg.Templates[i].Name == name {
+			cfg.Templates[i].Variables = vars
+			return SaveConfig(cfg)
+		}
+	}
+	return i18n.Errorf("template '%s' not found", name)
+}
+
 // RemoveTemplate removes a template by name
 func RemoveTemplate(name string) error {
 	cfg, err := LoadConfig()
@@ -328,7 +807,7 @@ func RemoveTemplate(name string) error {
 	}
 
 	if !found {
-		return fmt.Errorf("template '%s' not found", name)
+		return i18n.Errorf("template '%s' not found", name)
 	}
 
 	cfg.Templates = newTemplates
@@ -348,7 +827,7 @@ func GetTemplate(name string) (*Template, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("template '%s' not found", name)
+	return nil, i18n.Errorf("template '%s' not found", name)
 }
 
 // ListTemplates returns all saved templates
@@ -360,45 +839,94 @@ func ListTemplates() ([]Template, error) {
 	return cfg.Templates, nil
 }
 
-// SetLanguageDefault sets the default template for a specific language
+// SetLanguageDefault sets a single default template for a specific language, replacing any fallbacks
 func SetLanguageDefault(language, templateName string) error {
+	return SetLanguageDefaults(language, []string{templateName})
+}
+
+// SetLanguageDefaults sets the ranked default templates for a specific language
+func SetLanguageDefaults(language string, templateNames []string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Verify templates exist
+	for _, name := range templateNames {
+		if !hasTemplate(cfg, name) {
+			return i18n.Errorf("template '%s' not found", name)
+		}
+	}
+
+	// Initialize map if nil
+	if cfg.LanguageDefaults == nil {
+		cfg.LanguageDefaults = make(map[string]DefaultList)
+	}
+
+	cfg.LanguageDefaults[language] = dedupe(templateNames)
+	return SaveConfig(cfg)
+}
+
+// AddLanguageFallback appends a template to the end of a language's ranked defaults
+func AddLanguageFallback(language, templateName string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	if !hasTemplate(cfg, templateName) {
+		return i18n.Errorf("template '%s' not found", templateName)
+	}
+
+	if cfg.LanguageDefaults == nil {
+		cfg.LanguageDefaults = make(map[string]DefaultList)
+	}
+	cfg.LanguageDefaults[language] = dedupe(append(cfg.LanguageDefaults[language], templateName))
+	return SaveConfig(cfg)
+}
+
+// RemoveLanguageFallback removes a template from a language's ranked defaults
+func RemoveLanguageFallback(language, templateName string) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return err
 	}
 
-	// Verify template exists
+	remaining := DefaultList{}
 	found := false
-	for _, t := range cfg.Templates {
-		if t.Name == templateName {
+	for _, name := range cfg.LanguageDefaults[language] {
+		if name == templateName {
 			found = true
-			break
+			continue
 		}
+		remaining = append(remaining, name)
 	}
 	if !found {
-		return fmt.Errorf("template '%s' not found", templateName)
+		return i18n.Errorf("template '%s' is not a default for %s", templateName, language)
 	}
 
-	// Initialize map if nil
-	if cfg.LanguageDefaults == nil {
-		cfg.LanguageDefaults = make(map[string]string)
+	if len(remaining) == 0 {
+		delete(cfg.LanguageDefaults, language)
+	} else {
+		cfg.LanguageDefaults[language] = remaining
 	}
-
-	cfg.LanguageDefaults[language] = templateName
 	return SaveConfig(cfg)
 }
 
-// GetLanguageDefault returns the default template for a specific language
+// GetLanguageDefault returns the first default template for a language that still exists
 func GetLanguageDefault(language string) (string, error) {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return "", err
 	}
+	return effectiveDefault(cfg, language), nil
+}
 
-	if cfg.LanguageDefaults == nil {
-		return "", nil
+// GetLanguageDefaults returns the ranked default templates for a language, including removed ones
+func GetLanguageDefaults(language string) ([]string, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
 	}
-
 	return cfg.LanguageDefaults[language], nil
 }
 
@@ -416,7 +944,7 @@ func ClearLanguageDefault(language string) error {
 	return SaveConfig(cfg)
 }
 
-// IsDefaultTemplate checks if a template is set as default for any language
+// IsDefaultTemplate returns the languages for which a template is the effective default
 func IsDefaultTemplate(templateName string) []string {
 	cfg, err := LoadConfig()
 	if err != nil {
@@ -424,12 +952,44 @@ func IsDefaultTemplate(templateName string) []string {
 	}
 
 	languages := []string{}
-	if cfg.LanguageDefaults != nil {
-		for lang, tmpl := range cfg.LanguageDefaults {
-			if tmpl == templateName {
-				languages = append(languages, lang)
-			}
+	for _, lang := range utils.SortedKeys(cfg.LanguageDefaults) {
+		if effectiveDefault(cfg, lang) == templateName {
+			languages = append(languages, lang)
 		}
 	}
 	return languages
 }
+
+// effectiveDefault returns the first template in a language's ranked defaults that still exists
+func effectiveDefault(cfg *Config, language string) string {
+	for _, name := range cfg.LanguageDefaults[language] {
+		if hasTemplate(cfg, name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// hasTemplate reports whether a template with the given name is saved
+func hasTemplate(cfg *Config, name string) bool {
+	for _, t := range cfg.Templates {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// dedupe removes repeated names while keeping the first occurrence's position
+func dedupe(names []string) DefaultList {
+	seen := make(map[string]bool)
+	result := DefaultList{}
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Environment describes the machine Foundry runs on, as captured by foundry detect
+type Environment struct {
+	OS                string   `yaml:"os"`
+	Arch              string   `yaml:"arch"`
+	Distro            string   `yaml:"distro,omitempty"`         // os-release ID on Linux (ubuntu, fedora, ...), macos or windows
+	DistroVersion     string   `yaml:"distro_version,omitempty"` // os-release VERSION_ID, or the macOS/Windows version
+	WSL               bool     `yaml:"wsl,omitempty"`            // Linux running under the Windows Subsystem for Linux
+	Shell             string   `yaml:"shell,omitempty"`          // the user's shell ($SHELL, or powershell on Windows)
+	Shells            []string `yaml:"shells,omitempty"`
+	ContainerRuntimes []string `yaml:"container_runtimes,omitempty"` // most preferred first: docker, podman, ...
+}
+
+// EnvironmentVars returns the built-in template variables describing the machine:
+// OS, ARCH, DISTRO, DISTRO_VERSION, WSL, SHELL and CONTAINER_RUNTIME.
+// OS and ARCH always describe the running binary; the rest come from the last foundry detect.
+func EnvironmentVars(cfg *Config) map[string]string {
+	env := Environment{}
+	if cfg != nil && cfg.Environment != nil {
+		env = *cfg.Environment
+	}
+	vars := map[string]string{
+		"OS":                runtime.GOOS,
+		"ARCH":              runtime.GOARCH,
+		"DISTRO":            env.Distro,
+		"DISTRO_VERSION":    env.DistroVersion,
+		"WSL":               strconv.FormatBool(env.WSL),
+		"SHELL":             strings.TrimSuffix(filepath.Base(env.Shell), ".exe"),
+		"CONTAINER_RUNTIME": "",
+	}
+	if vars["SHELL"] == "." {
+		vars["SHELL"] = ""
+	}
+	if len(env.ContainerRuntimes) > 0 {
+		vars["CONTAINER_RUNTIME"] = env.ContainerRuntimes[0]
+	}
+	return vars
+}
@@ -0,0 +1,45 @@
+package config
+
+// HookPolicy limits what template hooks may run
+type HookPolicy struct {
+	Allow     []string `yaml:"allow,omitempty"`     // programs hooks may invoke; empty allows any not denied
+	Deny      []string `yaml:"deny,omitempty"`      // programs hooks may never invoke
+	Container bool     `yaml:"container,omitempty"` // run hooks in a container instead of on the host
+	Image     string   `yaml:"image,omitempty"`     // container image; default is the language's official image
+}
+
+// IsZero reports whether the policy sets nothing
+func (p HookPolicy) IsZero() bool {
+	return len(p.Allow) == 0 && len(p.Deny) == 0 && !p.Container && p.Image == ""
+}
+
+// Combine returns the stricter of two policies: programs must be allowed by both and are
+// denied by either, and hooks run in a container if either asks for it
+func (p HookPolicy) Combine(other HookPolicy) HookPolicy {
+	combined := HookPolicy{
+		Deny:      append(append([]string{}, p.Deny...), other.Deny...),
+		Container: p.Container || other.Container,
+		Image:     p.Image,
+	}
+	if combined.Image == "" {
+		combined.Image = other.Image
+	}
+	switch {
+	case len(p.Allow) == 0:
+		combined.Allow = other.Allow
+	case len(other.Allow) == 0:
+		combined.Allow = p.Allow
+	default:
+		for _, a := range p.Allow {
+			for _, b := range other.Allow {
+				if a == b {
+					combined.Allow = append(combined.Allow, a)
+				}
+			}
+		}
+		if len(combined.Allow) == 0 {
+			combined.Deny = append(combined.Deny, "*") // the allowlists have nothing in common
+		}
+	}
+	return combined
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+// journalDir is the directory next to the config file holding the config as it was before each change
+const journalDir = "config-history"
+
+// maxJournal bounds how many changes can be undone
+const maxJournal = 20
+
+// journalTimeFormat names journal entries so they sort in the order they were made
+const journalTimeFormat = "20060102T150405.000000000Z"
+
+// JournalEntry is the config as it was before one command changed it
+type JournalEntry struct {
+	Time    time.Time `yaml:"time"`
+	Command string    `yaml:"command"`
+	Config  string    `yaml:"config"` // previous content of the config file
+}
+
+// journalCommand describes the running command when its config changes are journaled
+var journalCommand string
+
+// journaled is set once the running command's first change has been recorded
+var journaled bool
+
+// Journal makes SaveConfig record the config as it was before command changed it, so
+// foundry config undo can restore it. All changes one command makes are undone together.
+func Journal(command string) {
+	journalCommand = command
+	journaled = false
+}
+
+// recordJournal saves the current content of the config file at path as a journal entry
+func recordJournal(path string) error {
+	data, err := fsys.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(filepath.Dir(path), journalDir)
+	if err := fsys.MkdirAll(dir, 0755); err != nil {
+		return i18n.Errorf("cannot create config history: %w", err)
+	}
+	now := time.Now().UTC()
+	entry, err := yaml.Marshal(&JournalEntry{Time: now, Command: journalCommand, Config: string(data)})
+	if err != nil {
+		return err
+	}
+	if err := fsys.WriteFile(filepath.Join(dir, now.Format(journalTimeFormat)+".yaml"), entry, 0600); err != nil {
+		return i18n.Errorf("cannot write config history: %w", err)
+	}
+
+	// Only the most recent changes are kept
+	names, err := journalNames(dir)
+	if err != nil {
+		return err
+	}
+	for len(names) > maxJournal {
+		fsys.Remove(filepath.Join(dir, names[0]))
+		names = names[1:]
+	}
+	return nil
+}
+
+// journalNames returns the journal entry files in dir, oldest first
+func journalNames(dir string) ([]string, error) {
+	entries, err := fsys.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".yaml") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// History returns the journaled changes, most recent first
+func History() ([]JournalEntry, error) {
+	path, err := getConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(filepath.Dir(path), journalDir)
+	names, err := journalNames(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]JournalEntry, 0, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		data, err := fsys.ReadFile(filepath.Join(dir, names[i]))
+		if err != nil {
+			return nil, err
+		}
+		var entry JournalEntry
+		if err := yaml.Unmarshal(data, &entry); err != nil {
+			return nil, i18n.Errorf("failed to parse config history %s: %w", names[i], err)
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+// Undo restores the config as it was before the most recent journaled change and drops that
+// entry from the journal. It returns the undone entry, or nil when there is nothing to undo.
+func Undo() (*JournalEntry, error) {
+	path, err := getConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(filepath.Dir(path), journalDir)
+	names, err := journalNames(dir)
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+
+	last := filepath.Join(dir, names[len(names)-1])
+	data, err := fsys.ReadFile(last)
+	if err != nil {
+		return nil, err
+	}
+	var entry JournalEntry
+	if err := yaml.Unmarshal(data, &entry); err != nil {
+		return nil, i18n.Errorf("failed to parse config history %s: %w", names[len(names)-1], err)
+	}
+	if err := yaml.Unmarshal([]byte(entry.Config), &Config{}); err != nil {
+		return nil, i18n.Errorf("failed to parse config history %s: %w", names[len(names)-1], err)
+	}
+	if err := fsys.WriteFile(path, []byte(entry.Config), 0644); err != nil {
+		return nil, i18n.Errorf("failed to write config: %w", err)
+	}
+	if err := fsys.Remove(last); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
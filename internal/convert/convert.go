@@ -0,0 +1,183 @@
+// Package convert turns templates written for other scaffolders into Foundry templates. It
+// understands cookiecutter templates (cookiecutter.json next to a {{cookiecutter.*}} directory)
+// and simple yeoman generators (generators/app/templates with EJS placeholders).
+package convert
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Scaffolders a template can be converted from
+const (
+	KindCookiecutter = "cookiecutter"
+	KindYeoman       = "yeoman"
+)
+
+// Result describes a converted template
+type Result struct {
+	Kind     string
+	Manifest *manifest.Manifest
+	Files    int
+	// Manual lists the paths still holding logic Foundry cannot express, such as Jinja
+	// conditionals, EJS code blocks or cookiecutter hooks; they need converting by hand
+	Manual []string
+	// Skipped lists the variables without a Foundry equivalent, such as cookiecutter dictionaries
+	Skipped []string
+}
+
+// skippedDirs are never copied into a converted template
+var skippedDirs = map[string]bool{
+	".git": true, "node_modules": true, "__pycache__": true,
+}
+
+// converter rewrites the placeholders of one scaffolder
+type converter struct {
+	placeholder *regexp.Regexp // first group is the variable name
+	leftover    *regexp.Regexp // template logic that remains after replacing placeholders
+	rename      func(name string) string
+	builtins    map[string]string // scaffolder variable names standing for Foundry built-ins
+	used        map[string]bool   // Foundry variables the files refer to
+}
+
+// variable returns the Foundry name for the scaffolder variable name
+func (c *converter) variable(name string) string {
+	if builtin, ok := c.builtins[strings.ToLower(name)]; ok {
+		return builtin
+	}
+	return strings.ToUpper(utils.SnakeCase(name))
+}
+
+// replace turns the scaffolder's placeholders in s into Foundry placeholders
+func (c *converter) replace(s string) string {
+	return c.placeholder.ReplaceAllStringFunc(s, func(match string) string {
+		name := c.variable(c.placeholder.FindStringSubmatch(match)[1])
+		c.used[name] = true
+		return "{{" + name + "}}"
+	})
+}
+
+// Detect reports which scaffolder the template at dir was written for
+func Detect(dir string) (string, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", i18n.Errorf("%s is not a directory", dir)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "cookiecutter.json")); err == nil {
+		return KindCookiecutter, nil
+	}
+	if yeomanTemplates(dir) != "" {
+		return KindYeoman, nil
+	}
+	return "", i18n.Errorf("%s is neither a cookiecutter template (cookiecutter.json) nor a yeoman generator (generators/app/templates)", dir)
+}
+
+// Convert writes the template at src as a Foundry template, with a foundry.yaml declaring its
+// variables, into dst, which must not exist yet
+func Convert(src, dst string) (*Result, error) {
+	kind, err := Detect(src)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return nil, i18n.Errorf("output directory %s already exists", dst)
+	}
+	var result *Result
+	switch kind {
+	case KindCookiecutter:
+		result, err = convertCookiecutter(src, dst)
+	default:
+		result, err = convertYeoman(src, dst)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Kind = kind
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(result.Manifest); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dst, manifest.FileName), buf.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// copyTree copies the files under root into dst, converting placeholders in file contents and
+// path names; rename, when set, adjusts each file name first
+func copyTree(root, dst string, c *converter, result *Result) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if info.IsDir() && skippedDirs[info.Name()] {
+			return filepath.SkipDir
+		}
+		if c.rename != nil && !info.IsDir() {
+			rel = filepath.Join(filepath.Dir(rel), c.rename(info.Name()))
+		}
+		rel = c.replace(filepath.ToSlash(rel))
+		target := filepath.Join(dst, filepath.FromSlash(rel))
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !utils.IsBinaryFile(path, data) {
+			text, enc := utils.DecodeText(data)
+			content := c.replace(text)
+			if c.leftover.MatchString(content) || c.leftover.MatchString(rel) {
+				result.Manual = append(result.Manual, rel)
+			}
+			data = utils.EncodeText(content, enc)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		result.Files++
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+// declareUsed appends a variable without prompt for every placeholder the files use that is
+// neither a built-in nor declared yet
+func declareUsed(m *manifest.Manifest, c *converter) {
+	declared := map[string]bool{}
+	for _, v := range m.Variables {
+		declared[v.Name] = true
+	}
+	builtins := utils.ProjectNameVars("x")
+	builtins["AUTHOR"] = ""
+	for _, name := range utils.GeneratedNames {
+		builtins[name] = ""
+	}
+	for _, name := range utils.SortedKeys(c.used) {
+		if _, ok := builtins[name]; !ok && !declared[name] {
+			m.Variables = append(m.Variables, manifest.Variable{Name: name})
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package convert
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/manifest"
+	"gopkg.in/yaml.v3"
+)
+
+// cookiecutterBuiltins maps common cookiecutter variables to the Foundry built-ins they stand for
+var cookiecutterBuiltins = map[string]string{
+	"project_name": "PROJECT_NAME",
+	"project_slug": "PROJECT_NAME_SNAKE",
+	"module_name":  "PROJECT_NAME_SNAKE",
+	"package_name": "PROJECT_NAME_SNAKE",
+	"repo_name":    "PROJECT_NAME_KEBAB",
+	"author":       "AUTHOR",
+	"author_name":  "AUTHOR",
+	"full_name":    "AUTHOR",
+	"year":         "YEAR",
+}
+
+// convertCookiecutter converts the cookiecutter template at src. The files come from its
+// {{cookiecutter.*}} directory, the variables from cookiecutter.json.
+func convertCookiecutter(src, dst string) (*Result, error) {
+	c := &converter{
+		placeholder: regexp.MustCompile(`\{\{\s*cookiecutter\.(\w+)\s*\}\}`),
+		leftover:    regexp.MustCompile(`\{\{\s*cookiecutter\.|\{%`),
+		builtins:    cookiecutterBuiltins,
+		used:        map[string]bool{},
+	}
+	root, err := cookiecutterRoot(src)
+	if err != nil {
+		return nil, err
+	}
+	m := &manifest.Manifest{Name: filepath.Base(absPath(src))}
+	result := &Result{Manifest: m}
+	if err := cookiecutterVariables(filepath.Join(src, "cookiecutter.json"), c, result); err != nil {
+		return nil, err
+	}
+	if err := copyTree(root, dst, c, result); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(src, "hooks")); err == nil {
+		result.Manual = append(result.Manual, "hooks/")
+	}
+	declareUsed(m, c)
+	return result, nil
+}
+
+// cookiecutterRoot returns the single top-level directory whose name is a cookiecutter
+// placeholder; it becomes the project directory, so its contents are the template
+func cookiecutterRoot(src string) (string, error) {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return "", err
+	}
+	var roots []string
+	for _, e := range entries {
+		if e.IsDir() && strings.Contains(e.Name(), "cookiecutter.") {
+			roots = append(roots, e.Name())
+		}
+	}
+	if len(roots) != 1 {
+		return "", i18n.Errorf("expected one {{cookiecutter.*}} directory in %s, found %d", src, len(roots))
+	}
+	return filepath.Join(src, roots[0]), nil
+}
+
+// cookiecutterVariables declares the variables of cookiecutter.json in m, in file order. Keys
+// starting with an underscore configure cookiecutter itself and are left out, lists become
+// choices and booleans yes/no choices. Defaults computed from other variables are dropped.
+func cookiecutterVariables(path string, c *converter, result *Result) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return i18n.Errorf("failed to parse cookiecutter.json: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return i18n.Errorf("failed to parse cookiecutter.json: expected an object")
+	}
+	fields := doc.Content[0].Content
+	for i := 0; i+1 < len(fields); i += 2 {
+		key, value := fields[i].Value, fields[i+1]
+		if strings.HasPrefix(key, "_") {
+			continue
+		}
+		name := c.variable(key)
+		if _, builtin := c.builtins[strings.ToLower(key)]; builtin {
+			continue
+		}
+		v := manifest.Variable{Name: name, Prompt: strings.ReplaceAll(key, "_", " ")}
+		switch value.Kind {
+		case yaml.ScalarNode:
+			if value.Tag == "!!bool" {
+				v.Choices = []string{"true", "false"}
+			}
+			if !strings.Contains(value.Value, "{{") {
+				v.Default = value.Value
+			}
+		case yaml.SequenceNode:
+			for _, item := range value.Content {
+				if item.Kind == yaml.ScalarNode {
+					v.Choices = append(v.Choices, item.Value)
+				}
+			}
+			if len(v.Choices) > 0 {
+				v.Default = v.Choices[0]
+			}
+		default:
+			result.Skipped = append(result.Skipped, key)
+			continue
+		}
+		result.Manifest.Variables = append(result.Manifest.Variables, v)
+	}
+	return nil
+}
+
+// absPath returns path made absolute, or path itself when that fails
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
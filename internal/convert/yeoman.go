@@ -0,0 +1,125 @@
+package convert
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/manifest"
+)
+
+// yeomanBuiltins maps common yeoman prompt names to the Foundry built-ins they stand for
+var yeomanBuiltins = map[string]string{
+	"name":        "PROJECT_NAME",
+	"appname":     "PROJECT_NAME",
+	"projectname": "PROJECT_NAME",
+	"author":      "AUTHOR",
+	"authorname":  "AUTHOR",
+}
+
+var (
+	// promptPattern matches a prompt object in a generator's index.js: braces without nested
+	// braces, holding a name
+	promptPattern  = regexp.MustCompile(`\{[^{}]*\bname\s*:\s*['"](\w+)['"][^{}]*\}`)
+	messagePattern = regexp.MustCompile(`\bmessage\s*:\s*['"]([^'"]*)['"]`)
+	defaultPattern = regexp.MustCompile(`\bdefault\s*:\s*(?:['"]([^'"]*)['"]|(true|false|\d+))`)
+	typePattern    = regexp.MustCompile(`\btype\s*:\s*['"](\w+)['"]`)
+	choicesPattern = regexp.MustCompile(`\bchoices\s*:\s*\[([^\]]*)\]`)
+	quotedPattern  = regexp.MustCompile(`['"]([^'"]*)['"]`)
+)
+
+// yeomanTemplates returns the templates directory of the yeoman generator at dir, or "" when
+// dir holds none
+func yeomanTemplates(dir string) string {
+	for _, rel := range []string{"generators/app/templates", "app/templates"} {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// convertYeoman converts the yeoman generator at src. The files come from the app generator's
+// templates directory, with <%= name %> placeholders converted and the pre-1.0 underscore
+// prefix (_package.json) dropped; the variables come from the prompts in its index.js.
+func convertYeoman(src, dst string) (*Result, error) {
+	c := &converter{
+		placeholder: regexp.MustCompile(`<%[=-]\s*(?:this\.)?(?:props\.|answers\.)?(\w+)\s*%>`),
+		leftover:    regexp.MustCompile(`<%`),
+		rename: func(name string) string {
+			if strings.HasPrefix(name, "_") && !strings.HasPrefix(name, "__") {
+				return name[1:]
+			}
+			return name
+		},
+		builtins: yeomanBuiltins,
+		used:     map[string]bool{},
+	}
+	templates := yeomanTemplates(src)
+	m := &manifest.Manifest{Name: filepath.Base(absPath(src))}
+	if data, err := os.ReadFile(filepath.Join(src, "package.json")); err == nil {
+		var pkg struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		}
+		if json.Unmarshal(data, &pkg) == nil {
+			if pkg.Name != "" {
+				m.Name = strings.TrimPrefix(pkg.Name, "generator-")
+			}
+			m.Description = pkg.Description
+		}
+	}
+	result := &Result{Manifest: m}
+	if data, err := os.ReadFile(filepath.Join(filepath.Dir(templates), "index.js")); err == nil {
+		m.Variables = yeomanPrompts(string(data), c)
+	}
+	if err := copyTree(templates, dst, c, result); err != nil {
+		return nil, err
+	}
+	declareUsed(m, c)
+	return result, nil
+}
+
+// yeomanPrompts returns the prompts found in a generator's source as variables. Only plain
+// literals are understood: computed messages and defaults are left out.
+func yeomanPrompts(source string, c *converter) []manifest.Variable {
+	var vars []manifest.Variable
+	seen := map[string]bool{}
+	for _, match := range promptPattern.FindAllStringSubmatch(source, -1) {
+		block, key := match[0], match[1]
+		msg := messagePattern.FindStringSubmatch(block)
+		if msg == nil {
+			continue
+		}
+		name := c.variable(key)
+		if _, builtin := c.builtins[strings.ToLower(key)]; builtin || seen[name] {
+			continue
+		}
+		seen[name] = true
+		v := manifest.Variable{Name: name, Prompt: msg[1]}
+		if d := defaultPattern.FindStringSubmatch(block); d != nil {
+			v.Default = d[1] + d[2]
+		}
+		if ch := choicesPattern.FindStringSubmatch(block); ch != nil {
+			for _, q := range quotedPattern.FindAllStringSubmatch(ch[1], -1) {
+				v.Choices = append(v.Choices, q[1])
+			}
+		}
+		if t := typePattern.FindStringSubmatch(block); t != nil {
+			switch t[1] {
+			case "confirm":
+				v.Choices = []string{"true", "false"}
+			case "checkbox":
+				v.Type = manifest.TypeList
+			}
+		}
+		if v.Check(v.Default) != nil {
+			v.Default = ""
+		}
+		vars = append(vars, v)
+	}
+	return vars
+}
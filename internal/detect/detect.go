@@ -6,10 +6,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
-	"sort"
 	"strings"
 
 	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/lang"
+	"github.com/kajvans/foundry/internal/utils"
 )
 
 type ScanResult struct {
@@ -17,6 +19,7 @@ type ScanResult struct {
 	PackageManagers map[string]bool
 	DevTools        map[string]bool
 	VSCodePath      string // Path to VS Code executable
+	Environment     *config.Environment
 }
 
 // checkVSCode checks for VS Code installation on various platforms
@@ -115,24 +118,21 @@ func checkVSCode() string {
 	return ""
 }
 
-// ScanSystem does all the logic of checking binaries
-func ScanSystem() *ScanResult {
-	categories := map[string]map[string]string{
-		"Languages": {
-			"Go":         "go",
-			"Python":     "python3",
-			"Node.js":    "node",
-			"Rust":       "rustc",
-			"Java":       "javac",
-			"C++":        "g++",
-			"PHP":        "php",
-			"Ruby":       "ruby",
-			"Swift":      "swift",
-			"Kotlin":     "kotlinc",
-			"C#":         "csc",
-			"C":          "gcc",
-			"TypeScript": "tsc",
-		},
+// languageBinaries maps registered languages to the executable that indicates they are installed
+func languageBinaries() map[string]string {
+	binaries := make(map[string]string)
+	for _, l := range lang.All() {
+		if l.Binary != "" {
+			binaries[l.Name] = l.Binary
+		}
+	}
+	return binaries
+}
+
+// toolCategories maps each category foundry detect reports to its tools and their executables
+func toolCategories() map[string]map[string]string {
+	return map[string]map[string]string{
+		"Languages": languageBinaries(),
 		"Package Managers": {
 			"pip":      "pip3",
 			"npm":      "npm",
@@ -147,11 +147,16 @@ func ScanSystem() *ScanResult {
 			"bundler":  "bundle",
 			"brew":     "brew",
 			"apt":      "apt",
+			"mix":      "mix",
+			"sbt":      "sbt",
+			"cabal":    "cabal",
+			"stack":    "stack",
 		},
 		"Development Tools": {
 			"git":       "git",
 			"docker":    "docker",
 			"kubectl":   "kubectl",
+			"helm":      "helm",
 			"apache":    "apache2",
 			"nginx":     "nginx",
 			"terraform": "terraform",
@@ -162,11 +167,17 @@ func ScanSystem() *ScanResult {
 			"vscode":    "code",
 		},
 	}
+}
+
+// ScanSystem does all the logic of checking binaries
+func ScanSystem() *ScanResult {
+	categories := toolCategories()
 
 	result := &ScanResult{
 		Languages:       map[string]bool{},
 		PackageManagers: map[string]bool{},
 		DevTools:        map[string]bool{},
+		Environment:     ScanEnvironment(),
 	}
 
 	for category, tools := range categories {
@@ -200,52 +211,70 @@ func ScanSystem() *ScanResult {
 
 // PrintResult prints the detected tools nicely
 func PrintResult(result *ScanResult) {
-	categories := map[string]map[string]bool{
-		"Languages":         result.Languages,
-		"Package Managers":  result.PackageManagers,
-		"Development Tools": result.DevTools,
+	categories := []struct {
+		name  string
+		tools map[string]bool
+	}{
+		{"Languages", result.Languages},
+		{"Package Managers", result.PackageManagers},
+		{"Development Tools", result.DevTools},
 	}
 
-	for category, tools := range categories {
-		fmt.Printf("=== %s ===\n", category)
-		names := make([]string, 0, len(tools))
-		for name := range tools {
-			names = append(names, name)
-		}
-		sort.Strings(names)
-		for _, name := range names {
+	for _, category := range categories {
+		fmt.Printf("=== %s ===\n", i18n.T(category.name))
+		tools := category.tools
+		for _, name := range utils.SortedKeys(tools) {
 			if tools[name] {
-				fmt.Printf("✅ %-10s\n", name)
+				fmt.Printf(i18n.T("✅ %-10s\n"), name)
 			} else {
-				fmt.Printf("❌ %-10s\n", name)
+				fmt.Printf(i18n.T("❌ %-10s\n"), name)
 			}
 		}
 		fmt.Println()
 	}
-}
 
-func SaveConfig(ScanResult *ScanResult) error {
-	// Convert maps to slices
-	installedLanguages := []string{}
-	for lang, found := range ScanResult.Languages {
-		if found {
-			installedLanguages = append(installedLanguages, lang)
+	if env := result.Environment; env != nil {
+		fmt.Println(i18n.T("=== Environment ==="))
+		fmt.Printf(i18n.T("OS:         %s/%s\n"), env.OS, env.Arch)
+		if env.Distro != "" {
+			fmt.Printf(i18n.T("Distro:     %s\n"), strings.TrimSpace(env.Distro+" "+env.DistroVersion))
+		}
+		if env.WSL {
+			fmt.Println(i18n.T("WSL:        yes"))
 		}
+		if env.Shell != "" {
+			fmt.Printf(i18n.T("Shell:      %s\n"), env.Shell)
+		}
+		fmt.Printf(i18n.T("Shells:     %s\n"), listOrNone(env.Shells))
+		fmt.Printf(i18n.T("Containers: %s\n"), listOrNone(env.ContainerRuntimes))
+		fmt.Println()
 	}
+}
 
-	installedPackageManagers := []string{}
-	for pm, found := range ScanResult.PackageManagers {
-		if found {
-			installedPackageManagers = append(installedPackageManagers, pm)
-		}
+// listOrNone joins names for display, or returns "none"
+func listOrNone(names []string) string {
+	if len(names) == 0 {
+		return "none"
 	}
+	return strings.Join(names, ", ")
+}
 
-	installedDevTools := []string{}
-	for tool, found := range ScanResult.DevTools {
-		if found {
-			installedDevTools = append(installedDevTools, tool)
+// installed returns the names of the tools that were found, sorted
+func installed(tools map[string]bool) []string {
+	names := []string{}
+	for _, name := range utils.SortedKeys(tools) {
+		if tools[name] {
+			names = append(names, name)
 		}
 	}
+	return names
+}
+
+func SaveConfig(ScanResult *ScanResult) error {
+	// Convert maps to slices
+	installedLanguages := installed(ScanResult.Languages)
+	installedPackageManagers := installed(ScanResult.PackageManagers)
+	installedDevTools := installed(ScanResult.DevTools)
 
 	// Save to config
 	if err := config.SetConfigValue("installed_languages", installedLanguages); err != nil {
@@ -258,6 +287,12 @@ func SaveConfig(ScanResult *ScanResult) error {
 		return err
 	}
 
+	if ScanResult.Environment != nil {
+		if err := config.SetConfigValue("environment", ScanResult.Environment); err != nil {
+			return err
+		}
+	}
+
 	// Save VS Code path if found
 	if ScanResult.VSCodePath != "" {
 		if err := config.SetConfigValue("vscode_path", ScanResult.VSCodePath); err != nil {
@@ -0,0 +1,93 @@
+package detect
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/config"
+)
+
+// shellBinaries are the shells looked for, in the order they are listed
+var shellBinaries = []string{"bash", "zsh", "fish", "sh", "nu", "pwsh", "powershell", "cmd"}
+
+// containerRuntimes are the container runtimes looked for, most preferred first
+var containerRuntimes = []string{"docker", "podman", "nerdctl", "containerd", "colima"}
+
+// ScanEnvironment captures the OS, architecture, distribution, shells and container runtimes
+func ScanEnvironment() *config.Environment {
+	env := &config.Environment{
+		OS:   runtime.GOOS,
+		Arch: runtime.GOARCH,
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		release := readOSRelease("/etc/os-release")
+		env.Distro = release["ID"]
+		env.DistroVersion = release["VERSION_ID"]
+		env.WSL = isWSL()
+	case "darwin":
+		env.Distro = "macos"
+		env.DistroVersion = commandOutput("sw_vers", "-productVersion")
+	case "windows":
+		env.Distro = "windows"
+		env.DistroVersion = strings.TrimSpace(strings.TrimPrefix(commandOutput("cmd", "/c", "ver"), "Microsoft Windows"))
+	}
+
+	env.Shell = os.Getenv("SHELL")
+	if env.Shell == "" && runtime.GOOS == "windows" {
+		env.Shell = "powershell"
+	}
+	for _, shell := range shellBinaries {
+		if _, err := exec.LookPath(shell); err == nil {
+			env.Shells = append(env.Shells, shell)
+		}
+	}
+	for _, name := range containerRuntimes {
+		if _, err := exec.LookPath(name); err == nil {
+			env.ContainerRuntimes = append(env.ContainerRuntimes, name)
+		}
+	}
+	return env
+}
+
+// readOSRelease parses an os-release file into its KEY=value pairs
+func readOSRelease(path string) map[string]string {
+	values := map[string]string{}
+	f, err := os.Open(path)
+	if err != nil {
+		return values
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok || strings.HasPrefix(key, "#") {
+			continue
+		}
+		values[key] = strings.Trim(value, `"'`)
+	}
+	return values
+}
+
+// isWSL reports whether Linux runs under the Windows Subsystem for Linux
+func isWSL() bool {
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		return true
+	}
+	data, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	return err == nil && strings.Contains(strings.ToLower(string(data)), "microsoft")
+}
+
+// commandOutput returns the trimmed output of a command, or "" if it fails
+func commandOutput(name string, args ...string) string {
+	out, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,159 @@
+package detect
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// installCommands are the install commands of the system package managers hints suggest
+var installCommands = map[string]string{
+	"brew":   "brew install %s",
+	"apt":    "sudo apt install %s",
+	"dnf":    "sudo dnf install %s",
+	"pacman": "sudo pacman -S %s",
+	"apk":    "sudo apk add %s",
+	"winget": "winget install %s",
+}
+
+// distroManagers maps os-release IDs to their package manager
+var distroManagers = map[string]string{
+	"ubuntu": "apt", "debian": "apt", "linuxmint": "apt", "pop": "apt", "raspbian": "apt",
+	"fedora": "dnf", "rhel": "dnf", "centos": "dnf", "rocky": "dnf", "almalinux": "dnf",
+	"arch": "pacman", "manjaro": "pacman", "endeavouros": "pacman",
+	"alpine": "apk",
+}
+
+// packages maps programs to the package providing them, per package manager; "*" applies to
+// every manager without an entry of its own
+var packages = map[string]map[string]string{
+	"node":     {"brew": "node", "apt": "nodejs", "dnf": "nodejs", "pacman": "nodejs", "apk": "nodejs", "winget": "OpenJS.NodeJS"},
+	"npm":      {"brew": "node", "apt": "npm", "dnf": "nodejs", "pacman": "npm", "apk": "npm", "winget": "OpenJS.NodeJS"},
+	"npx":      {"brew": "node", "apt": "npm", "dnf": "nodejs", "pacman": "npm", "apk": "npm", "winget": "OpenJS.NodeJS"},
+	"go":       {"apt": "golang-go", "dnf": "golang", "winget": "GoLang.Go", "*": "go"},
+	"cargo":    {"brew": "rust", "pacman": "rust", "winget": "Rustlang.Rustup", "*": "cargo"},
+	"rustc":    {"apt": "rustc", "dnf": "rust", "apk": "rust", "winget": "Rustlang.Rustup", "*": "rust"},
+	"python3":  {"brew": "python", "pacman": "python", "winget": "Python.Python.3.12", "*": "python3"},
+	"pip3":     {"brew": "python", "apt": "python3-pip", "dnf": "python3-pip", "pacman": "python-pip", "apk": "py3-pip", "winget": "Python.Python.3.12"},
+	"git":      {"winget": "Git.Git", "*": "git"},
+	"docker":   {"brew": "--cask docker", "apt": "docker.io", "dnf": "moby-engine", "winget": "Docker.DockerDesktop", "*": "docker"},
+	"make":     {"winget": "GnuWin32.Make", "*": "make"},
+	"cmake":    {"winget": "Kitware.CMake", "*": "cmake"},
+	"mvn":      {"winget": "", "*": "maven"},
+	"gradle":   {"winget": "", "*": "gradle"},
+	"javac":    {"brew": "openjdk", "apt": "default-jdk", "dnf": "java-latest-openjdk-devel", "pacman": "jdk-openjdk", "apk": "openjdk21", "winget": "Microsoft.OpenJDK.21"},
+	"dotnet":   {"brew": "--cask dotnet-sdk", "apt": "dotnet-sdk-8.0", "dnf": "dotnet-sdk-8.0", "pacman": "dotnet-sdk", "winget": "Microsoft.DotNet.SDK.8"},
+	"ruby":     {"winget": "RubyInstallerTeam.Ruby.3.3", "*": "ruby"},
+	"bundle":   {"brew": "ruby", "apt": "ruby-bundler", "dnf": "rubygem-bundler", "pacman": "ruby-bundler", "apk": "ruby-bundler"},
+	"php":      {"winget": "PHP.PHP", "*": "php"},
+	"composer": {"apt": "composer", "brew": "composer", "dnf": "composer", "pacman": "composer", "apk": "composer"},
+}
+
+// npmPackages are programs installed with npm wherever node is
+var npmPackages = map[string]string{"yarn": "yarn", "pnpm": "pnpm", "tsc": "typescript"}
+
+// Missing returns the programs that are not on PATH, in order
+func Missing(programs []string) []string {
+	var missing []string
+	for _, program := range programs {
+		if _, err := exec.LookPath(program); err != nil {
+			missing = append(missing, program)
+		}
+	}
+	return missing
+}
+
+// InstallHint explains that program is missing, using what the last foundry detect saved in
+// cfg, and suggests the command installing it with the machine's package manager
+func InstallHint(program string, cfg *config.Config) string {
+	var hint string
+	switch tool, detected := detectedTool(program, cfg); {
+	case cfg == nil || cfg.Environment == nil:
+		hint = i18n.Sprintf("%s is not installed (run foundry detect to check your tools)", program)
+	case detected:
+		hint = i18n.Sprintf("%s is not on PATH, though foundry detect found %s before", program, tool)
+	default:
+		hint = i18n.Sprintf("%s was not detected on this machine (foundry detect)", program)
+	}
+	if install := installCommand(program, cfg); install != "" {
+		hint += "; " + i18n.Sprintf("install it with: %s", install)
+	}
+	return hint
+}
+
+// detectedTool returns the detect tool name of program and whether foundry detect found it
+func detectedTool(program string, cfg *config.Config) (string, bool) {
+	if cfg == nil {
+		return program, false
+	}
+	installed := map[string]bool{}
+	for _, list := range [][]string{cfg.InstalledLanguages, cfg.InstalledPackageManagers, cfg.InstalledDevTools} {
+		for _, name := range list {
+			installed[name] = true
+		}
+	}
+	for _, category := range []string{"Package Managers", "Development Tools", "Languages"} {
+		tools := toolCategories()[category]
+		for _, name := range utils.SortedKeys(tools) {
+			if tools[name] == program {
+				return name, installed[name]
+			}
+		}
+	}
+	return program, installed[program]
+}
+
+// installCommand returns the command installing program, or "" when no package is known
+func installCommand(program string, cfg *config.Config) string {
+	if pkg, ok := npmPackages[program]; ok {
+		return "npm install -g " + pkg
+	}
+	manager := packageManager(cfg)
+	byManager, ok := packages[program]
+	if !ok || manager == "" {
+		return ""
+	}
+	pkg, ok := byManager[manager]
+	if !ok {
+		pkg = byManager["*"]
+	}
+	if pkg == "" {
+		return ""
+	}
+	return strings.Replace(installCommands[manager], "%s", pkg, 1)
+}
+
+// packageManager returns the system package manager hints use: Homebrew when foundry detect
+// found it, else the one of the Linux distribution (or apt when detected), or winget on Windows
+func packageManager(cfg *config.Config) string {
+	goos := runtime.GOOS
+	if cfg != nil {
+		detected := map[string]bool{}
+		for _, name := range cfg.InstalledPackageManagers {
+			detected[name] = true
+		}
+		if detected["brew"] {
+			return "brew"
+		}
+		if env := cfg.Environment; env != nil {
+			if manager, ok := distroManagers[strings.ToLower(env.Distro)]; ok {
+				return manager
+			}
+			goos = env.OS
+		}
+		if detected["apt"] {
+			return "apt"
+		}
+	}
+	switch goos {
+	case "darwin":
+		return "brew"
+	case "windows":
+		return "winget"
+	}
+	return ""
+}
@@ -0,0 +1,182 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxCells bounds the line-matching table; larger inputs are shown as a full replacement
+const maxCells = 4_000_000
+
+// Op is one line of an edit script
+type Op struct {
+	Kind byte // ' ' kept, '-' removed, '+' added
+	Line string
+}
+
+// Lines splits text into lines without their line endings
+func Lines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Edits returns the line edits that turn a into b, based on their longest common subsequence
+func Edits(a, b []string) []Op {
+	n, m := len(a), len(b)
+	if n*m > maxCells {
+		ops := make([]Op, 0, n+m)
+		for _, l := range a {
+			ops = append(ops, Op{'-', l})
+		}
+		for _, l := range b {
+			ops = append(ops, Op{'+', l})
+		}
+		return ops
+	}
+
+	// lcs[i][j] is the common subsequence length of a[i:] and b[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]Op, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, Op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, Op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, Op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, Op{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, Op{'+', b[j]})
+	}
+	return ops
+}
+
+// Unified returns a unified diff from a to b with the given lines of context,
+// or "" when they are equal
+func Unified(fromName, toName, a, b string, context int) string {
+	ops := Edits(Lines(a), Lines(b))
+
+	var out strings.Builder
+	for start := 0; start < len(ops); {
+		// Find the next change and extend the hunk while changes are close together
+		first := start
+		for first < len(ops) && ops[first].Kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		last := first
+		for k := first; k < len(ops); k++ {
+			if ops[k].Kind != ' ' {
+				last = k
+			} else if k-last > 2*context {
+				break
+			}
+		}
+		from := first - context
+		if from < start {
+			from = start
+		}
+		to := last + context + 1
+		if to > len(ops) {
+			to = len(ops)
+		}
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		aStart, bStart := position(ops[:from])
+		aLen, bLen := position(ops[from:to])
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", span(aStart, aLen), span(bStart, bLen))
+		for _, op := range ops[from:to] {
+			fmt.Fprintf(&out, "%c%s\n", op.Kind, op.Line)
+		}
+		start = to
+	}
+	return out.String()
+}
+
+// position counts the lines of a and b covered by ops
+func position(ops []Op) (a, b int) {
+	for _, op := range ops {
+		if op.Kind != '+' {
+			a++
+		}
+		if op.Kind != '-' {
+			b++
+		}
+	}
+	return a, b
+}
+
+// span formats a hunk range the way diff(1) does: 1-based start, length omitted when 1
+func span(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+// Markers merges a and b into one text where every differing region is wrapped in
+// conflict markers labelled aLabel and bLabel, for the user to resolve in an editor
+func Markers(a, b, aLabel, bLabel string) string {
+	ops := Edits(Lines(a), Lines(b))
+
+	var out strings.Builder
+	for k := 0; k < len(ops); {
+		if ops[k].Kind == ' ' {
+			out.WriteString(ops[k].Line + "\n")
+			k++
+			continue
+		}
+		var ours, theirs []string
+		for ; k < len(ops) && ops[k].Kind != ' '; k++ {
+			if ops[k].Kind == '-' {
+				ours = append(ours, ops[k].Line)
+			} else {
+				theirs = append(theirs, ops[k].Line)
+			}
+		}
+		fmt.Fprintf(&out, "<<<<<<< %s\n", aLabel)
+		for _, l := range ours {
+			out.WriteString(l + "\n")
+		}
+		out.WriteString("=======\n")
+		for _, l := range theirs {
+			out.WriteString(l + "\n")
+		}
+		fmt.Fprintf(&out, ">>>>>>> %s\n", bLabel)
+	}
+	return out.String()
+}
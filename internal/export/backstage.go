@@ -0,0 +1,306 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/project"
+	"github.com/kajvans/foundry/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// placeholderPattern matches Foundry placeholders such as {{PROJECT_NAME}}
+var placeholderPattern = regexp.MustCompile(`\{\{([A-Z][A-Z0-9_]*)\}\}`)
+
+// nunjucksEscapes keeps template syntax already in the files (GitHub Actions expressions,
+// Jinja blocks) from being evaluated by Backstage's Nunjucks renderer
+var nunjucksEscapes = strings.NewReplacer(
+	"${{", "${{ '${{' }}",
+	"{%", "${{ '{%' }}",
+	"{#", "${{ '{#' }}",
+)
+
+// skippedDirs are never part of a skeleton
+var skippedDirs = map[string]bool{
+	".git": true, "node_modules": true, "vendor": true, ".venv": true, "dist": true, "build": true,
+}
+
+// backstageTemplate is a Backstage Software Template (scaffolder.backstage.io/v1beta3)
+type backstageTemplate struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   backstageMetadata `yaml:"metadata"`
+	Spec       backstageSpec     `yaml:"spec"`
+}
+
+type backstageMetadata struct {
+	Name        string   `yaml:"name"`
+	Title       string   `yaml:"title,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	Links       []link   `yaml:"links,omitempty"`
+}
+
+type link struct {
+	URL   string `yaml:"url"`
+	Title string `yaml:"title,omitempty"`
+	Icon  string `yaml:"icon,omitempty"`
+}
+
+type backstageSpec struct {
+	Owner      string                 `yaml:"owner"`
+	Type       string                 `yaml:"type"`
+	Parameters []parameterGroup       `yaml:"parameters"`
+	Steps      []step                 `yaml:"steps"`
+	Output     map[string]interface{} `yaml:"output,omitempty"`
+}
+
+type parameterGroup struct {
+	Title      string              `yaml:"title"`
+	Required   []string            `yaml:"required,omitempty"`
+	Properties map[string]property `yaml:"properties"`
+}
+
+type property struct {
+	Title       string                 `yaml:"title"`
+	Type        string                 `yaml:"type"`
+	Description string                 `yaml:"description,omitempty"`
+	Default     string                 `yaml:"default,omitempty"`
+	UIField     string                 `yaml:"ui:field,omitempty"`
+	UIOptions   map[string]interface{} `yaml:"ui:options,omitempty"`
+}
+
+type step struct {
+	ID     string                 `yaml:"id"`
+	Name   string                 `yaml:"name"`
+	Action string                 `yaml:"action"`
+	Input  map[string]interface{} `yaml:"input"`
+}
+
+// Backstage writes a Backstage Software Template for tmpl into outDir: template.yaml and a
+// skeleton/ directory holding the template files with Foundry placeholders turned into
+// Backstage expressions. m is the template's manifest and may be nil.
+func Backstage(tmpl *config.Template, m *manifest.Manifest, outDir string) error {
+	skeleton := filepath.Join(outDir, "skeleton")
+	if err := writeSkeleton(tmpl.Path, skeleton); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(skeleton, "catalog-info.yaml")); os.IsNotExist(err) {
+		if err := os.WriteFile(filepath.Join(skeleton, "catalog-info.yaml"), []byte(catalogInfo(tmpl)), 0644); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(templateDefinition(tmpl, m)); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outDir, "template.yaml"), buf.Bytes(), 0644)
+}
+
+// templateDefinition builds template.yaml: parameters for the project name, owner and manifest
+// variables, then fetch, publish and register steps
+func templateDefinition(tmpl *config.Template, m *manifest.Manifest) *backstageTemplate {
+	project := parameterGroup{
+		Title:    "Project",
+		Required: []string{"name", "owner"},
+		Properties: map[string]property{
+			"name":        {Title: "Name", Type: "string", Description: "Name of the new project ({{PROJECT_NAME}})"},
+			"owner":       {Title: "Owner", Type: "string", UIField: "OwnerPicker", UIOptions: map[string]interface{}{"catalogFilter": map[string]interface{}{"kind": "Group"}}},
+			"author":      {Title: "Author", Type: "string", Description: "Author written into the project ({{AUTHOR}})"},
+			"VERSION":     {Title: "Initial version", Type: "string", Default: "0.1.0"},
+			"description": {Title: "Description", Type: "string"},
+		},
+	}
+	values := map[string]interface{}{
+		"name":        "${{ parameters.name }}",
+		"owner":       "${{ parameters.owner }}",
+		"author":      "${{ parameters.author }}",
+		"VERSION":     "${{ parameters.VERSION }}",
+		"description": "${{ parameters.description }}",
+	}
+
+	// The manifest wins over what was saved with template add, as when the template is used
+	description, owner, homepage := tmpl.Description, tmpl.Maintainer, tmpl.Homepage
+	if m != nil {
+		if m.Description != "" {
+			description = m.Description
+		}
+		if m.Maintainer != "" {
+			owner = m.Maintainer
+		}
+		if m.Homepage != "" {
+			homepage = m.Homepage
+		}
+		for _, v := range m.Variables {
+			title := v.Prompt
+			if title == "" {
+				title = v.Name
+			}
+			project.Properties[v.Name] = property{Title: title, Type: "string", Default: v.Default}
+			if v.Required && v.Default == "" {
+				project.Required = append(project.Required, v.Name)
+			}
+			values[v.Name] = "${{ parameters." + v.Name + " }}"
+		}
+	}
+
+	if owner == "" {
+		owner = "unknown"
+	}
+	var links []link
+	if homepage != "" {
+		links = append(links, link{URL: homepage, Title: "Homepage", Icon: "docs"})
+	}
+
+	var tags []string
+	for _, tag := range []string{tmpl.Language, tmpl.Framework} {
+		if tag != "" {
+			tags = append(tags, strings.ToLower(tag))
+		}
+	}
+
+	repository := parameterGroup{
+		Title:    "Repository",
+		Required: []string{"repoUrl"},
+		Properties: map[string]property{
+			"repoUrl": {Title: "Repository location", Type: "string", UIField: "RepoUrlPicker", UIOptions: map[string]interface{}{"allowedHosts": []string{"github.com"}}},
+		},
+	}
+
+	return &backstageTemplate{
+		APIVersion: "scaffolder.backstage.io/v1beta3",
+		Kind:       "Template",
+		Metadata: backstageMetadata{
+			Name:        strings.ToLower(tmpl.Name),
+			Title:       tmpl.Name,
+			Description: description,
+			Tags:        tags,
+			Links:       links,
+		},
+		Spec: backstageSpec{
+			Owner:      owner,
+			Type:       "service",
+			Parameters: []parameterGroup{project, repository},
+			Steps: []step{
+				{ID: "fetch", Name: "Fetch skeleton", Action: "fetch:template", Input: map[string]interface{}{
+					"url":    "./skeleton",
+					"values": values,
+				}},
+				{ID: "publish", Name: "Publish", Action: "publish:github", Input: map[string]interface{}{
+					"repoUrl":     "${{ parameters.repoUrl }}",
+					"description": "${{ parameters.description }}",
+				}},
+				{ID: "register", Name: "Register", Action: "catalog:register", Input: map[string]interface{}{
+					"repoContentsUrl": "${{ steps['publish'].output.repoContentsUrl }}",
+					"catalogInfoPath": "/catalog-info.yaml",
+				}},
+			},
+			Output: map[string]interface{}{
+				"links": []map[string]string{
+					{"title": "Repository", "url": "${{ steps['publish'].output.remoteUrl }}"},
+					{"title": "Open in catalog", "icon": "catalog", "entityRef": "${{ steps['register'].output.entityRef }}"},
+				},
+			},
+		},
+	}
+}
+
+// catalogInfo returns the catalog-info.yaml added to skeletons that do not ship one
+func catalogInfo(tmpl *config.Template) string {
+	return fmt.Sprintf(`apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: ${{ values.name | dump }}
+  description: ${{ values.description | dump }}
+  tags:
+    - %s
+spec:
+  type: service
+  lifecycle: experimental
+  owner: ${{ values.owner | dump }}
+`, strings.ToLower(tmpl.Language))
+}
+
+// writeSkeleton copies the template files into dir, converting placeholders in text files
+func writeSkeleton(templateDir, dir string) error {
+	ignores := utils.LoadIgnorePatterns(templateDir, ".foundryignore")
+	verbatim := project.VerbatimPatterns(templateDir)
+	return filepath.Walk(templateDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(templateDir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if utils.MatchIgnore(filepath.ToSlash(rel), ignores) || (info.IsDir() && skippedDirs[info.Name()]) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		target := filepath.Join(dir, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if rel == manifest.FileName || rel == project.KeepFile || !info.Mode().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case utils.IsBinaryFile(rel, data):
+		case utils.MatchIgnore(filepath.ToSlash(rel), verbatim):
+			// copied verbatim by Foundry, so only Nunjucks syntax is escaped
+			data = []byte(nunjucksEscapes.Replace(string(data)))
+		default:
+			content, err := utils.ExpandIncludes(templateDir, rel, string(data))
+			if err != nil {
+				return err
+			}
+			data = []byte(ToNunjucks(content))
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+// ToNunjucks turns Foundry placeholders into Backstage template expressions, escaping
+// any Nunjucks syntax the file already contains
+func ToNunjucks(content string) string {
+	content = nunjucksEscapes.Replace(content)
+	return placeholderPattern.ReplaceAllStringFunc(content, func(match string) string {
+		switch name := placeholderPattern.FindStringSubmatch(match)[1]; name {
+		case "PROJECT_NAME":
+			return "${{ values.name }}"
+		case "PROJECT_NAME_LOWER":
+			return "${{ values.name | lower }}"
+		case "PROJECT_NAME_UPPER":
+			return "${{ values.name | upper }}"
+		case "PROJECT_NAME_KEBAB", "PROJECT_SLUG", "PROJECT_NAME_DNS":
+			return "${{ values.name | lower | replace(' ', '-') | replace('_', '-') }}"
+		case "PROJECT_NAME_SNAKE", "PROJECT_NAME_DB":
+			return "${{ values.name | lower | replace(' ', '_') | replace('-', '_') }}"
+		case "PROJECT_NAME_ALNUM":
+			return "${{ values.name | lower | replace(' ', '') | replace('-', '') | replace('_', '') }}"
+		case "AUTHOR":
+			return "${{ values.author }}"
+		default:
+			return "${{ values." + name + " }}"
+		}
+	})
+}
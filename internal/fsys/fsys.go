@@ -0,0 +1,109 @@
+// Package fsys is the filesystem that rendering, scanning and config code read and write
+// through. The package functions delegate to the current FS, the real filesystem unless
+// replaced, so that code can run in memory in tests or against virtual template sources.
+package fsys
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FS is a writable filesystem with the subset of the os package Foundry uses.
+// Errors follow the os conventions, so os.IsNotExist and errors.Is(err, fs.ErrNotExist) work.
+type FS interface {
+	Open(name string) (io.ReadCloser, error)
+	Create(name string) (io.WriteCloser, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	Stat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	MkdirAll(path string, perm fs.FileMode) error
+	Remove(name string) error
+	RemoveAll(path string) error
+	Rename(oldpath, newpath string) error
+	Chmod(name string, mode fs.FileMode) error
+	// Symlink creates newname as a link to oldname, and Readlink returns a link's target
+	Symlink(oldname, newname string) error
+	Readlink(name string) (string, error)
+	// Walk visits root and everything below it in lexical order, like filepath.Walk
+	Walk(root string, fn filepath.WalkFunc) error
+}
+
+// current is the FS the package functions use
+var current FS = OS{}
+
+// Set makes f the filesystem for all later calls
+func Set(f FS) {
+	current = f
+}
+
+// Get returns the current filesystem
+func Get() FS {
+	return current
+}
+
+// Open opens a file for reading on the current FS
+func Open(name string) (io.ReadCloser, error) { return current.Open(name) }
+
+// Create creates or truncates a file for writing on the current FS
+func Create(name string) (io.WriteCloser, error) { return current.Create(name) }
+
+// ReadFile reads a whole file from the current FS
+func ReadFile(name string) ([]byte, error) { return current.ReadFile(name) }
+
+// WriteFile writes a whole file to the current FS
+func WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return current.WriteFile(name, data, perm)
+}
+
+// Stat describes a file on the current FS
+func Stat(name string) (fs.FileInfo, error) { return current.Stat(name) }
+
+// ReadDir lists a directory on the current FS, sorted by name
+func ReadDir(name string) ([]fs.DirEntry, error) { return current.ReadDir(name) }
+
+// MkdirAll creates a directory and its parents on the current FS
+func MkdirAll(path string, perm fs.FileMode) error { return current.MkdirAll(path, perm) }
+
+// Remove removes a file or empty directory from the current FS
+func Remove(name string) error { return current.Remove(name) }
+
+// RemoveAll removes path and everything below it from the current FS
+func RemoveAll(path string) error { return current.RemoveAll(path) }
+
+// Rename moves a file or directory on the current FS
+func Rename(oldpath, newpath string) error { return current.Rename(oldpath, newpath) }
+
+// Chmod changes the permissions of a file on the current FS
+func Chmod(name string, mode fs.FileMode) error { return current.Chmod(name, mode) }
+
+// Symlink creates newname as a symbolic link to oldname on the current FS
+func Symlink(oldname, newname string) error { return current.Symlink(oldname, newname) }
+
+// Readlink returns the target of a symbolic link on the current FS
+func Readlink(name string) (string, error) { return current.Readlink(name) }
+
+// Walk walks the tree at root on the current FS
+func Walk(root string, fn filepath.WalkFunc) error { return current.Walk(root, fn) }
+
+// OS is the real filesystem
+type OS struct{}
+
+func (OS) Open(name string) (io.ReadCloser, error)    { return os.Open(name) }
+func (OS) Create(name string) (io.WriteCloser, error) { return os.Create(name) }
+func (OS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+func (OS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+func (OS) Stat(name string) (fs.FileInfo, error)        { return os.Stat(name) }
+func (OS) ReadDir(name string) ([]fs.DirEntry, error)   { return os.ReadDir(name) }
+func (OS) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+func (OS) Remove(name string) error                     { return os.Remove(name) }
+func (OS) RemoveAll(path string) error                  { return os.RemoveAll(path) }
+func (OS) Rename(oldpath, newpath string) error         { return os.Rename(oldpath, newpath) }
+func (OS) Chmod(name string, mode fs.FileMode) error    { return os.Chmod(name, mode) }
+func (OS) Symlink(oldname, newname string) error        { return os.Symlink(oldname, newname) }
+func (OS) Readlink(name string) (string, error)         { return os.Readlink(name) }
+func (OS) Walk(root string, fn filepath.WalkFunc) error { return filepath.Walk(root, fn) }
@@ -0,0 +1,347 @@
+package fsys
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mem is an in-memory FS for tests and virtual template sources. Paths are cleaned, so
+// "a/./b" and "a/b" name the same file; the root directory always exists. Symbolic links are
+// stored but never resolved: Stat and Walk describe the link itself.
+type Mem struct {
+	mu    sync.Mutex
+	nodes map[string]*memNode
+}
+
+// memNode is a file or directory held by Mem
+type memNode struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// NewMem returns an empty in-memory filesystem
+func NewMem() *Mem {
+	return &Mem{nodes: map[string]*memNode{}}
+}
+
+// clean normalizes name so every spelling of a path maps to one key
+func clean(name string) string {
+	return filepath.Clean(name)
+}
+
+// isRoot reports whether the cleaned path is a filesystem root
+func isRoot(name string) bool {
+	return name == "." || name == string(filepath.Separator) || filepath.Dir(name) == name
+}
+
+// lookup returns the node at the cleaned path name; roots are implicit directories
+func (m *Mem) lookup(name string) (*memNode, bool) {
+	if n, ok := m.nodes[name]; ok {
+		return n, true
+	}
+	if isRoot(name) {
+		return &memNode{mode: fs.ModeDir | 0755}, true
+	}
+	return nil, false
+}
+
+// parentDir checks that the parent of name exists and is a directory
+func (m *Mem) parentDir(op, name string) error {
+	parent, ok := m.lookup(filepath.Dir(name))
+	if !ok {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	if !parent.mode.IsDir() {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return nil
+}
+
+func (m *Mem) Open(name string) (io.ReadCloser, error) {
+	data, err := m.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *Mem) Create(name string) (io.WriteCloser, error) {
+	if err := m.WriteFile(name, nil, 0666); err != nil {
+		return nil, err
+	}
+	return &memWriter{m: m, name: clean(name)}, nil
+}
+
+func (m *Mem) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, ok := m.lookup(clean(name))
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if n.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	return append([]byte(nil), n.data...), nil
+}
+
+func (m *Mem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := clean(name)
+	if err := m.parentDir("open", key); err != nil {
+		return err
+	}
+	if n, ok := m.nodes[key]; ok {
+		if n.mode.IsDir() {
+			return &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+		}
+		// like os.WriteFile, an existing file keeps its permissions
+		perm = n.mode.Perm()
+	}
+	m.nodes[key] = &memNode{data: append([]byte(nil), data...), mode: perm.Perm(), modTime: time.Now()}
+	return nil
+}
+
+func (m *Mem) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := clean(name)
+	n, ok := m.lookup(key)
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return memInfo{name: filepath.Base(key), node: n}, nil
+}
+
+func (m *Mem) ReadDir(name string) ([]fs.DirEntry, error) {
+	info, err := m.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, &fs.PathError{Op: "readdirent", Path: name, Err: fs.ErrInvalid}
+	}
+	var entries []fs.DirEntry
+	for _, child := range m.children(clean(name)) {
+		if childInfo, err := m.Stat(filepath.Join(name, child)); err == nil {
+			entries = append(entries, fs.FileInfoToDirEntry(childInfo))
+		}
+	}
+	return entries, nil
+}
+
+func (m *Mem) MkdirAll(path string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := clean(path)
+	var missing []string
+	for p := key; !isRoot(p); p = filepath.Dir(p) {
+		n, ok := m.nodes[p]
+		if ok {
+			if !n.mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: p, Err: fs.ErrInvalid}
+			}
+			break
+		}
+		missing = append(missing, p)
+	}
+	for _, p := range missing {
+		m.nodes[p] = &memNode{mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+	}
+	return nil
+}
+
+func (m *Mem) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := clean(name)
+	if _, ok := m.nodes[key]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if len(m.below(key)) > 0 {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrExist}
+	}
+	delete(m.nodes, key)
+	return nil
+}
+
+func (m *Mem) RemoveAll(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := clean(path)
+	for _, p := range m.below(key) {
+		delete(m.nodes, p)
+	}
+	delete(m.nodes, key)
+	return nil
+}
+
+func (m *Mem) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	from, to := clean(oldpath), clean(newpath)
+	n, ok := m.nodes[from]
+	if !ok {
+		return &fs.PathError{Op: "rename", Path: oldpath, Err: fs.ErrNotExist}
+	}
+	if err := m.parentDir("rename", to); err != nil {
+		return err
+	}
+	if existing, ok := m.nodes[to]; ok && existing.mode.IsDir() && len(m.below(to)) > 0 {
+		return &fs.PathError{Op: "rename", Path: newpath, Err: fs.ErrExist}
+	}
+	for _, p := range m.below(from) {
+		m.nodes[to+p[len(from):]] = m.nodes[p]
+		delete(m.nodes, p)
+	}
+	delete(m.nodes, from)
+	m.nodes[to] = n
+	return nil
+}
+
+func (m *Mem) Chmod(name string, mode fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, ok := m.nodes[clean(name)]
+	if !ok {
+		return &fs.PathError{Op: "chmod", Path: name, Err: fs.ErrNotExist}
+	}
+	n.mode = n.mode.Type() | mode.Perm()
+	return nil
+}
+
+func (m *Mem) Symlink(oldname, newname string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := clean(newname)
+	if err := m.parentDir("symlink", key); err != nil {
+		return err
+	}
+	if _, ok := m.nodes[key]; ok {
+		return &fs.PathError{Op: "symlink", Path: newname, Err: fs.ErrExist}
+	}
+	m.nodes[key] = &memNode{data: []byte(oldname), mode: fs.ModeSymlink | 0777, modTime: time.Now()}
+	return nil
+}
+
+func (m *Mem) Readlink(name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, ok := m.nodes[clean(name)]
+	if !ok {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrNotExist}
+	}
+	if n.mode&fs.ModeSymlink == 0 {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+	}
+	return string(n.data), nil
+}
+
+func (m *Mem) Walk(root string, fn filepath.WalkFunc) error {
+	info, err := m.Stat(root)
+	if err != nil {
+		return fn(root, nil, err)
+	}
+	err = m.walk(root, info, fn)
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
+
+// walk calls fn for path and, if it is a directory, for its children in lexical order
+func (m *Mem) walk(path string, info fs.FileInfo, fn filepath.WalkFunc) error {
+	if err := fn(path, info, nil); err != nil || !info.IsDir() {
+		if err == filepath.SkipDir && info.IsDir() {
+			return nil
+		}
+		return err
+	}
+	for _, child := range m.children(clean(path)) {
+		name := filepath.Join(path, child)
+		childInfo, err := m.Stat(name)
+		if err != nil {
+			// removed by fn while walking
+			continue
+		}
+		if err := m.walk(name, childInfo, fn); err != nil {
+			if err == filepath.SkipDir && !childInfo.IsDir() {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// children returns the sorted names directly inside dir
+func (m *Mem) children(dir string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var names []string
+	for p := range m.nodes {
+		if p != dir && filepath.Dir(p) == dir {
+			names = append(names, filepath.Base(p))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// below returns every path strictly inside dir; the caller holds the lock
+func (m *Mem) below(dir string) []string {
+	prefix := dir + string(filepath.Separator)
+	if isRoot(dir) {
+		prefix = strings.TrimSuffix(dir, string(filepath.Separator)) + string(filepath.Separator)
+	}
+	var paths []string
+	for p := range m.nodes {
+		if strings.HasPrefix(p, prefix) || dir == "." && !filepath.IsAbs(p) {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// memWriter buffers a Create'd file and stores the content on every write
+type memWriter struct {
+	m    *Mem
+	name string
+	buf  bytes.Buffer
+}
+
+func (w *memWriter) Write(p []byte) (int, error) {
+	n, _ := w.buf.Write(p)
+	w.m.mu.Lock()
+	defer w.m.mu.Unlock()
+	if node, ok := w.m.nodes[w.name]; ok {
+		node.data = append(node.data[:0], w.buf.Bytes()...)
+		node.modTime = time.Now()
+	}
+	return n, nil
+}
+
+func (w *memWriter) Close() error {
+	return nil
+}
+
+// memInfo is the fs.FileInfo of a Mem node
+type memInfo struct {
+	name string
+	node *memNode
+}
+
+func (i memInfo) Name() string       { return i.name }
+func (i memInfo) Size() int64        { return int64(len(i.node.data)) }
+func (i memInfo) Mode() fs.FileMode  { return i.node.mode }
+func (i memInfo) ModTime() time.Time { return i.node.modTime }
+func (i memInfo) IsDir() bool        { return i.node.mode.IsDir() }
+func (i memInfo) Sys() any           { return nil }
@@ -0,0 +1,29 @@
+package generate
+
+import "fmt"
+
+func init() {
+	register(&Generator{
+		Name:        "catalog-info",
+		Description: "Backstage catalog-info.yaml registering the project as a Component",
+		SingleFile:  true,
+		Generate: func(ctx *Context) ([]File, error) {
+			owner := ctx.Variables["OWNER"]
+			if owner == "" {
+				owner = "unknown"
+			}
+			content := fmt.Sprintf(`apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: %s
+  tags:
+    - %s
+spec:
+  type: service
+  lifecycle: experimental
+  owner: %s
+`, dnsName(ctx.ProjectName), dnsName(ctx.Language), owner)
+			return []File{{Path: "catalog-info.yaml", Content: content}}, nil
+		},
+	})
+}
@@ -0,0 +1,194 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// DependencyUpdates tunes the dependabot and renovate generators; the org config sets it under
+// dependency_updates so every project gets the same schedule, reviewers and labels
+type DependencyUpdates struct {
+	Schedule  string   `yaml:"schedule,omitempty"`                 // daily, weekly (default) or monthly
+	Limit     int      `yaml:"open_pull_requests_limit,omitempty"` // open update pull requests at a time
+	Reviewers []string `yaml:"reviewers,omitempty"`
+	Labels    []string `yaml:"labels,omitempty"`
+	Group     bool     `yaml:"group,omitempty"`   // one pull request for all minor and patch updates
+	Extends   []string `yaml:"extends,omitempty"` // Renovate presets, e.g. local>acme/renovate-config
+}
+
+// schedules are the update intervals both tools support
+var schedules = []string{"daily", "weekly", "monthly"}
+
+// Interval returns the update schedule, weekly unless set
+func (d *DependencyUpdates) Interval() string {
+	if d == nil || d.Schedule == "" {
+		return "weekly"
+	}
+	return d.Schedule
+}
+
+// Validate checks the schedule is one both tools support
+func (d *DependencyUpdates) Validate() error {
+	for _, s := range schedules {
+		if d.Interval() == s {
+			return nil
+		}
+	}
+	return i18n.Errorf("dependency_updates: unknown schedule '%s' (use %s)", d.Schedule, strings.Join(schedules, ", "))
+}
+
+// ecosystem is a kind of dependency manifest, named as Dependabot and Renovate call it
+type ecosystem struct {
+	patterns   []string // files at the project root that reveal it
+	dependabot string
+	renovate   []string
+}
+
+// ecosystems are checked in order, which is the order the generated files list them in
+var ecosystems = []ecosystem{
+	{[]string{"go.mod"}, "gomod", []string{"gomod"}},
+	{[]string{"package.json"}, "npm", []string{"npm"}},
+	{[]string{"requirements*.txt", "pyproject.toml", "Pipfile", "setup.py"}, "pip", []string{"pip_requirements", "pep621", "poetry", "pipenv", "pip_setup"}},
+	{[]string{"Cargo.toml"}, "cargo", []string{"cargo"}},
+	{[]string{"pom.xml"}, "maven", []string{"maven"}},
+	{[]string{"build.gradle", "build.gradle.kts"}, "gradle", []string{"gradle"}},
+	{[]string{"Gemfile"}, "bundler", []string{"bundler"}},
+	{[]string{"composer.json"}, "composer", []string{"composer"}},
+	{[]string{"*.csproj", "*.fsproj", "*.sln"}, "nuget", []string{"nuget"}},
+	{[]string{"*.tf"}, "terraform", []string{"terraform"}},
+	{[]string{"Dockerfile"}, "docker", []string{"dockerfile"}},
+	{[]string{"docker-compose.yml", "docker-compose.yaml", "compose.yml", "compose.yaml"}, "docker-compose", []string{"docker-compose"}},
+	{[]string{".github/workflows/*.yml", ".github/workflows/*.yaml"}, "github-actions", []string{"github-actions"}},
+}
+
+// detectEcosystems returns the ecosystems whose manifests the project has at its root
+func detectEcosystems(projectDir string) ([]ecosystem, error) {
+	var found []ecosystem
+	for _, e := range ecosystems {
+		for _, pattern := range e.patterns {
+			if matches, _ := filepath.Glob(filepath.Join(projectDir, filepath.FromSlash(pattern))); len(matches) > 0 {
+				found = append(found, e)
+				break
+			}
+		}
+	}
+	if len(found) == 0 {
+		return nil, i18n.Errorf("no dependency manifests (go.mod, package.json, pyproject.toml, ...) found in %s", projectDir)
+	}
+	return found, nil
+}
+
+// dependencyUpdates returns the project's settings after checking them
+func dependencyUpdates(ctx *Context) (*DependencyUpdates, error) {
+	d := ctx.DependencyUpdates
+	if d == nil {
+		d = &DependencyUpdates{}
+	}
+	return d, d.Validate()
+}
+
+// quotedList writes items as a YAML flow sequence of quoted strings
+func quotedList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// renovateConfig is renovate.json, with its fields in the order Renovate documents them
+type renovateConfig struct {
+	Schema            string           `json:"$schema"`
+	Extends           []string         `json:"extends"`
+	EnabledManagers   []string         `json:"enabledManagers"`
+	Labels            []string         `json:"labels,omitempty"`
+	Reviewers         []string         `json:"reviewers,omitempty"`
+	PrConcurrentLimit int              `json:"prConcurrentLimit,omitempty"`
+	PackageRules      []map[string]any `json:"packageRules,omitempty"`
+}
+
+func init() {
+	register(&Generator{
+		Name:        "dependabot",
+		Description: "Dependabot config updating the ecosystems found in the project",
+		SingleFile:  true,
+		Generate: func(ctx *Context) ([]File, error) {
+			settings, err := dependencyUpdates(ctx)
+			if err != nil {
+				return nil, err
+			}
+			found, err := detectEcosystems(ctx.ProjectDir)
+			if err != nil {
+				return nil, err
+			}
+			var b strings.Builder
+			b.WriteString("version: 2\nupdates:\n")
+			seen := map[string]bool{}
+			for _, e := range found {
+				if seen[e.dependabot] {
+					continue
+				}
+				seen[e.dependabot] = true
+				fmt.Fprintf(&b, "  - package-ecosystem: %q\n    directory: \"/\"\n    schedule:\n      interval: %q\n", e.dependabot, settings.Interval())
+				if settings.Limit > 0 {
+					fmt.Fprintf(&b, "    open-pull-requests-limit: %d\n", settings.Limit)
+				}
+				if len(settings.Reviewers) > 0 {
+					fmt.Fprintf(&b, "    reviewers: %s\n", quotedList(settings.Reviewers))
+				}
+				if len(settings.Labels) > 0 {
+					fmt.Fprintf(&b, "    labels: %s\n", quotedList(settings.Labels))
+				}
+				if settings.Group {
+					b.WriteString("    groups:\n      minor-and-patch:\n        update-types: [\"minor\", \"patch\"]\n")
+				}
+			}
+			return []File{{Path: filepath.Join(".github", "dependabot.yml"), Content: b.String()}}, nil
+		},
+	})
+
+	register(&Generator{
+		Name:        "renovate",
+		Description: "renovate.json enabling the package managers found in the project",
+		SingleFile:  true,
+		Generate: func(ctx *Context) ([]File, error) {
+			settings, err := dependencyUpdates(ctx)
+			if err != nil {
+				return nil, err
+			}
+			found, err := detectEcosystems(ctx.ProjectDir)
+			if err != nil {
+				return nil, err
+			}
+			config := renovateConfig{
+				Schema:            "https://docs.renovatebot.com/renovate-schema.json",
+				Extends:           append([]string{}, settings.Extends...),
+				Labels:            settings.Labels,
+				Reviewers:         settings.Reviewers,
+				PrConcurrentLimit: settings.Limit,
+			}
+			if len(config.Extends) == 0 {
+				config.Extends = []string{"config:recommended"}
+			}
+			config.Extends = append(config.Extends, "schedule:"+settings.Interval())
+			for _, e := range found {
+				config.EnabledManagers = append(config.EnabledManagers, e.renovate...)
+			}
+			if settings.Group {
+				config.PackageRules = []map[string]any{{
+					"matchUpdateTypes": []string{"minor", "patch"},
+					"groupName":        "minor and patch updates",
+				}}
+			}
+			data, err := json.MarshalIndent(config, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			return []File{{Path: "renovate.json", Content: string(data) + "\n"}}, nil
+		},
+	})
+}
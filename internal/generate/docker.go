@@ -0,0 +1,86 @@
+package generate
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+
+	"github.com/kajvans/foundry/internal/lang"
+)
+
+func init() {
+	register(&Generator{
+		Name:        "dockerfile",
+		Description: "Dockerfile building and running the project with its language's official image",
+		SingleFile:  true,
+		With:        []string{"dockerignore"},
+		Generate: func(ctx *Context) ([]File, error) {
+			l, ok := ctx.Settings()
+			if !ok || l.DockerImage == "" {
+				return nil, i18n.Errorf("no Docker image defined for language '%s'", ctx.Language)
+			}
+			var b strings.Builder
+			fmt.Fprintf(&b, "FROM %s\n\n", l.Image(ctx.Versions[l.Tool]))
+			b.WriteString("WORKDIR /app\nCOPY . .\n")
+			if l.Ecosystem != "" {
+				fmt.Fprintf(&b, "RUN %s\n", lang.Expand(l, ctx.ProjectDir, []string{"{{install}}"})[0])
+			}
+			if build, ok := l.Tasks["build"]; ok {
+				fmt.Fprintf(&b, "RUN %s\n", lang.Expand(l, ctx.ProjectDir, []string{build})[0])
+			}
+			if port := ctx.Variables["PORT"]; port != "" {
+				fmt.Fprintf(&b, "\nEXPOSE %s\n", port)
+			}
+			if run, ok := l.Tasks["run"]; ok {
+				fmt.Fprintf(&b, "\nCMD [\"sh\", \"-c\", %q]\n", lang.Expand(l, ctx.ProjectDir, []string{run})[0])
+			}
+			return []File{{Path: "Dockerfile", Content: b.String()}}, nil
+		},
+	})
+
+	register(&Generator{
+		Name:        "dockerignore",
+		Description: ".dockerignore keeping VCS data, secrets and the language's dependencies and build output out of images",
+		SingleFile:  true,
+		Generate: func(ctx *Context) ([]File, error) {
+			patterns := append([]string{}, dockerignoreCommon...)
+			if l, ok := ctx.Settings(); ok {
+				patterns = append(patterns, l.Dockerignore...)
+			}
+			return []File{withDockerignore(ctx.ProjectDir, patterns)}, nil
+		},
+	})
+}
+
+const dockerignoreFile = ".dockerignore"
+
+// dockerignoreCommon are excluded from every image regardless of language
+var dockerignoreCommon = []string{".git", ".foundry", ".env", ".DS_Store", "Dockerfile", dockerignoreFile}
+
+// withDockerignore appends the patterns missing from the project's .dockerignore, so entries
+// a template ships are kept and running the generator again changes nothing
+func withDockerignore(projectDir string, patterns []string) File {
+	existing, _ := fsys.ReadFile(filepath.Join(projectDir, dockerignoreFile))
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(existing))
+	for scanner.Scan() {
+		seen[strings.TrimSpace(scanner.Text())] = true
+	}
+
+	content := string(existing)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	for _, p := range patterns {
+		if !seen[p] {
+			seen[p] = true
+			content += p + "\n"
+		}
+	}
+	return File{Path: dockerignoreFile, Content: content, Overwrite: true}
+}
@@ -0,0 +1,210 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/kajvans/foundry/internal/backup"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/lang"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// Context carries what generators need to know about the project being created
+type Context struct {
+	ProjectName string
+	ProjectDir  string
+	Language    string
+	Framework   string // detected framework, e.g. "Django"; empty if none
+	Version     string // initial project version, e.g. "0.1.0"
+	Docker      bool   // Dockerfile generation enabled in config
+	Author      string
+	License     string // SPDX identifier from config, e.g. "MIT"
+
+	// Runtime versions keyed by tool name (e.g. "go": "1.22.2"), declared by the template or detected
+	Versions map[string]string
+
+	// Template variables recorded for the project (e.g. PORT)
+	Variables map[string]string
+
+	// Dev tools found by foundry detect (e.g. "kubectl")
+	Tools []string
+
+	// Release date written into generated files (YYYY-MM-DD); empty means today
+	Date string
+
+	// Schedule, reviewers and labels of the dependabot and renovate generators, from the org config
+	DependencyUpdates *DependencyUpdates
+
+	// Backup receives files before a generator overwrites them; nil keeps no backups
+	Backup *backup.Session
+}
+
+// Settings returns the registry entry for the project's framework, or its language if none
+func (c *Context) Settings() (*lang.Language, bool) {
+	if c.Framework != "" {
+		if l, ok := lang.Resolve(c.Framework); ok {
+			return l, true
+		}
+	}
+	return lang.Resolve(c.Language)
+}
+
+// ReleaseDate returns the date generators should write, pinned by Date for reproducible runs
+func (c *Context) ReleaseDate() string {
+	if c.Date != "" {
+		return c.Date
+	}
+	return time.Now().Format("2006-01-02")
+}
+
+// HasTool reports whether detection found the given dev tool
+func (c *Context) HasTool(name string) bool {
+	for _, tool := range c.Tools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// File is a file produced by a generator, relative to the project root
+type File struct {
+	Path    string
+	Content string
+	Mode    os.FileMode
+
+	// Overwrite marks files the generator merged with existing content, such as docker-compose.yml
+	Overwrite bool
+}
+
+// Generator produces optional project files such as tool configs
+type Generator struct {
+	Name        string
+	Description string
+	Generate    func(ctx *Context) ([]File, error)
+
+	// SingleFile marks generators that always emit exactly one file, so they can render to stdout
+	SingleFile bool
+
+	// With names generators whose files Run writes alongside this one's, e.g. .dockerignore with the Dockerfile
+	With []string
+}
+
+var registry = map[string]*Generator{}
+
+// register adds a generator to the registry; called from init functions
+func register(g *Generator) {
+	registry[g.Name] = g
+}
+
+// Get returns the generator registered under name
+func Get(name string) (*Generator, bool) {
+	g, ok := registry[name]
+	return g, ok
+}
+
+// Names returns all registered generator names, sorted
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render returns the content a single-file generator would write, without touching the project
+func Render(name string, ctx *Context) (string, error) {
+	g, ok := Get(name)
+	if !ok {
+		return "", i18n.Errorf("unknown generator '%s' (available: %v)", name, Names())
+	}
+	if !g.SingleFile {
+		return "", i18n.Errorf("generator %s writes several files and cannot render to stdout", name)
+	}
+
+	files, err := g.Generate(ctx)
+	if err != nil {
+		return "", i18n.Errorf("generator %s: %w", name, err)
+	}
+	if len(files) != 1 {
+		return "", i18n.Errorf("generator %s produced %d files, expected 1", name, len(files))
+	}
+	return files[0].Content, nil
+}
+
+// Result reports what Run did with the files a generator produced, by relative path
+type Result struct {
+	Created   []string
+	Updated   []string
+	Unchanged []string // already identical on disk, so not rewritten
+	Skipped   []string // already present and not merged, so the existing file wins
+}
+
+// Written returns the files Run actually wrote
+func (r *Result) Written() []string {
+	return append(append([]string{}, r.Created...), r.Updated...)
+}
+
+// Run executes the named generator and writes its files into ctx.ProjectDir.
+// Existing files are left untouched so template-provided versions win, unless the generator merged into them.
+// Files whose content is already identical are not rewritten, so running a generator again is a no-op.
+func Run(name string, ctx *Context) (*Result, error) {
+	g, ok := Get(name)
+	if !ok {
+		return nil, i18n.Errorf("unknown generator '%s' (available: %v)", name, Names())
+	}
+
+	files, err := g.Generate(ctx)
+	if err != nil {
+		return nil, i18n.Errorf("generator %s: %w", name, err)
+	}
+	for _, with := range g.With {
+		more, err := registry[with].Generate(ctx)
+		if err != nil {
+			return nil, i18n.Errorf("generator %s: %w", with, err)
+		}
+		files = append(files, more...)
+	}
+
+	result := &Result{}
+	for _, f := range files {
+		dst := filepath.Join(ctx.ProjectDir, f.Path)
+		exists := false
+		if digest, err := utils.FileDigest(dst); err == nil {
+			exists = true
+			if digest == utils.Digest([]byte(f.Content)) {
+				result.Unchanged = append(result.Unchanged, f.Path)
+				continue
+			}
+			if !f.Overwrite {
+				result.Skipped = append(result.Skipped, f.Path)
+				continue
+			}
+		}
+		if exists {
+			if err := ctx.Backup.Save(f.Path); err != nil {
+				return result, err
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return result, i18n.Errorf("failed to create directory for %s: %w", f.Path, err)
+		}
+		mode := f.Mode
+		if mode == 0 {
+			mode = 0644
+		}
+		if err := os.WriteFile(dst, []byte(f.Content), mode); err != nil {
+			return result, i18n.Errorf("failed to write %s: %w", f.Path, err)
+		}
+		if exists {
+			result.Updated = append(result.Updated, f.Path)
+		} else {
+			result.Created = append(result.Created, f.Path)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,131 @@
+package generate
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// goModulePath reads the module path from the project's go.mod
+func goModulePath(projectDir string) string {
+	f, err := os.Open(filepath.Join(projectDir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		}
+	}
+	return ""
+}
+
+// versionDecl matches a package-level version variable, alone or in a var block
+var versionDecl = regexp.MustCompile(`(?m)^(var\s+|\s+)version(\s+string)?\s*(=|$)`)
+
+// versionVar names the variable the release version is linked into: the cmd package's version
+// when the project declares one there, as cobra CLIs do, and main.version otherwise, which the
+// linker leaves alone when main does not declare it
+func versionVar(projectDir, module string) string {
+	paths, _ := filepath.Glob(filepath.Join(projectDir, "cmd", "*.go"))
+	for _, p := range paths {
+		if data, err := os.ReadFile(p); err == nil && versionDecl.Match(data) {
+			return module + "/cmd.version"
+		}
+	}
+	return "main.version"
+}
+
+func init() {
+	register(&Generator{
+		Name:        "goreleaser",
+		Description: "goreleaser config and GitHub release workflow for Go CLIs",
+		Generate: func(ctx *Context) ([]File, error) {
+			if ctx.Language != "Go" {
+				return nil, i18n.Errorf("goreleaser is only available for Go projects (got %s)", ctx.Language)
+			}
+			binary := strings.ToLower(ctx.ProjectName)
+			module := goModulePath(ctx.ProjectDir)
+			if module == "" {
+				module = binary
+			}
+
+			var b strings.Builder
+			b.WriteString("version: 2\n\n")
+			fmt.Fprintf(&b, "project_name: %s\n\n", binary)
+			b.WriteString("before:\n  hooks:\n    - go mod tidy\n\n")
+			b.WriteString("builds:\n")
+			fmt.Fprintf(&b, "  - binary: %s\n", binary)
+			b.WriteString("    env:\n      - CGO_ENABLED=0\n")
+			b.WriteString("    goos: [linux, darwin, windows]\n")
+			b.WriteString("    goarch: [amd64, arm64]\n")
+			fmt.Fprintf(&b, "    ldflags:\n      - -s -w -X %s={{.Version}}\n\n", versionVar(ctx.ProjectDir, module))
+			b.WriteString("archives:\n  - formats: [tar.gz]\n    format_overrides:\n      - goos: windows\n        formats: [zip]\n\n")
+			b.WriteString("checksum:\n  name_template: checksums.txt\n\n")
+			b.WriteString("changelog:\n  sort: asc\n")
+
+			files := []File{{Path: ".goreleaser.yaml", Content: b.String()}}
+
+			if ctx.Docker {
+				files[0].Content += fmt.Sprintf(`
+dockers:
+  - image_templates:
+      - "ghcr.io/{{ .Env.GITHUB_REPOSITORY_OWNER }}/%s:{{ .Version }}"
+    dockerfile: goreleaser.Dockerfile
+`, binary)
+				files = append(files, File{Path: "goreleaser.Dockerfile", Content: fmt.Sprintf(`FROM gcr.io/distroless/static:nonroot
+COPY %s /usr/local/bin/%s
+ENTRYPOINT ["/usr/local/bin/%s"]
+`, binary, binary, binary)})
+			}
+
+			// Pushing the image to ghcr.io needs the workflow logged in to the registry
+			login := ""
+			if ctx.Docker {
+				login = `      - uses: docker/login-action@v3
+        with:
+          registry: ghcr.io
+          username: ${{ github.actor }}
+          password: ${{ secrets.GITHUB_TOKEN }}
+`
+			}
+			files = append(files, File{Path: filepath.Join(".github", "workflows", "release.yml"), Content: `name: release
+
+on:
+  push:
+    tags:
+      - "v*"
+
+permissions:
+  contents: write
+  packages: write
+
+jobs:
+  goreleaser:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+      - uses: actions/setup-go@v5
+        with:
+          go-version-file: go.mod
+` + login + `      - uses: goreleaser/goreleaser-action@v6
+        with:
+          version: "~> v2"
+          args: release --clean
+        env:
+          GITHUB_TOKEN: ${{ secrets.GITHUB_TOKEN }}
+`})
+			return files, nil
+		},
+	})
+}
@@ -0,0 +1,181 @@
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// defaultPort is used when the project does not define a PORT variable
+const defaultPort = "8080"
+
+// dnsName turns a project name into a lower-case DNS-1123 label for Kubernetes objects
+func dnsName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	label := strings.Trim(b.String(), "-")
+	if len(label) > 63 {
+		label = strings.TrimRight(label[:63], "-")
+	}
+	return label
+}
+
+// requireKubernetesTooling checks the docker option and the detected tool behind a Kubernetes generator
+func requireKubernetesTooling(ctx *Context, tool string) error {
+	if !ctx.Docker {
+		return i18n.Errorf("Kubernetes generators need the docker option (foundry config --docker)")
+	}
+	if !ctx.HasTool(tool) {
+		return i18n.Errorf("%s was not detected; install it and run 'foundry detect'", tool)
+	}
+	return nil
+}
+
+// projectPort returns the PORT variable recorded for the project or the default
+func projectPort(ctx *Context) string {
+	if port := ctx.Variables["PORT"]; port != "" {
+		return port
+	}
+	return defaultPort
+}
+
+func init() {
+	register(&Generator{
+		Name:        "k8s",
+		Description: "Kubernetes Deployment, Service and Ingress manifests",
+		Generate: func(ctx *Context) ([]File, error) {
+			if err := requireKubernetesTooling(ctx, "kubectl"); err != nil {
+				return nil, err
+			}
+			name := dnsName(ctx.ProjectName)
+			port := projectPort(ctx)
+			dir := filepath.Join("deploy", "k8s")
+			return []File{
+				{Path: filepath.Join(dir, "deployment.yaml"), Content: fmt.Sprintf(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: %[1]s
+  labels:
+    app: %[1]s
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: %[1]s
+  template:
+    metadata:
+      labels:
+        app: %[1]s
+    spec:
+      containers:
+        - name: %[1]s
+          image: %[1]s:latest
+          ports:
+            - containerPort: %[2]s
+`, name, port)},
+				{Path: filepath.Join(dir, "service.yaml"), Content: fmt.Sprintf(`apiVersion: v1
+kind: Service
+metadata:
+  name: %[1]s
+spec:
+  selector:
+    app: %[1]s
+  ports:
+    - port: 80
+      targetPort: %[2]s
+`, name, port)},
+				{Path: filepath.Join(dir, "ingress.yaml"), Content: fmt.Sprintf(`apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: %[1]s
+spec:
+  rules:
+    - host: %[1]s.local
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: %[1]s
+                port:
+                  number: 80
+`, name)},
+			}, nil
+		},
+	})
+
+	register(&Generator{
+		Name:        "helm",
+		Description: "Minimal Helm chart with Deployment and Service",
+		Generate: func(ctx *Context) ([]File, error) {
+			if err := requireKubernetesTooling(ctx, "helm"); err != nil {
+				return nil, err
+			}
+			name := dnsName(ctx.ProjectName)
+			version := ctx.Version
+			if version == "" {
+				version = "0.1.0"
+			}
+			dir := filepath.Join("deploy", "helm", name)
+			return []File{
+				{Path: filepath.Join(dir, "Chart.yaml"), Content: fmt.Sprintf(`apiVersion: v2
+name: %s
+description: Helm chart for %s
+type: application
+version: %s
+appVersion: %q
+`, name, ctx.ProjectName, version, version)},
+				{Path: filepath.Join(dir, "values.yaml"), Content: fmt.Sprintf(`replicaCount: 1
+
+image:
+  repository: %s
+  tag: latest
+
+service:
+  port: 80
+  targetPort: %s
+`, name, projectPort(ctx))},
+				{Path: filepath.Join(dir, "templates", "deployment.yaml"), Content: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Chart.Name }}
+spec:
+  replicas: {{ .Values.replicaCount }}
+  selector:
+    matchLabels:
+      app: {{ .Chart.Name }}
+  template:
+    metadata:
+      labels:
+        app: {{ .Chart.Name }}
+    spec:
+      containers:
+        - name: {{ .Chart.Name }}
+          image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"
+          ports:
+            - containerPort: {{ .Values.service.targetPort }}
+`},
+				{Path: filepath.Join(dir, "templates", "service.yaml"), Content: `apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Chart.Name }}
+spec:
+  selector:
+    app: {{ .Chart.Name }}
+  ports:
+    - port: {{ .Values.service.port }}
+      targetPort: {{ .Values.service.targetPort }}
+`},
+			}, nil
+		},
+	})
+}
@@ -0,0 +1,24 @@
+package generate
+
+import (
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/license"
+)
+
+func init() {
+	register(&Generator{
+		Name:        "license",
+		Description: "LICENSE with the full text of the configured license, author and year filled in",
+		SingleFile:  true,
+		Generate: func(ctx *Context) ([]File, error) {
+			if ctx.License == "" {
+				return nil, i18n.Errorf("no license configured (set one with foundry config --license)")
+			}
+			l, ok := license.Lookup(ctx.License)
+			if !ok {
+				return nil, i18n.Errorf("no text for license %s (known: %v)", ctx.License, license.IDs())
+			}
+			return []File{{Path: "LICENSE", Content: l.Text(ctx.ReleaseDate()[:4], ctx.Author)}}, nil
+		},
+	})
+}
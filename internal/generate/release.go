@@ -0,0 +1,52 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	register(&Generator{
+		Name:        "changelog",
+		Description: "CHANGELOG.md in Keep a Changelog format starting at the initial version",
+		SingleFile:  true,
+		Generate: func(ctx *Context) ([]File, error) {
+			var b strings.Builder
+			b.WriteString("# Changelog\n\n")
+			b.WriteString("All notable changes to this project will be documented in this file.\n\n")
+			b.WriteString("The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),\n")
+			b.WriteString("and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).\n\n")
+			b.WriteString("## [Unreleased]\n\n")
+			fmt.Fprintf(&b, "## [%s] - %s\n\n", ctx.Version, ctx.ReleaseDate())
+			fmt.Fprintf(&b, "### Added\n\n- Initial project scaffold for %s.\n", ctx.ProjectName)
+			return []File{{Path: "CHANGELOG.md", Content: b.String()}}, nil
+		},
+	})
+
+	register(&Generator{
+		Name:        "release-please",
+		Description: "release-please config and manifest seeded with the initial version",
+		Generate: func(ctx *Context) ([]File, error) {
+			releaseType := "simple"
+			if l, ok := ctx.Settings(); ok && l.ReleaseType != "" {
+				releaseType = l.ReleaseType
+			}
+			config := fmt.Sprintf(`{
+  "$schema": "https://raw.githubusercontent.com/googleapis/release-please/main/schemas/config.json",
+  "packages": {
+    ".": {
+      "release-type": %q,
+      "package-name": %q,
+      "changelog-path": "CHANGELOG.md"
+    }
+  }
+}
+`, releaseType, ctx.ProjectName)
+			manifest := fmt.Sprintf("{\n  \".\": %q\n}\n", ctx.Version)
+			return []File{
+				{Path: "release-please-config.json", Content: config},
+				{Path: ".release-please-manifest.json", Content: manifest},
+			}, nil
+		},
+	})
+}
@@ -0,0 +1,64 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/lang"
+)
+
+// taskNames is the fixed order of targets in generated task runners
+var taskNames = []string{"build", "test", "lint", "run"}
+
+// tasksFor returns the commands for each target, including docker-build
+func tasksFor(ctx *Context) (map[string]string, []string, error) {
+	l, ok := ctx.Settings()
+	if !ok || len(l.Tasks) == 0 {
+		return nil, nil, i18n.Errorf("no task definitions for language '%s'", ctx.Language)
+	}
+	commands := make(map[string]string, len(l.Tasks)+1)
+	for name, command := range l.Tasks {
+		commands[name] = lang.Expand(l, ctx.ProjectDir, []string{command})[0]
+	}
+	commands["docker-build"] = fmt.Sprintf("docker build -t %s .", strings.ToLower(ctx.ProjectName))
+	return commands, append(append([]string{}, taskNames...), "docker-build"), nil
+}
+
+func init() {
+	register(&Generator{
+		Name:        "makefile",
+		Description: "Makefile with build, test, lint, run and docker-build targets",
+		SingleFile:  true,
+		Generate: func(ctx *Context) ([]File, error) {
+			commands, order, err := tasksFor(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var b strings.Builder
+			fmt.Fprintf(&b, ".PHONY: %s\n", strings.Join(order, " "))
+			for _, name := range order {
+				fmt.Fprintf(&b, "\n%s:\n\t%s\n", name, commands[name])
+			}
+			return []File{{Path: "Makefile", Content: b.String()}}, nil
+		},
+	})
+
+	register(&Generator{
+		Name:        "taskfile",
+		Description: "Taskfile.yml with build, test, lint, run and docker-build tasks",
+		SingleFile:  true,
+		Generate: func(ctx *Context) ([]File, error) {
+			commands, order, err := tasksFor(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var b strings.Builder
+			b.WriteString("version: '3'\n\ntasks:\n")
+			for _, name := range order {
+				fmt.Fprintf(&b, "  %s:\n    cmds:\n      - %s\n", name, commands[name])
+			}
+			return []File{{Path: "Taskfile.yml", Content: b.String()}}, nil
+		},
+	})
+}
@@ -0,0 +1,114 @@
+package generate
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/lang"
+)
+
+var versionPattern = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// ResolveVersions returns the runtime versions to pin for a project.
+// Versions declared by the template win; otherwise the installed toolchain for language is queried.
+func ResolveVersions(language string, declared map[string]string) map[string]string {
+	versions := make(map[string]string)
+	for tool, v := range declared {
+		versions[tool] = v
+	}
+
+	l, ok := lang.Resolve(language)
+	if !ok || l.Tool == "" || len(l.VersionCommand) == 0 {
+		return versions
+	}
+	if _, ok := versions[l.Tool]; ok {
+		return versions
+	}
+	// Some tools (java) print their version on stderr
+	out, err := exec.Command(l.VersionCommand[0], l.VersionCommand[1:]...).CombinedOutput()
+	if err != nil {
+		return versions
+	}
+	if v := versionPattern.FindString(string(out)); v != "" {
+		versions[l.Tool] = v
+	}
+	return versions
+}
+
+// asdfName maps a mise tool name to its asdf plugin name
+func asdfName(tool string) string {
+	for _, l := range lang.All() {
+		if l.Tool == tool && l.AsdfTool != "" {
+			return l.AsdfTool
+		}
+	}
+	return tool
+}
+
+// sortedTools returns the tool names in versions, sorted for stable output
+func sortedTools(versions map[string]string) []string {
+	tools := make([]string, 0, len(versions))
+	for tool := range versions {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+func init() {
+	register(&Generator{
+		Name:        "envrc",
+		Description: "direnv .envrc activating the project's toolchain and .env",
+		SingleFile:  true,
+		Generate: func(ctx *Context) ([]File, error) {
+			var b strings.Builder
+			fmt.Fprintf(&b, "# direnv configuration for %s (https://direnv.net)\n", ctx.ProjectName)
+			for _, tool := range sortedTools(ctx.Versions) {
+				fmt.Fprintf(&b, "# %s %s\n", tool, ctx.Versions[tool])
+			}
+			if l, ok := ctx.Settings(); ok && l.DirenvLayout != "" {
+				fmt.Fprintf(&b, "%s\n", l.DirenvLayout)
+			}
+			b.WriteString("watch_file mise.toml .tool-versions\n")
+			b.WriteString("dotenv_if_exists .env\n")
+			return []File{{Path: ".envrc", Content: b.String()}}, nil
+		},
+	})
+
+	register(&Generator{
+		Name:        "mise",
+		Description: "mise.toml pinning runtime versions",
+		SingleFile:  true,
+		Generate: func(ctx *Context) ([]File, error) {
+			if len(ctx.Versions) == 0 {
+				return nil, i18n.Errorf("no runtime version known for %s; declare one under 'runtimes' in foundry.yaml", ctx.Language)
+			}
+			var b strings.Builder
+			b.WriteString("[tools]\n")
+			for _, tool := range sortedTools(ctx.Versions) {
+				fmt.Fprintf(&b, "%s = %q\n", tool, ctx.Versions[tool])
+			}
+			return []File{{Path: "mise.toml", Content: b.String()}}, nil
+		},
+	})
+
+	register(&Generator{
+		Name:        "tool-versions",
+		Description: ".tool-versions pinning runtime versions (asdf/mise)",
+		SingleFile:  true,
+		Generate: func(ctx *Context) ([]File, error) {
+			if len(ctx.Versions) == 0 {
+				return nil, i18n.Errorf("no runtime version known for %s; declare one under 'runtimes' in foundry.yaml", ctx.Language)
+			}
+			var b strings.Builder
+			for _, tool := range sortedTools(ctx.Versions) {
+				fmt.Fprintf(&b, "%s %s\n", asdfName(tool), ctx.Versions[tool])
+			}
+			return []File{{Path: ".tool-versions", Content: b.String()}}, nil
+		},
+	})
+}
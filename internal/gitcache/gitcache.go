@@ -0,0 +1,91 @@
+package gitcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// DefaultMaxAge is how long a mirror is used without fetching from the remote
+const DefaultMaxAge = time.Hour
+
+// fetchedMarker is touched inside a mirror every time it is successfully fetched
+const fetchedMarker = "FOUNDRY_FETCHED"
+
+// MirrorPath returns where the mirror of url lives inside cacheDir
+func MirrorPath(cacheDir, url string) string {
+	sum := sha256.Sum256([]byte(normalize(url)))
+	return filepath.Join(cacheDir, "git", hex.EncodeToString(sum[:])[:16]+".git")
+}
+
+// Mirror returns a bare mirror of url inside cacheDir, cloning it on first use and
+// fetching it when it is older than maxAge. If fetching fails but a mirror exists,
+// the cached copy is returned with stale set, so scaffolding keeps working offline.
+func Mirror(cacheDir, url string, maxAge time.Duration) (dir string, stale bool, err error) {
+	dir = MirrorPath(cacheDir, url)
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+			return "", false, i18n.Errorf("cannot create cache directory: %w", err)
+		}
+		if err := git("", "clone", "--mirror", "--quiet", "--", normalize(url), dir); err != nil {
+			os.RemoveAll(dir)
+			return "", false, err
+		}
+		return dir, false, touch(dir)
+	}
+
+	if !isStale(dir, maxAge) {
+		return dir, false, nil
+	}
+	if err := git(dir, "remote", "update", "--prune"); err != nil {
+		return dir, true, nil
+	}
+	return dir, false, touch(dir)
+}
+
+// normalize turns local paths into absolute ones so the same repository maps to one mirror
+func normalize(url string) string {
+	if strings.Contains(url, "://") || strings.Contains(url, "@") {
+		return url
+	}
+	if _, err := os.Stat(url); err == nil {
+		if abs, err := filepath.Abs(url); err == nil {
+			return abs
+		}
+	}
+	return url
+}
+
+// isStale reports whether the mirror in dir was last fetched more than maxAge ago
+func isStale(dir string, maxAge time.Duration) bool {
+	info, err := os.Stat(filepath.Join(dir, fetchedMarker))
+	if err != nil {
+		return true
+	}
+	return time.Since(info.ModTime()) > maxAge
+}
+
+// touch records a successful fetch of the mirror in dir
+func touch(dir string) error {
+	return os.WriteFile(filepath.Join(dir, fetchedMarker), []byte(time.Now().UTC().Format(time.RFC3339)+"\n"), 0644)
+}
+
+// git runs a git command, in dir when it is not empty, and includes its output in errors
+func git(dir string, args ...string) error {
+	name := args[0]
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return i18n.Errorf("git %s: %v: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
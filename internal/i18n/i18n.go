@@ -0,0 +1,105 @@
+// Package i18n localizes Foundry's CLI messages. Messages are written in English in the code;
+// each other locale has a catalog mapping the English text, format verbs included, to its
+// translation. Messages without a translation are shown in English.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Default is the locale of the messages in the code
+const Default = "en"
+
+// catalogs holds the translations per locale
+var catalogs = map[string]map[string]string{
+	"nl": nl,
+}
+
+// current is the locale messages are shown in
+var current = Default
+
+// Locales returns the supported locales, sorted
+func Locales() []string {
+	locales := []string{Default}
+	for l := range catalogs {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Supported reports whether messages can be shown in locale
+func Supported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok || locale == Default
+}
+
+// Detect returns the locale to use: configured when set, else the language of LC_ALL,
+// LC_MESSAGES or LANG (nl_NL.UTF-8 selects nl). Unsupported locales fall back to English.
+func Detect(configured string) string {
+	candidates := []string{configured, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")}
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		locale := normalize(c)
+		if Supported(locale) {
+			return locale
+		}
+		if c == configured {
+			continue // an unknown configured locale still lets the environment decide
+		}
+		return Default
+	}
+	return Default
+}
+
+// SetLocale selects the locale messages are shown in
+func SetLocale(locale string) {
+	locale = normalize(locale)
+	if !Supported(locale) {
+		locale = Default
+	}
+	current = locale
+}
+
+// Locale returns the locale messages are shown in
+func Locale() string {
+	return current
+}
+
+// T returns the translation of an English message in the current locale, without symbols in plain mode
+func T(msg string) string {
+	if translated, ok := catalogs[current][msg]; ok {
+		msg = translated
+	}
+	if plain {
+		msg = plainText(msg)
+	}
+	return msg
+}
+
+// Sprintf formats the translation of an English format string
+func Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Errorf returns an error with the translation of an English format string; %w wraps as with fmt.Errorf
+func Errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(T(format), args...)
+}
+
+// normalize reduces a locale such as nl_NL.UTF-8 or nl-BE to its language, nl
+func normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "_-.@"); i >= 0 {
+		locale = locale[:i]
+	}
+	if locale == "c" || locale == "posix" {
+		return Default
+	}
+	return locale
+}
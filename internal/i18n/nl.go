@@ -0,0 +1,734 @@
+package i18n
+
+// nl holds the Dutch translations
+var nl = map[string]string{
+	// General
+	"Error: ":                              "Fout: ",
+	"Error: %v\n":                          "Fout: %v\n",
+	"Warning: %v\n":                        "Waarschuwing: %v\n",
+	"Warning: failed to load config: %v\n": "Waarschuwing: configuratie laden mislukt: %v\n",
+	"Error loading config: %v\n":           "Fout bij laden van configuratie: %v\n",
+	"Error loading config: %v":             "Fout bij laden van configuratie: %v",
+	"Warning:":                             "Waarschuwing:",
+	"Error:":                               "Fout:",
+	"to":                                   "naar",
+	"installed:":                           "geïnstalleerd:",
+	"missing:":                             "ontbreekt:",
+	"Selection cancelled":                  "Selectie geannuleerd",
+	"input cancelled":                      "invoer geannuleerd",
+
+	// foundry config
+	"Error setting default for %s: %v\n":                                               "Fout bij instellen van standaard voor %s: %v\n",
+	"✓ Set default template for %s: %s\n":                                              "✓ Standaardtemplate voor %s ingesteld: %s\n",
+	"✓ Set default template for %s: %s":                                                "✓ Standaardtemplate voor %s ingesteld: %s",
+	"Error: --add-fallback and --remove-default require exactly one language argument": "Fout: --add-fallback en --remove-default vereisen precies één taal als argument",
+	"Error adding fallback for %s: %v\n":                                               "Fout bij toevoegen van terugvaloptie voor %s: %v\n",
+	"✓ Added fallback template for %s: %s\n":                                           "✓ Terugvaltemplate voor %s toegevoegd: %s\n",
+	"Error removing default for %s: %v\n":                                              "Fout bij verwijderen van standaard voor %s: %v\n",
+	"✓ Removed %s from the defaults for %s\n":                                          "✓ %s verwijderd uit de standaarden voor %s\n",
+	"Error clearing default for %s: %v\n":                                              "Fout bij wissen van standaard voor %s: %v\n",
+	"✓ Cleared default template for %s\n":                                              "✓ Standaardtemplate voor %s gewist\n",
+	"Error: invalid --package-managers value '%s', expected ecosystem=pm1,pm2\n":       "Fout: ongeldige waarde voor --package-managers '%s', verwacht ecosysteem=pm1,pm2\n",
+	"Error setting package managers for %s: %v\n":                                      "Fout bij instellen van pakketbeheerders voor %s: %v\n",
+	"Error: --clone-depth must not be negative":                                        "Fout: --clone-depth mag niet negatief zijn",
+	"Error: unsupported locale '%s' (available: %s)\n":                                 "Fout: niet-ondersteunde taal '%s' (beschikbaar: %s)\n",
+	"\nConfiguration updated. Current values:":                                         "\nConfiguratie bijgewerkt. Huidige waarden:",
+	"Author: %s\n":                          "Auteur: %s\n",
+	"License: %s\n":                         "Licentie: %s\n",
+	"Default Language: %s\n":                "Standaardtaal: %s\n",
+	"Docker: %t\n":                          "Docker: %t\n",
+	"Interactive: %t\n":                     "Interactief: %t\n",
+	"Installed Languages: %v\n":             "Geïnstalleerde talen: %v\n",
+	"Installed Package Managers: %v\n":      "Geïnstalleerde pakketbeheerders: %v\n",
+	"Installed Dev Tools: %v\n":             "Geïnstalleerde ontwikkeltools: %v\n",
+	"Environment: %s\n":                     "Omgeving: %s\n",
+	"Shells: %v\n":                          "Shells: %v\n",
+	"Container Runtimes: %v\n":              "Container-runtimes: %v\n",
+	"Templates: %d saved\n":                 "Templates: %d opgeslagen\n",
+	"Project Roots: %v\n":                   "Projectmappen: %v\n",
+	"Clone Depth: %d\n":                     "Kloondiepte: %d\n",
+	"Org Config: %s\n":                      "Organisatieconfiguratie: %s\n",
+	"Locale: %s\n":                          "Taal: %s\n",
+	"Hooks Allowed: %v\n":                   "Toegestane hooks: %v\n",
+	"Hooks Denied: %v\n":                    "Geweigerde hooks: %v\n",
+	"Hooks In Container: %s\n":              "Hooks in container: %s\n",
+	"\nPreferred Package Managers:\n":       "\nVoorkeurspakketbeheerders:\n",
+	"\nLanguage Defaults:\n":                "\nStandaarden per taal:\n",
+	"cannot determine home directory: %w":   "kan thuismap niet bepalen: %w",
+	"cannot create config directory: %w":    "kan configuratiemap niet aanmaken: %w",
+	"failed to parse config: %w":            "configuratie lezen mislukt: %w",
+	"cannot create config file: %w":         "kan configuratiebestand niet aanmaken: %w",
+	"failed to write config: %w":            "configuratie schrijven mislukt: %w",
+	"unknown config key: %s":                "onbekende configuratiesleutel: %s",
+	"template '%s' not found":               "template '%s' niet gevonden",
+	"template '%s' is not a default for %s": "template '%s' is geen standaard voor %s",
+
+	// foundry detect
+	"Languages":               "Talen",
+	"Package Managers":        "Pakketbeheerders",
+	"Development Tools":       "Ontwikkeltools",
+	"Scanning your system...": "Systeem wordt gescand...",
+	"Detection complete. Please review the detected tools above.": "Detectie voltooid. Controleer de gevonden tools hierboven.",
+	"Configuration saved.":    "Configuratie opgeslagen.",
+	"Does this look correct?": "Klopt dit?",
+	"Please adjust configuration manually or re-run detection.": "Pas de configuratie handmatig aan of voer de detectie opnieuw uit.",
+	"=== Environment ===": "=== Omgeving ===",
+	"OS:         %s/%s\n": "OS:         %s/%s\n",
+	"Distro:     %s\n":    "Distributie: %s\n",
+	"WSL:        yes":     "WSL:        ja",
+	"Shell:      %s\n":    "Shell:      %s\n",
+	"Shells:     %s\n":    "Shells:     %s\n",
+	"Containers: %s\n":    "Containers: %s\n",
+
+	// foundry new
+	"Using bundle '%s'":                                                 "Bundel '%s' wordt gebruikt",
+	"\nDry run: no files written, no git init.":                         "\nProefrun: geen bestanden geschreven, geen git init.",
+	"  Would create %d files:\n":                                        "  Zou %d bestanden aanmaken:\n",
+	"    ... and %d more\n":                                             "    ... en nog %d\n",
+	"  Would generate: %s\n":                                            "  Zou genereren: %s\n",
+	"  Would write service metadata to %s\n":                            "  Zou servicemetadata schrijven naar %s\n",
+	"  Would run %d post_create hook(s)\n":                              "  Zou %d post_create-hook(s) uitvoeren\n",
+	"⚠ Failed to keep template history: %v":                             "⚠ Templategeschiedenis bewaren mislukt: %v",
+	"⚠ Failed to write service metadata: %v":                            "⚠ Servicemetadata schrijven mislukt: %v",
+	"✓ Service metadata written to %s":                                  "✓ Servicemetadata geschreven naar %s",
+	"⚠ Failed to record file digests: %v":                               "⚠ Bestandshashes vastleggen mislukt: %v",
+	"\nRunning language-specific setup in %s...":                        "\nTaalspecifieke installatie wordt uitgevoerd in %s...",
+	"\nRunning language-specific setup...":                              "\nTaalspecifieke installatie wordt uitgevoerd...",
+	"⚠ Post-create steps failed: %v":                                    "⚠ Stappen na aanmaken mislukt: %v",
+	"✓ Post-create steps finished.":                                     "✓ Stappen na aanmaken voltooid.",
+	"\n⚠ Post-create steps skipped as per --no-post flag.":              "\n⚠ Stappen na aanmaken overgeslagen vanwege --no-post.",
+	"\n✓ Project '%s' written to %s":                                    "\n✓ Project '%s' geschreven naar %s",
+	"⚠ Failed to write project stamp: %v":                               "⚠ Projectstempel schrijven mislukt: %v",
+	"⚠ Invalid git_cache_ttl '%s', using %s":                            "⚠ Ongeldige git_cache_ttl '%s', %s wordt gebruikt",
+	"Fetching %s template from %s...":                                   "%s-template wordt opgehaald van %s...",
+	"template variable '%s' is required; pass it with --var %s=<value>": "templatevariabele '%s' is verplicht; geef hem mee met --var %s=<waarde>",
+	"\n⚠ Template hooks skipped as per --no-hooks flag.":                "\n⚠ Templatehooks overgeslagen vanwege --no-hooks.",
+	"\nRunning template hooks...":                                       "\nTemplatehooks worden uitgevoerd...",
+	"✓ Template hooks finished.":                                        "✓ Templatehooks voltooid.",
+	"Select a language:":                                                "Kies een taal:",
+	" (default)":                                                        " (standaard)",
+	"Select a %s template:":                                             "Kies een %s-template:",
+	"ℹ Show template info...":                                           "ℹ Templateinformatie tonen...",
+	"Show info for which template?":                                     "Informatie tonen voor welke template?",
+	"Homepage: %s\n":                                                    "Homepage: %s\n",
+	"Maintainer: %s\n":                                                  "Beheerder: %s\n",
+	"No README found for this template.\n":                              "Geen README gevonden voor deze template.\n",
+	"... (%d more lines in %s)":                                         "... (nog %d regels in %s)",
+	"Available templates:":                                              "Beschikbare templates:",
+	" (default for: %v)":                                                " (standaard voor: %v)",
+	"cannot check min_foundry_version: %w":                              "kan min_foundry_version niet controleren: %w",
+	"this template requires Foundry %s or newer, but you are running %s\nUpgrade from https://github.com/kajvans/foundry/releases and try again": "deze template vereist Foundry %s of nieuwer, maar je gebruikt %s\nWerk bij via https://github.com/kajvans/foundry/releases en probeer het opnieuw",
+	"Creating project '%s' from template '%s'...": "Project '%s' wordt aangemaakt uit template '%s'...",
+	"  Language: %s\n":                       "  Taal: %s\n",
+	"  Target: %s\n":                         "  Doel: %s\n",
+	"\n✓ Project '%s' created successfully!": "\n✓ Project '%s' succesvol aangemaakt!",
+	"  Location: %s\n":                       "  Locatie: %s\n",
+	"\nOpening project in VS Code...":        "\nProject wordt geopend in VS Code...",
+	"✗ Failed to open VS Code: %v":           "✗ VS Code openen mislukt: %v",
+	"✓ VS Code opened.":                      "✓ VS Code geopend.",
+	"\nNext steps:":                          "\nVolgende stappen:",
+	"  cd %s\n":                              "  cd %s\n",
+	"  Run the following commands to get started with your %s project:\n": "  Voer de volgende commando's uit om met je %s-project te beginnen:\n",
+	"\nInitializing git repository...":                                    "\nGit-repository wordt geïnitialiseerd...",
+	"✗ Failed to initialize git repository: %v":                           "✗ Git-repository initialiseren mislukt: %v",
+	"✓ Git repository initialized.":                                       "✓ Git-repository geïnitialiseerd.",
+	"Adding default .gitignore for %s...":                                 "Standaard .gitignore voor %s wordt toegevoegd...",
+	"✗ Failed to create .gitignore: %v":                                   "✗ .gitignore aanmaken mislukt: %v",
+	"✓ .gitignore created.":                                               "✓ .gitignore aangemaakt.",
+	"⚠ No default .gitignore available for %s":                            "⚠ Geen standaard .gitignore beschikbaar voor %s",
+	"✗ Failed to add files to git: %v":                                    "✗ Bestanden toevoegen aan git mislukt: %v",
+	"✓ Files added to git.":                                               "✓ Bestanden toegevoegd aan git.",
+	"✗ Failed to commit files to git: %v":                                 "✗ Bestanden committen in git mislukt: %v",
+	"✓ Initial commit created.":                                           "✓ Eerste commit aangemaakt.",
+	"\n⚠ Git initialization skipped as per --no-git flag.":                "\n⚠ Git-initialisatie overgeslagen vanwege --no-git.",
+	"✗ Failed to tag initial commit: %v":                                  "✗ Eerste commit taggen mislukt: %v",
+	"✓ Tagged initial commit as %s.":                                      "✓ Eerste commit getagd als %s.",
+	"--output-archive and --path cannot be combined":                      "--output-archive en --path kunnen niet samen worden gebruikt",
+	"Unsupported archive format: %s (use .tar.gz, .tgz, .tar or .zip)":    "Niet-ondersteund archiefformaat: %s (gebruik .tar.gz, .tgz, .tar of .zip)",
+	"File '%s' already exists":                                            "Bestand '%s' bestaat al",
+	"--bundle picks the template itself; it cannot be combined with --template, --language, --git or --from": "--bundle kiest zelf de template; het kan niet samen met --template, --language, --git of --from worden gebruikt",
+	"Bundle '%s' requires git; --no-git and --output-archive are not allowed":                                "Bundel '%s' vereist git; --no-git en --output-archive zijn niet toegestaan",
+	"Invalid target path: %v": "Ongeldig doelpad: %v",
+	"Bundle '%s': %v":         "Bundel '%s': %v",
+	"--sha256 only applies to archive and bucket sources given with --from":                          "--sha256 geldt alleen voor archief- en bucketbronnen die met --from zijn opgegeven",
+	"--keep-history requires a git template source":                                                  "--keep-history vereist een git-templatebron",
+	"Template path no longer exists: %s":                                                             "Templatepad bestaat niet meer: %s",
+	"--post-in-docker needs docker; install it and run 'foundry detect'":                             "--post-in-docker vereist docker; installeer het en voer 'foundry detect' uit",
+	"--post-in-docker: no Docker image is known for %s (set docker_image under languages in config)": "--post-in-docker: geen Docker-image bekend voor %s (stel docker_image in onder languages in de configuratie)",
+	"Directory '%s' already exists":                                                                  "Map '%s' bestaat al",
+	"Error parsing --var: %v":                                                                        "Fout bij lezen van --var: %v",
+	"Error previewing project: %v":                                                                   "Fout bij voorvertonen van project: %v",
+	"Error creating project: %v":                                                                     "Fout bij aanmaken van project: %v",
+	"Failed to create temporary directory: %v":                                                       "Tijdelijke map aanmaken mislukt: %v",
+	"No default template set for language '%s'\nSet one with: foundry config %s <template-name>\nOr use --template to specify a template directly": "Geen standaardtemplate ingesteld voor taal '%s'\nStel er een in met: foundry config %s <templatenaam>\nOf gebruik --template om direct een template op te geven",
+	"No templates available. Add one with: foundry template add <name> <path>":                                                                     "Geen templates beschikbaar. Voeg er een toe met: foundry template add <naam> <pad>",
+	"No languages detected from templates":                                 "Geen talen gevonden in de templates",
+	"No templates available for language '%s'":                             "Geen templates beschikbaar voor taal '%s'",
+	"Template not found":                                                   "Template niet gevonden",
+	"Please specify --language or --template (or enable interactive mode)": "Geef --language of --template op (of schakel de interactieve modus in)",
+
+	// Service metadata
+	"service metadata field '%s' is required but resolves to nothing: %s":    "servicemetadataveld '%s' is verplicht maar levert niets op: %s",
+	"service metadata field '%s' is required; pass it with --var %s=<value>": "servicemetadataveld '%s' is verplicht; geef het mee met --var %s=<waarde>",
+	"Service %s": "Service %s",
+
+	// foundry add
+	"⚠ %s: all files already exist, nothing written":                                "⚠ %s: alle bestanden bestaan al, niets geschreven",
+	"✓ %s: already up to date":                                                      "✓ %s: al bijgewerkt",
+	"⚠ Failed to update project stamp: %v":                                          "⚠ Projectstempel bijwerken mislukt: %v",
+	"⚠ Failed to save merge base: %v":                                               "⚠ Samenvoegbasis opslaan mislukt: %v",
+	"\n%d created, %d updated, %d unchanged":                                        "\n%d aangemaakt, %d bijgewerkt, %d ongewijzigd",
+	"\nGenerating optional components...":                                           "\nOptionele onderdelen worden gegenereerd...",
+	"⚠ %s: files already provided by the template, skipped":                         "⚠ %s: bestanden al geleverd door de template, overgeslagen",
+	"--stdout renders exactly one component":                                        "--stdout rendert precies één onderdeel",
+	"Error reading project stamp: %v":                                               "Fout bij lezen van projectstempel: %v",
+	"%s is not a Foundry project (no %s)\nPass --language to add components anyway": "%s is geen Foundry-project (geen %s)\nGeef --language mee om toch onderdelen toe te voegen",
+	"No components were added":                                                      "Er zijn geen onderdelen toegevoegd",
+
+	// foundry reproduce and restore
+	"failed to read %s: %w": "lezen van %s mislukt: %w",
+	"⚠ Project was created with Foundry %s, this is %s; output may differ":                "⚠ Project is aangemaakt met Foundry %s, dit is %s; de uitvoer kan verschillen",
+	"Reproducing project '%s' into %s...":                                                 "Project '%s' wordt gereproduceerd in %s...",
+	"\n✓ Project '%s' reproduced in %s":                                                   "\n✓ Project '%s' gereproduceerd in %s",
+	"%s is not a Foundry project (no %s)":                                                 "%s is geen Foundry-project (geen %s)",
+	"Error reading stamp: %v":                                                             "Fout bij lezen van stempel: %v",
+	"Project was not created with --reproducible; nothing is pinned to reproduce it from": "Project is niet aangemaakt met --reproducible; er is niets vastgelegd om het uit te reproduceren",
+	"No backups in %s":          "Geen back-ups in %s",
+	"✓ Restored %s":             "✓ %s hersteld",
+	"Error reading backups: %v": "Fout bij lezen van back-ups: %v",
+	"Previous versions of %s saved to backup %s (undo with: foundry restore %s)": "Vorige versies van %s opgeslagen in back-up %s (ongedaan maken met: foundry restore %s)",
+
+	// foundry template
+	"Error: --sha256 only applies to archive and bucket sources": "Fout: --sha256 geldt alleen voor archief- en bucketbronnen",
+	"Scanning template directory: %s":                            "Templatemap wordt gescand: %s",
+	"Error scanning template: %v\n":                              "Fout bij scannen van template: %v\n",
+	"✓ Detected language: %s":                                    "✓ Gevonden taal: %s",
+	"✓ Detected framework: %s":                                   "✓ Gevonden framework: %s",
+	"✓ Found %d files":                                           "✓ %d bestanden gevonden",
+	"Error saving template: %v\n":                                "Fout bij opslaan van template: %v\n",
+	"\n✓ Template '%s' saved successfully!":                      "\n✓ Template '%s' succesvol opgeslagen!",
+	"  Path: %s\n":                                               "  Pad: %s\n",
+	"  Description: %s\n":                                        "  Beschrijving: %s\n",
+	"cannot create templates directory: %w":                      "kan templatemap niet aanmaken: %w",
+	"\nTip: no default template for %s yet. Set this one with: foundry config %s %s": "\nTip: nog geen standaardtemplate voor %s. Stel deze in met: foundry config %s %s",
+	"No default template for %s yet. Use '%s' as the default?":                       "Nog geen standaardtemplate voor %s. '%s' als standaard gebruiken?",
+	"Error updating template: %v\n":                                                  "Fout bij bijwerken van template: %v\n",
+	"✓ Template '%s' updated (%d files)":                                             "✓ Template '%s' bijgewerkt (%d bestanden)",
+	"Error loading templates: %v\n":                                                  "Fout bij laden van templates: %v\n",
+	"No templates saved yet.":                                                        "Nog geen templates opgeslagen.",
+	"\nAdd a template with: foundry template add <name> <path>":                      "\nVoeg een template toe met: foundry template add <naam> <pad>",
+	"Saved Templates (%d):\n\n":                                                      "Opgeslagen templates (%d):\n\n",
+	"   Language: %s\n":                                                              "   Taal: %s\n",
+	"   Framework: %s\n":                                                             "   Framework: %s\n",
+	"   Path: %s\n":                                                                  "   Pad: %s\n",
+	"   Source: %s\n":                                                                "   Bron: %s\n",
+	"   Description: %s\n":                                                           "   Beschrijving: %s\n",
+	"   Files: %d\n":                                                                 "   Bestanden: %d\n",
+	"   ⭐ Default for: %v":                                                           "   ⭐ Standaard voor: %v",
+	"   ⚠  Warning: Path no longer exists":                                           "   ⚠  Waarschuwing: pad bestaat niet meer",
+	"Default for %s will fall back to '%s'":                                          "Standaard voor %s valt terug op '%s'",
+	"Error: template '%s' is the default for: %v\nUse --force to remove it anyway.\n": "Fout: template '%s' is de standaard voor: %v\nGebruik --force om hem toch te verwijderen.\n",
+	"✓ Template '%s' removed successfully":                                            "✓ Template '%s' succesvol verwijderd",
+	"Template: %s\n":                                                                  "Template: %s\n",
+	"Language: %s\n":                                                                  "Taal: %s\n",
+	"Framework: %s\n":                                                                 "Framework: %s\n",
+	"Path: %s\n":                                                                      "Pad: %s\n",
+	"Source: %s\n":                                                                    "Bron: %s\n",
+	"SHA-256: %s\n":                                                                   "SHA-256: %s\n",
+	"Description: %s\n":                                                               "Beschrijving: %s\n",
+	"Min Foundry version: %s\n":                                                       "Minimale Foundry-versie: %s\n",
+	"Labels: %s\n":                                                                    "Labels: %s\n",
+	"   Labels: %s\n":                                                                 "   Labels: %s\n",
+	"No templates match the filter.":                                                  "Geen templates voldoen aan het filter.",
+	"Screenshots:":                                                                    "Schermafbeeldingen:",
+	"Default for: %v\n":                                                               "Standaard voor: %v\n",
+	"\n⚠  Warning: Template path no longer exists":                                    "\n⚠  Waarschuwing: templatepad bestaat niet meer",
+	"\nFiles (%d):\n":                                                                 "\nBestanden (%d):\n",
+	"✓ Exported '%s' as a Backstage Software Template to %s":                           "✓ '%s' geëxporteerd als Backstage Software Template naar %s",
+	"  Register template.yaml in your Backstage catalog to offer it in the scaffolder": "  Registreer template.yaml in je Backstage-catalogus om hem in de scaffolder aan te bieden",
+	"choose an export format (--backstage)":                                            "kies een exportformaat (--backstage)",
+	"output directory %s already exists":                                               "uitvoermap %s bestaat al",
+	"export failed: %v":                                                                "exporteren mislukt: %v",
+
+	// foundry update
+	"Apply the update?":                                            "Update toepassen?",
+	"Update cancelled":                                             "Update geannuleerd",
+	"Rendering template for '%s'...":                               "Template wordt gerenderd voor '%s'...",
+	"⚠ Failed to render service metadata: %v":                      "⚠ Servicemetadata renderen mislukt: %v",
+	"\nDry run: %s\n":                                              "\nProefrun: %s\n",
+	"\n⚠ Project updated with conflicts: %s":                       "\n⚠ Project bijgewerkt met conflicten: %s",
+	"\n✓ Project updated: %s":                                      "\n✓ Project bijgewerkt: %s",
+	"\nTemplate changes %s → %s:":                                  "\nTemplatewijzigingen %s → %s:",
+	"  (no changelog entries)":                                     "  (geen changelog-regels)",
+	"⚠ Cannot list template changes: %v":                           "⚠ Kan templatewijzigingen niet tonen: %v",
+	"\nTemplate commits since %.7s:":                               "\nTemplatecommits sinds %.7s:",
+	"  + %s (%screated)":                                           "  + %s (%saangemaakt)",
+	"  ~ %s (%supdated)":                                           "  ~ %s (%sbijgewerkt)",
+	"  ~ %s (%smerged with your changes)":                          "  ~ %s (%ssamengevoegd met je wijzigingen)",
+	"  = %s (edited locally, kept)\n":                              "  = %s (lokaal bewerkt, behouden)\n",
+	"  - %s (deleted locally, not recreated)\n":                    "  - %s (lokaal verwijderd, niet opnieuw aangemaakt)\n",
+	"  # %s (protected, not touched)\n":                            "  # %s (beschermd, niet aangeraakt)\n",
+	"  ! %s (changed locally and in the template, left alone)":     "  ! %s (lokaal en in de template gewijzigd, met rust gelaten)",
+	"\nConflict: %s changed in your project and in the template":   "\nConflict: %s is gewijzigd in je project en in de template",
+	"  (binary file, no diff shown)":                               "  (binair bestand, geen diff getoond)",
+	"Resolve %s:":                                                  "%s oplossen:",
+	"Keep my version":                                              "Mijn versie behouden",
+	"Take the template version":                                    "De templateversie nemen",
+	"Edit a merge of both":                                         "Een samenvoeging van beide bewerken",
+	"Skip for now":                                                 "Voorlopig overslaan",
+	"Always keep my version of this file":                          "Altijd mijn versie van dit bestand behouden",
+	"⚠ Resolution cancelled; remaining conflicts left as they are": "⚠ Oplossen geannuleerd; resterende conflicten blijven zoals ze zijn",
+	"editor %s failed: %w":                                         "editor %s mislukt: %w",
+	"⚠ %s still contains conflict markers":                         "⚠ %s bevat nog conflictmarkeringen",
+	"Template has changed since the project was created\n  expected hash: %s\n  current hash:  %s": "Template is gewijzigd sinds het project is aangemaakt\n  verwachte hash: %s\n  huidige hash:   %s",
+	"Failed to save merge base: %v":               "Samenvoegbasis opslaan mislukt: %v",
+	"Failed to write project stamp: %v":           "Projectstempel schrijven mislukt: %v",
+	"Error rendering template: %v":                "Fout bij renderen van template: %v",
+	"Error updating project: %v":                  "Fout bij bijwerken van project: %v",
+	"Project stamp records no template or source": "Projectstempel bevat geen template of bron",
+
+	// foundry selftest
+	"Self-test directory: %s": "Map van de zelftest: %s",
+	"✗ %s":                    "✗ %s",
+	"✓ %s (%.1fs)":            "✓ %s (%.1fs)",
+	"\n✓ All %d steps passed": "\n✓ Alle %d stappen geslaagd",
+	"Self-test failed":        "Zelftest mislukt",
+	"foundry %s: %v\n%s":      "foundry %s: %v\n%s",
+	"--dry-run created %s":    "--dry-run heeft %s aangemaakt",
+	"the manifest foundry.yaml was copied into the project": "het manifest foundry.yaml is naar het project gekopieerd",
+	"new --quiet printed %q, expected only %q":              "new --quiet printte %q, alleen %q verwacht",
+	"%s does not contain %q:\n%s":                           "%s bevat %q niet:\n%s",
+
+	// Backups
+	"failed to back up %s: %w":           "back-up van %s maken mislukt: %w",
+	"no backup named '%s'":               "geen back-up met de naam '%s'",
+	"backup '%s' does not contain %s":    "back-up '%s' bevat %s niet",
+	"failed to restore %s: %w":           "herstellen van %s mislukt: %w",
+	"cannot create backup directory: %w": "kan back-upmap niet aanmaken: %w",
+
+	// Manifests, hooks and merge rules
+	"hook %q: set exactly one of run and args":                         "hook %q: stel precies één van run en args in",
+	"hook %q: shell does not apply to args, which run without a shell": "hook %q: shell geldt niet voor args, die zonder shell draaien",
+	"hook %q: unknown shell '%s' (use %s)":                             "hook %q: onbekende shell '%s' (gebruik %s)",
+	"hook %q: %w":                                                      "hook %q: %w",
+	"files: entry without path":                                        "files: regel zonder pad",
+	"files %q: %w":                                                     "files %q: %w",
+	"failed to parse %s: %w":                                           "lezen van %s mislukt: %w",
+	"invalid %s: %w":                                                   "ongeldig %s: %w",
+	"invalid condition %q: %w":                                         "ongeldige voorwaarde %q: %w",
+	"expected a variable name before %s, got %q":                       "variabelenaam verwacht vóór %s, kreeg %q",
+	"expected a variable name or comparison, got %q":                   "variabelenaam of vergelijking verwacht, kreeg %q",
+	"merge rule for '%s': unknown driver '%s' (use json, yaml, lines, text or none)":                   "samenvoegregel voor '%s': onbekende driver '%s' (gebruik json, yaml, lines, text of none)",
+	"unknown merge driver '%s' (use json, yaml, lines, text or none)":                                  "onbekende samenvoegdriver '%s' (gebruik json, yaml, lines, text of none)",
+	"hooks must run in a container, but no image is known for this language; set image in hook_policy": "hooks moeten in een container draaien, maar er is geen image bekend voor deze taal; stel image in onder hook_policy",
+	"hook %q failed: %w":                                              "hook %q mislukt: %w",
+	"unknown shell '%s'":                                              "onbekende shell '%s'",
+	"hook policy refuses:\n  %s":                                      "hookbeleid weigert:\n  %s",
+	"running hooks in a container needs docker or podman":             "hooks in een container draaien vereist docker of podman",
+	"shell '%s' is not available in containers; use sh, bash or args": "shell '%s' is niet beschikbaar in containers; gebruik sh, bash of args",
+
+	// Organization config
+	"cannot read org config %s: %w":     "kan organisatieconfiguratie %s niet lezen: %w",
+	"failed to parse org config %s: %w": "organisatieconfiguratie %s lezen mislukt: %w",
+	"bundle '%s' not found: no org config with bundles is set up (foundry config --org-config <path|url>)": "bundel '%s' niet gevonden: er is geen organisatieconfiguratie met bundels ingesteld (foundry config --org-config <pad|url>)",
+	"bundle '%s' not found (available: %s)": "bundel '%s' niet gevonden (beschikbaar: %s)",
+
+	// Template sources and archives
+	"cannot create cache directory: %w": "kan cachemap niet aanmaken: %w",
+	"git %s: %v: %s":                    "git %s: %v: %s",
+	"unsupported archive format: %s (use .tar.gz, .tgz, .tar or .zip)": "niet-ondersteund archiefformaat: %s (gebruik .tar.gz, .tgz, .tar of .zip)",
+	"cannot create archive: %w":                                        "kan archief niet aanmaken: %w",
+	"failed to write archive: %w":                                      "archief schrijven mislukt: %w",
+	"failed to create directory: %w":                                   "map aanmaken mislukt: %w",
+	"failed to get absolute target path: %w":                           "absoluut doelpad bepalen mislukt: %w",
+	"failed to get absolute source path: %w":                           "absoluut bronpad bepalen mislukt: %w",
+	"failed to write %s: %w":                                           "schrijven van %s mislukt: %w",
+	"failed to create directory for %s: %w":                            "map voor %s aanmaken mislukt: %w",
+	"failed to create temporary directory: %w":                         "tijdelijke map aanmaken mislukt: %w",
+	"failed to download %s: %w":                                        "downloaden van %s mislukt: %w",
+	"failed to download %s: %s":                                        "downloaden van %s mislukt: %s",
+	"failed to download %s: %v: %s":                                    "downloaden van %s mislukt: %v: %s",
+	"unsupported archive format: %s":                                   "niet-ondersteund archiefformaat: %s",
+	"archive entry escapes destination: %s":                            "archiefitem valt buiten de bestemming: %s",
+	"checksums can only pin archive objects, not the prefix %s":        "checksums kunnen alleen archiefobjecten vastleggen, niet het voorvoegsel %s",
+	"%s sources need the %s CLI on PATH":                               "%s-bronnen vereisen de %s-CLI op PATH",
+	"invalid Azure location %s, expected az://account/container/path":  "ongeldige Azure-locatie %s, verwacht az://account/container/pad",
+	"unsupported bucket scheme: %s":                                    "niet-ondersteund bucketschema: %s",
+	"no embedded template named '%s'":                                  "geen ingebouwde template met de naam '%s'",
+	"failed to unpack embedded template '%s': %w":                      "ingebouwde template '%s' uitpakken mislukt: %w",
+	"failed to clone git repository: %v: %s":                           "git-repository klonen mislukt: %v: %s",
+	"failed to check out %s: %v: %s":                                   "uitchecken van %s mislukt: %v: %s",
+	"git log: %v: %s":                                                  "git log: %v: %s",
+	"failed to get absolute path: %w":                                  "absoluut pad bepalen mislukt: %w",
+	"template path no longer exists: %s":                               "templatepad bestaat niet meer: %s",
+	"unsupported template source: %s":                                  "niet-ondersteunde templatebron: %s",
+	"checksum mismatch: expected sha256 %s, got %s":                    "checksum komt niet overeen: sha256 %s verwacht, %s gekregen",
+
+	// Project stamps and templates
+	"cannot replace %s: %w":                       "kan %s niet vervangen: %w",
+	"cannot create %s directory: %w":              "kan map %s niet aanmaken: %w",
+	"cannot create stamp file: %w":                "kan stempelbestand niet aanmaken: %w",
+	"failed to write stamp: %w":                   "stempel schrijven mislukt: %w",
+	"directory does not exist: %s":                "map bestaat niet: %s",
+	"failed to scan directory: %w":                "map scannen mislukt: %w",
+	"template directory does not exist: %s":       "templatemap bestaat niet: %s",
+	"failed to list template files: %w":           "templatebestanden opsommen mislukt: %w",
+	"failed to hash template: %w":                 "template hashen mislukt: %w",
+	"template name cannot be empty":               "templatenaam mag niet leeg zijn",
+	"template name contains invalid characters":   "templatenaam bevat ongeldige tekens",
+	"invalid var format '%s', expected key=value": "ongeldig var-formaat '%s', verwacht sleutel=waarde",
+	"variable key cannot be empty":                "variabelesleutel mag niet leeg zijn",
+	"%s is not a directory":                       "%s is geen map",
+	"%s is not writable":                          "%s is niet schrijfbaar",
+	"invalid version '%s'":                        "ongeldige versie '%s'",
+
+	// Template deprecation
+	"no longer recommended for new projects": "niet meer aanbevolen voor nieuwe projecten",
+	"use '%s' instead":                       "gebruik in plaats daarvan '%s'",
+	"unsupported since %s":                   "niet meer ondersteund sinds %s",
+	"sunset on %s":                           "wordt uitgefaseerd op %s",
+	"template '%s' is deprecated and the org config blocks deprecated templates: %s": "template '%s' is verouderd en de organisatieconfiguratie blokkeert verouderde templates: %s",
+	"⚠ Template '%s' is deprecated: %s":                                              "⚠ Template '%s' is verouderd: %s",
+	"   ⚠  Deprecated: %s":                                                           "   ⚠  Verouderd: %s",
+	"Deprecated: %s":                                                                 "Verouderd: %s",
+	" (deprecated)":                                                                  " (verouderd)",
+	"deprecated: sunset '%s' is not a date like 2025-12-31":                          "deprecated: sunset '%s' is geen datum zoals 2025-12-31",
+
+	// Template scopes and shared templates
+	"personal":                         "persoonlijk",
+	"shared":                           "gedeeld",
+	"unknown scope '%s' (use %s)":      "onbekend bereik '%s' (gebruik %s)",
+	"   Scope: %s\n":                   "   Bereik: %s\n",
+	"Scope: %s\n":                      "Bereik: %s\n",
+	"No templates with scope %s saved": "Geen templates met bereik %s opgeslagen",
+	"shared template '%s' not found: the org config has no shared templates":    "gedeelde template '%s' niet gevonden: de organisatieconfiguratie heeft geen gedeelde templates",
+	"shared template '%s' not found (see foundry template list --scope shared)": "gedeelde template '%s' niet gevonden (zie foundry template list --scope shared)",
+	"Shared template '%s': %v": "Gedeelde template '%s': %v",
+	"cannot publish to the remote org config %s; publish to a local checkout of it and push that":                                "kan niet publiceren naar de externe organisatieconfiguratie %s; publiceer naar een lokale checkout ervan en push die",
+	"failed to parse org config %s: expected a mapping at the top level":                                                         "organisatieconfiguratie %s lezen mislukt: verwachtte een mapping op het hoogste niveau",
+	"Error: '%s' is a personal template; if it is meant for everyone, mark it with: foundry template update %s --scope shared\n": "Fout: '%s' is een persoonlijke template; is hij voor iedereen bedoeld, markeer hem dan met: foundry template update %s --scope shared\n",
+	"Error: '%s' is a local directory others cannot fetch; add the template from its git URL or archive to publish it\n":         "Fout: '%s' is een lokale map die anderen niet kunnen ophalen; voeg de template toe vanaf zijn git-URL of archief om hem te publiceren\n",
+	"Error: no org config is set up; pass --to <file> or run foundry config --org-config <path>":                                 "Fout: er is geen organisatieconfiguratie ingesteld; geef --to <bestand> op of voer foundry config --org-config <pad> uit",
+	"✓ Published '%s' to %s as %s": "✓ '%s' gepubliceerd naar %s als %s",
+
+	// Snippets
+	"snippet name cannot be empty":                                       "snippetnaam mag niet leeg zijn",
+	"snippet name contains invalid characters":                           "snippetnaam bevat ongeldige tekens",
+	"cannot create snippet directory: %w":                                "kan snippetmap niet aanmaken: %w",
+	"snippet '%s' not found":                                             "snippet '%s' niet gevonden",
+	"failed to parse snippet %s: %w":                                     "snippet %s lezen mislukt: %w",
+	"marker %q not found":                                                "markering %q niet gevonden",
+	"Snippet '%s' already exists (use --force to replace it)":            "Snippet '%s' bestaat al (gebruik --force om hem te vervangen)",
+	"Cannot read snippet: %v":                                            "Kan snippet niet lezen: %v",
+	"Snippet '%s' is empty":                                              "Snippet '%s' is leeg",
+	"Failed to save snippet: %v":                                         "Snippet opslaan mislukt: %v",
+	"✓ Snippet '%s' saved":                                               "✓ Snippet '%s' opgeslagen",
+	"  Placeholders: %s\n":                                               "  Plaatshouders: %s\n",
+	"Error reading snippets: %v":                                         "Fout bij lezen van snippets: %v",
+	"No snippets saved. Add one with: foundry snippet add <name> <file>": "Geen snippets opgeslagen. Voeg er een toe met: foundry snippet add <naam> <bestand>",
+	"Name the file to insert the snippet into, or use --stdout":          "Geef het bestand op waarin de snippet moet komen, of gebruik --stdout",
+	"--before needs --marker":                                            "--before vereist --marker",
+	"Cannot read %s: %v":                                                 "Kan %s niet lezen: %v",
+	"✓ %s already contains snippet '%s'":                                 "✓ %s bevat snippet '%s' al",
+	"Failed to write %s: %v":                                             "Schrijven van %s mislukt: %v",
+	"✓ Inserted snippet '%s' into %s":                                    "✓ Snippet '%s' ingevoegd in %s",
+	"snippet variable '%s' is required; pass it with --var %s=<value>":   "snippetvariabele '%s' is verplicht; geef hem op met --var %s=<waarde>",
+
+	// Template move
+	"%s already exists":        "%s bestaat al",
+	"cannot create %s: %w":     "kan %s niet aanmaken: %w",
+	"cannot copy %s to %s: %w": "kan %s niet kopiëren naar %s: %w",
+	"Error: '%s' is fetched from %s and kept in Foundry's template directory; it cannot be moved\n": "Fout: '%s' wordt opgehaald van %s en bewaard in de templatemap van Foundry; hij kan niet verplaatst worden\n",
+	"Error: template '%s' is already at %s\n":                                                       "Fout: template '%s' staat al op %s\n",
+	"Error moving template: %v\n":                                                                   "Fout bij verplaatsen van template: %v\n",
+	"✓ Moved %s to %s":                                                                              "✓ %s verplaatst naar %s",
+	"Error: %s is not a directory\n":                                                                "Fout: %s is geen map\n",
+	"✗ Failed to move the template back to %s: %v":                                                  "✗ Template terugzetten naar %s mislukt: %v",
+	"✓ Template '%s' now points to %s (%d files)":                                                   "✓ Template '%s' verwijst nu naar %s (%d bestanden)",
+
+	// Automatic template rescans
+	"⚠ Template '%s' changed but could not be rescanned: %v":                                 "⚠ Template '%s' is gewijzigd maar kon niet opnieuw gescand worden: %v",
+	"⚠ Failed to save rescanned template: %v":                                                "⚠ Opslaan van opnieuw gescande template mislukt: %v",
+	"Template '%s' changed since it was last scanned; refreshed its metadata (%s, %d files)": "Template '%s' is gewijzigd sinds de laatste scan; metadata bijgewerkt (%s, %d bestanden)",
+
+	// Command aliases
+	"unterminated quote or escape in %q":                      "onafgesloten aanhalingsteken of escape in %q",
+	"alias '%s' expands back to itself through other aliases": "alias '%s' verwijst via andere aliassen naar zichzelf terug",
+	"alias '%s': %w":                                    "alias '%s': %w",
+	"alias '%s' is empty":                               "alias '%s' is leeg",
+	"invalid alias name '%s'":                           "ongeldige aliasnaam '%s'",
+	"'%s' is a built-in command and cannot be an alias": "'%s' is een ingebouwd commando en kan geen alias zijn",
+	"\nAliases:\n":                                      "\nAliassen:\n",
+	"Error: invalid --alias value '%s', expected name=command\n": "Fout: ongeldige --alias-waarde '%s', verwacht naam=commando\n",
+	"Error setting alias %s: %v\n":                               "Fout bij instellen van alias %s: %v\n",
+
+	// Config history and undo
+	"cannot create config history: %w":      "kan configuratiegeschiedenis niet aanmaken: %w",
+	"cannot write config history: %w":       "kan configuratiegeschiedenis niet schrijven: %w",
+	"failed to parse config history %s: %w": "configuratiegeschiedenis %s lezen mislukt: %w",
+	"Nothing to undo.":                      "Niets om ongedaan te maken.",
+	"✓ Undid: %s (%s)":                      "✓ Ongedaan gemaakt: %s (%s)",
+	"⚠ Template '%s' has no files at %s; fetch them again with: foundry template update %s": "⚠ Template '%s' heeft geen bestanden op %s; haal ze opnieuw op met: foundry template update %s",
+	"⚠ Template '%s' has no files at %s":                                                    "⚠ Template '%s' heeft geen bestanden op %s",
+	"No configuration changes recorded.":                                                    "Geen configuratiewijzigingen vastgelegd.",
+
+	// Go template engine
+	"unknown engine '%s' (use %s)": "onbekende engine '%s' (gebruik %s)",
+	"cannot parse %s: %w":          "kan %s niet parsen: %w",
+	"cannot render %s: %w":         "kan %s niet renderen: %w",
+
+	// Git URL validation
+	"git URL is empty":                                                    "git-URL is leeg",
+	"git URL %q must not start with '-'":                                  "git-URL %q mag niet met '-' beginnen",
+	"git URL %q must not contain whitespace":                              "git-URL %q mag geen witruimte bevatten",
+	"git URL %q has no host":                                              "git-URL %q heeft geen host",
+	"git URL %q uses an unsupported scheme (use https, ssh, git or file)": "git-URL %q gebruikt een niet-ondersteund schema (gebruik https, ssh, git of file)",
+	"%q is not a git URL (e.g. https://github.com/user/repo or git@github.com:user/repo.git) or an existing path": "%q is geen git-URL (bijv. https://github.com/user/repo of git@github.com:user/repo.git) of bestaand pad",
+	"git is needed to fetch %s but was not found on PATH":                                                         "git is nodig om %s op te halen maar werd niet gevonden op PATH",
+	"Invalid --git URL: %v": "Ongeldige --git-URL: %v",
+
+	// Templated file names
+	"template path %s renders to the invalid name %q": "sjabloonpad %s wordt de ongeldige naam %q",
+
+	// Git host allowlist
+	"Invalid git URL: %v":                                          "Ongeldige git-URL: %v",
+	"⚠ %s is not an approved git host (approved: %s)":              "⚠ %s is geen goedgekeurde git-host (goedgekeurd: %s)",
+	"Git host %s is not approved by the org config (approved: %s)": "Git-host %s is niet goedgekeurd in de organisatieconfiguratie (goedgekeurd: %s)",
+
+	// Template index
+	"cannot read template index %s: %w":                                       "kan sjabloonindex %s niet lezen: %w",
+	"failed to parse template index %s: %w":                                   "sjabloonindex %s lezen mislukt: %w",
+	"template index %s: entry %d has no name":                                 "sjabloonindex %s: item %d heeft geen naam",
+	"template index %s: '%s' has no source":                                   "sjabloonindex %s: '%s' heeft geen bron",
+	"template index %s lists '%s' more than once":                             "sjabloonindex %s noemt '%s' meer dan eens",
+	"template '%s' is not in the index":                                       "sjabloon '%s' staat niet in de index",
+	"an index must be a .yaml or .json file, a directory or a git repository": "een index moet een .yaml- of .json-bestand, een map of een git-repository zijn",
+	"no %s found":          "geen %s gevonden",
+	"Template Index: %s\n": "Sjabloonindex: %s\n",
+	"Error: no template index is set up; pass --index <url> or run foundry config --template-index <url>": "Fout: er is geen sjabloonindex ingesteld; geef --index <url> op of voer foundry config --template-index <url> uit",
+	"The index lists no templates.": "De index bevat geen sjablonen.",
+	"Template to register:":         "Te registreren sjabloon:",
+	"Error: selection cancelled":    "Fout: selectie geannuleerd",
+	"Error: template '%s' already exists; save it under another name with --as\n":                   "Fout: sjabloon '%s' bestaat al; sla het onder een andere naam op met --as\n",
+	"Error: the index pins a sha256 for '%s', but only archive and bucket sources can be checked\n": "Fout: de index legt een sha256 vast voor '%s', maar alleen archief- en bucketbronnen kunnen worden gecontroleerd\n",
+	"✓ Template '%s' saved from the index":                                                          "✓ Sjabloon '%s' opgeslagen uit de index",
+	"Templates in the index (%d):\n\n":                                                              "Sjablonen in de index (%d):\n\n",
+	"  Source: %s\n":                                                                                "  Bron: %s\n",
+	"Register one with: foundry template browse <name>":                                             "Registreer er een met: foundry template browse <naam>",
+
+	// Project validation
+	"\nValidating project...":                                        "\nProject valideren...",
+	"⚠ Cannot search for leftover placeholders: %v":                  "⚠ Kan niet zoeken naar achtergebleven placeholders: %v",
+	"⚠ Placeholder {{%s}} left in %s:%d":                             "⚠ Placeholder {{%s}} achtergebleven in %s:%d",
+	"  - %s skipped: its program is not installed\n":                 "  - %s overgeslagen: het programma is niet geïnstalleerd\n",
+	"⚠ %s failed: %v":                                                "⚠ %s mislukt: %v",
+	"⚠ Validation found %d problem(s); the template may need fixing": "⚠ Validatie vond %d probleem/problemen; het sjabloon moet mogelijk worden gerepareerd",
+	"✓ Project validated":                                            "✓ Project gevalideerd",
+
+	// Variable collisions
+	"variable %s redefines the built-in %s":                 "variabele %s herdefinieert de ingebouwde %s",
+	"variable %s differs only in case from the built-in %s": "variabele %s verschilt alleen in hoofdletters van de ingebouwde %s",
+	"variables %s and %s differ only in case":               "variabelen %s en %s verschillen alleen in hoofdletters",
+	"Conflicting variables: %s":                             "Conflicterende variabelen: %s",
+
+	// List variables
+	"variable '%s': unknown type '%s' (use %s or %s)":  "variabele '%s': onbekend type '%s' (gebruik %s of %s)",
+	"expected a variable name before contains, got %q": "variabelenaam verwacht vóór contains, kreeg %q",
+	" (comma-separated)":                               " (kommagescheiden)",
+
+	// Variable choices and validation
+	"'%s' is not a valid %s (choose from %s)": "'%s' is geen geldige %s (kies uit %s)",
+	"'%s' is not a valid %s (must match %s)":  "'%s' is geen geldige %s (moet overeenkomen met %s)",
+	"variable '%s': invalid pattern: %w":      "variabele '%s': ongeldig patroon: %w",
+	"variable '%s': default: %w":              "variabele '%s': standaardwaarde: %w",
+	"⚠ Choose at least one":                   "⚠ Kies er minstens één",
+
+	// Generated variables
+	"cannot generate UUID: %w": "kan geen UUID genereren: %w",
+
+	// License
+	"⚠ No text known for license %s, so no LICENSE is written (known: %s)": "⚠ Geen tekst bekend voor licentie %s, dus er wordt geen LICENSE geschreven (bekend: %s)",
+
+	// Template stats
+	"Error: --top must be at least 1":      "Fout: --top moet minstens 1 zijn",
+	"(unknown)":                            "(onbekend)",
+	"Templates: %d\n":                      "Templates: %d\n",
+	"Disk usage: %s\n":                     "Schijfgebruik: %s\n",
+	"\nBy language:":                       "\nPer taal:",
+	"no default":                           "geen standaard",
+	"default %s":                           "standaard %s",
+	"\n⚠ Paths that no longer exist (%d):": "\n⚠ Paden die niet meer bestaan (%d):",
+	"\nMost used:":                         "\nMeest gebruikt:",
+	"\nLeast used:":                        "\nMinst gebruikt:",
+	"\nNever used (%d):":                   "\nNooit gebruikt (%d):",
+	"  %s: %d project(s), last on %s\n":    "  %s: %d project(en), laatst op %s\n",
+
+	// Includes
+	"cannot include %q: the path must stay inside the template": "kan %q niet invoegen: het pad moet binnen de template blijven",
+	"cannot include %q: include cycle %s":                       "kan %q niet invoegen: kringverwijzing %s",
+	"cannot include %q: includes nested more than %d deep":      "kan %q niet invoegen: invoegingen meer dan %d niveaus diep genest",
+	"cannot include %q: %w":                                     "kan %q niet invoegen: %w",
+
+	// Bench
+	"files, file size, files per directory, workers and runs must all be at least 1": "bestanden, bestandsgrootte, bestanden per map, workers en runs moeten allemaal minstens 1 zijn",
+	"cannot write the synthetic template: %w":                                        "kan de synthetische template niet schrijven: %w",
+	"Measuring %s with %d worker(s)...\n":                                            "%s meten met %d worker(s)...\n",
+	"Foundry scaffolding benchmark":                                                  "Foundry-benchmark voor het opzetten van projecten",
+	"Foundry %s, %s, %s/%s, %d CPUs\n":                                               "Foundry %s, %s, %s/%s, %d CPU's\n",
+	"%d files of %d bytes, %d per directory, median of %d runs\n\n":                  "%d bestanden van %d bytes, %d per map, mediaan van %d runs\n\n",
+	"workload": "werklast",
+	"median":   "mediaan",
+	"fastest":  "snelste",
+	"files/s":  "bestanden/s",
+
+	// Strict variables
+	"Strict Variables: %t\n":                      "Strikte variabelen: %t\n",
+	"Cannot search for leftover placeholders: %v": "Kan niet zoeken naar overgebleven placeholders: %v",
+	"Unresolved placeholders; the project was not created (pass them with --var or declare them in foundry.yaml):\n%s": "Onopgeloste placeholders; het project is niet aangemaakt (geef ze mee met --var of declareer ze in foundry.yaml):\n%s",
+
+	// Template convert
+	"%s is neither a cookiecutter template (cookiecutter.json) nor a yeoman generator (generators/app/templates)": "%s is geen cookiecutter-template (cookiecutter.json) en geen yeoman-generator (generators/app/templates)",
+	"expected one {{cookiecutter.*}} directory in %s, found %d":                                                   "één {{cookiecutter.*}}-map verwacht in %s, %d gevonden",
+	"failed to parse cookiecutter.json: %w":                                                                       "kan cookiecutter.json niet lezen: %w",
+	"failed to parse cookiecutter.json: expected an object":                                                       "kan cookiecutter.json niet lezen: object verwacht",
+	"conversion failed: %v":                                      "conversie mislukt: %v",
+	"✓ Converted %s template with %d files to %s":                "✓ %s-template met %d bestanden omgezet naar %s",
+	"  Variables: %s\n":                                          "  Variabelen: %s\n",
+	"⚠ Variables without a Foundry equivalent were left out: %s": "⚠ Variabelen zonder Foundry-equivalent zijn weggelaten: %s",
+	"⚠ These paths still hold %s logic Foundry cannot convert; rewrite them by hand:": "⚠ Deze paden bevatten nog %s-logica die Foundry niet kan omzetten; herschrijf ze met de hand:",
+	"  Review %s, then save the template with: foundry template add %s %s\n":          "  Controleer %s en sla de template daarna op met: foundry template add %s %s\n",
+
+	// Dry-run substitutions
+	"  Substitutions:":            "  Vervangingen:",
+	"      {{%s}} has no value%s": "      {{%s}} heeft geen waarde%s",
+	" (+%d lines)":                " (+%d regels)",
+
+	// Global gitignore and junk files
+	"Global Gitignore: %t\n": "Globale gitignore: %t\n",
+	"Keep Junk Files: %t\n":  "Rommelbestanden behouden: %t\n",
+
+	// Template update changes
+	"  No files added, removed or changed": "  Geen bestanden toegevoegd, verwijderd of gewijzigd",
+	"  No files added or removed":          "  Geen bestanden toegevoegd of verwijderd",
+	"  %d added, %d removed, %d changed\n": "  %d toegevoegd, %d verwijderd, %d gewijzigd\n",
+	"  %d added, %d removed\n":             "  %d toegevoegd, %d verwijderd\n",
+
+	// Variable defaults
+	"variable defaults refer to each other: %s": "standaardwaarden van variabelen verwijzen naar elkaar: %s",
+
+	// Computed variables
+	"invalid expression %q: %w":                     "ongeldige expressie %q: %w",
+	"unexpected %q":                                 "onverwacht %q",
+	"unterminated string":                           "string niet afgesloten",
+	"expected %q, got %q":                           "%q verwacht, %q gevonden",
+	"expected %q at the end":                        "%q verwacht aan het einde",
+	"unexpected end":                                "onverwacht einde",
+	"unknown function %s (use %s)":                  "onbekende functie %s (gebruik %s)",
+	"unknown variable %s":                           "onbekende variabele %s",
+	"computed: set both name and value":             "computed: stel zowel name als value in",
+	"computed '%s': already declared as a variable": "computed '%s': al gedeclareerd als variabele",
+	"computed '%s': %w":                             "computed '%s': %w",
+	"computed variable '%s': %w":                    "berekende variabele '%s': %w",
+	"%s is not installed (run foundry detect to check your tools)": "%s is niet geïnstalleerd (voer foundry detect uit om je tools te controleren)",
+	"%s is not on PATH, though foundry detect found %s before":     "%s staat niet in PATH, hoewel foundry detect %s eerder vond",
+	"%s was not detected on this machine (foundry detect)":         "%s is niet gevonden op deze machine (foundry detect)",
+	"install it with: %s":                                       "installeer het met: %s",
+	"env: invalid environment variable name '%s'":               "env: ongeldige naam van omgevingsvariabele '%s'",
+	"\nLearn more:":                                             "\nMeer informatie:",
+	"success: link %q without url":                              "success: link %q zonder url",
+	"Error reading --vars-stdin: %v":                            "Fout bij lezen van --vars-stdin: %v",
+	"expected a JSON object of variables: %w":                   "verwachtte een JSON-object met variabelen: %w",
+	"variable '%s': expected a string, number, boolean or list": "variabele '%s': verwachtte een tekst, getal, boolean of lijst",
+	"Binary Extensions: %v\n":                                   "Binaire extensies: %v\n",
+	"Text Extensions: %v\n":                                     "Tekstextensies: %v\n",
+	"\n⚠ Git initialization skipped: the project is inside the git repository %s (see --in-repo).": "\n⚠ Git-initialisatie overgeslagen: het project staat in de git-repository %s (zie --in-repo).",
+	"Skip git init; the enclosing repository tracks the project":                                   "Git init overslaan; de omliggende repository beheert het project",
+	"Commit the project to a new branch of the enclosing repository":                               "Het project committen op een nieuwe branch van de omliggende repository",
+	"Initialize a nested repository anyway":                                                        "Toch een geneste repository initialiseren",
+	"⚠ %s is inside the git repository %s":                                                         "⚠ %s staat in de git-repository %s",
+	"How should the project be added?":                                                             "Hoe moet het project worden toegevoegd?",
+	"\nCommitting the project to branch %s of %s...":                                               "\nProject committen op branch %s van %s...",
+	"✗ git %s failed: %v\n%s":                                                                      "✗ git %s mislukt: %v\n%s",
+	"✓ Project committed to branch %s.":                                                            "✓ Project gecommit op branch %s.",
+	"Unknown --in-repo '%s' (use %s)":                                                              "Onbekende --in-repo '%s' (gebruik %s)",
+	"Follow Symlinks: %t\n":                                                                        "Symlinks volgen: %t\n",
+	"failed to follow symlink %s: %w":                                                              "symlink %s volgen mislukt: %w",
+	"symlink %s points to a directory containing it":                                               "symlink %s wijst naar een map die hem zelf bevat",
+	"failed to read symlink %s: %w":                                                                "symlink %s lezen mislukt: %w",
+	"failed to create symlink %s: %w":                                                              "symlink %s aanmaken mislukt: %w",
+	"Cannot resolve the project directory: %v":                                                     "Kan de projectmap niet bepalen: %v",
+	"Cannot infer a project name from '%s'; pass one instead of '.'":                               "Kan geen projectnaam afleiden uit '%s'; geef er een op in plaats van '.'",
+	"Cannot read directory '%s': %v":                                                               "Kan map '%s' niet lezen: %v",
+	"Directory '%s' is not empty":                                                                  "Map '%s' is niet leeg",
+	"Error reading --var-file %s: %v":                                                              "Fout bij lezen van --var-file %s: %v",
+	"expected a YAML mapping of variables: %w":                                                     "verwachtte een YAML-mapping met variabelen: %w",
+	"expected a YAML mapping of variables":                                                         "verwachtte een YAML-mapping met variabelen",
+	"variable '%s': %w":                                                                            "variabele '%s': %w",
+	"variable '%s': unknown group '%s'":                                                            "variabele '%s': onbekende groep '%s'",
+	"groups: every group needs a name":                                                             "groups: elke groep heeft een naam nodig",
+	"group '%s' is declared twice":                                                                 "groep '%s' is twee keer gedeclareerd",
+	"group '%s': %w":                                                                               "groep '%s': %w",
+	"⚠ The recorded value '%s' of %s is no longer allowed: %v":                                     "⚠ De vastgelegde waarde '%s' van %s is niet meer toegestaan: %v",
+	"Variables to answer for this template version: %s":                                            "Te beantwoorden variabelen voor deze templateversie: %s",
+	"Line Endings: %s\n":                                                                           "Regeleinden: %s\n",
+	"Unknown line endings '%s' (use %s)":                                                           "Onbekende regeleinden '%s' (gebruik %s)",
+	"unknown line_endings '%s' (use %s)":                                                           "onbekende line_endings '%s' (gebruik %s)",
+	"Unknown --sbom '%s' (use %s)":                                                                 "Onbekende --sbom '%s' (gebruik %s)",
+	"⚠ Failed to write SBOM: %v":                                                                   "⚠ Schrijven van SBOM mislukt: %v",
+	"unknown SBOM format '%s' (use %s)":                                                            "onbekend SBOM-formaat '%s' (gebruik %s)",
+	"Unknown --format '%s' (use %s)":                                                               "Onbekend --format '%s' (gebruik %s)",
+	"✓ SBOM written to %s":                                                                         "✓ SBOM geschreven naar %s",
+	"the value given for %s is not valid (it must match %s)":                                       "de opgegeven waarde voor %s is ongeldig (moet overeenkomen met %s)",
+	"variable '%s': a sensitive variable cannot have choices, which are shown in a menu":           "variabele '%s': een gevoelige variabele kan geen keuzes hebben, want die worden in een menu getoond",
+	"variable '%s': a sensitive variable needs a name usable as an environment variable":           "variabele '%s': een gevoelige variabele heeft een naam nodig die als omgevingsvariabele bruikbaar is",
+	" (leave empty for the default)":                                                               " (leeg laten voor de standaardwaarde)",
+	"⚠ A value is required":                                                                        "⚠ Een waarde is verplicht",
+	"Sensitive variables are not recorded in the stamp; set %s in the environment":                 "Gevoelige variabelen worden niet in de stempel vastgelegd; stel %s in de omgeving in",
+	"⚠ Sensitive variables are read from the environment, which does not set %s":                   "⚠ Gevoelige variabelen worden uit de omgeving gelezen, waarin %s niet is ingesteld",
+	"⚠ %s is sensitive and --var keeps its value in your shell history; set the %s environment variable instead": "⚠ %s is gevoelig en --var bewaart de waarde in je shellgeschiedenis; stel in plaats daarvan de omgevingsvariabele %s in",
+	"template variable '%s' is required; set the %s environment variable":                                        "templatevariabele '%s' is verplicht; stel de omgevingsvariabele %s in",
+	"Variables: %s\n":               "Variabelen: %s\n",
+	"No saved variables for '%s'\n": "Geen opgeslagen variabelen voor '%s'\n",
+	"Error: %s is sensitive and is not saved in the config; set the %s environment variable instead\n": "Fout: %s is gevoelig en wordt niet in de configuratie opgeslagen; stel in plaats daarvan de omgevingsvariabele %s in\n",
+	"✓ Removed saved value of %s":                   "✓ Opgeslagen waarde van %s verwijderd",
+	"✓ Saved %s=%s for '%s'":                        "✓ %s=%s opgeslagen voor '%s'",
+	"Engine: %s\n":                                  "Engine: %s\n",
+	"line %d: unclosed {{":                          "regel %d: {{ niet gesloten",
+	"line %d: partials ({{%s}}) are not supported":  "regel %d: partials ({{%s}}) worden niet ondersteund",
+	"line %d: {{else}} outside a block":             "regel %d: {{else}} buiten een blok",
+	"line %d: {{/%s}} closes no block":              "regel %d: {{/%s}} sluit geen blok",
+	"line %d: {{/%s}} does not close {{#%s}}":       "regel %d: {{/%s}} sluit {{#%s}} niet",
+	"line %d: {{#%s}} is not closed":                "regel %d: {{#%s}} is niet gesloten",
+	"line %d: %s is not defined":                    "regel %d: %s is niet gedefinieerd",
+	"line %d: unknown helper '%s'":                  "regel %d: onbekende helper '%s'",
+	"line %d: unsupported expression '%s'":          "regel %d: niet-ondersteunde expressie '%s'",
+	"line %d: unsupported block helper '%s'":        "regel %d: niet-ondersteunde blokhelper '%s'",
+	"template path %s leaves the project directory": "sjabloonpad %s komt buiten de projectmap uit",
+
+	// Generators
+	"%s was not detected; install it and run 'foundry detect'":               "%s is niet gedetecteerd; installeer het en voer 'foundry detect' uit",
+	"Kubernetes generators need the docker option (foundry config --docker)": "Kubernetes-generators hebben de docker-optie nodig (foundry config --docker)",
+	"dependency_updates: unknown schedule '%s' (use %s)":                     "dependency_updates: onbekend schema '%s' (gebruik %s)",
+	"generator %s produced %d files, expected 1":                             "generator %s maakte %d bestanden, verwacht 1",
+	"generator %s writes several files and cannot render to stdout":          "generator %s schrijft meerdere bestanden en kan niet naar stdout renderen",
+	"generator %s: %w": "generator %s: %w",
+	"goreleaser is only available for Go projects (got %s)":                           "goreleaser is alleen beschikbaar voor Go-projecten (kreeg %s)",
+	"invalid service definition for %s: %w":                                           "ongeldige servicedefinitie voor %s: %w",
+	"no Docker image defined for language '%s'":                                       "geen Docker-image gedefinieerd voor taal '%s'",
+	"no dependency manifests (go.mod, package.json, pyproject.toml, ...) found in %s": "geen dependency-manifesten (go.mod, package.json, pyproject.toml, ...) gevonden in %s",
+	"no license configured (set one with foundry config --license)":                   "geen licentie ingesteld (stel er een in met foundry config --license)",
+	"no runtime version known for %s; declare one under 'runtimes' in foundry.yaml":   "geen runtimeversie bekend voor %s; geef er een op onder 'runtimes' in foundry.yaml",
+	"no task definitions for language '%s'":                                           "geen taakdefinities voor taal '%s'",
+	"no text for license %s (known: %v)":                                              "geen tekst voor licentie %s (bekend: %v)",
+	"unknown generator '%s' (available: %v)":                                          "onbekende generator '%s' (beschikbaar: %v)",
+	"Selection cancelled: %v":                                                         "Selectie geannuleerd: %v",
+	"✓ %s: %s":                                                                        "✓ %s: %s",
+	"%s: %w":                                                                          "%s: %w",
+	"line %d: {{#%s}} needs a variable":                                               "regel %d: {{#%s}} heeft een variabele nodig",
+	"engine '%s' and syntax '%s' disagree; set only one":                              "engine '%s' en syntax '%s' spreken elkaar tegen; geef er maar één op",
+	"⚠ %v":   "⚠ %v",
+	"⚠ %s":   "⚠ %s",
+	"✓ %s":   "✓ %s",
+	"  → %s": "  → %s",
+	"\n✓ %s": "\n✓ %s",
+}
@@ -0,0 +1,53 @@
+package i18n
+
+import "strings"
+
+// symbols are the decorations replaced in plain mode, with the word read instead ("" drops them)
+var symbols = []struct {
+	symbol string
+	word   string
+}{
+	{"✓", ""},
+	{"✅", "installed:"},
+	{"⚠", "Warning:"},
+	{"✗", "Error:"},
+	{"❌", "missing:"},
+	{"ℹ", ""},
+	{"⭐", ""},
+	{"→", "to"},
+}
+
+// plain reports whether messages are shown without symbols
+var plain bool
+
+// SetPlain selects plain mode, in which messages spell out their symbols as words so output
+// reads linearly in screen readers and dumb terminals
+func SetPlain(on bool) {
+	plain = on
+}
+
+// Plain reports whether plain mode is on
+func Plain() bool {
+	return plain
+}
+
+// plainText replaces the symbols in msg with words. A word already following its symbol,
+// as in "⚠ Warning: ...", is not repeated.
+func plainText(msg string) string {
+	for _, s := range symbols {
+		for {
+			i := strings.Index(msg, s.symbol)
+			if i < 0 {
+				break
+			}
+			rest := strings.TrimLeft(msg[i+len(s.symbol):], " ")
+			word := T(s.word)
+			if word == "" || strings.HasPrefix(strings.ToLower(rest), strings.ToLower(word)) {
+				msg = msg[:i] + rest
+			} else {
+				msg = msg[:i] + word + " " + rest
+			}
+		}
+	}
+	return msg
+}
@@ -0,0 +1,137 @@
+package index
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/source"
+	"gopkg.in/yaml.v3"
+)
+
+// FileNames are the index files looked for in a directory or git repository, in order
+var FileNames = []string{"foundry-index.yaml", "foundry-index.yml", "foundry-index.json"}
+
+// Index is a static list of templates, a YAML or JSON file served by any web server or kept
+// in a git repository, that users browse and register templates from without a registry backend
+type Index struct {
+	Templates []Entry `yaml:"templates"`
+}
+
+// Entry is one template offered by an index
+type Entry struct {
+	Name        string            `yaml:"name"`
+	Source      string            `yaml:"source"`           // any template source: git URL, archive, bucket
+	SHA256      string            `yaml:"sha256,omitempty"` // pins an archive or bucket source
+	Language    string            `yaml:"language,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Homepage    string            `yaml:"homepage,omitempty"`
+	Maintainer  string            `yaml:"maintainer,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+}
+
+// Load reads the index at location: an http(s) URL of a .yaml, .yml or .json file, a git
+// repository holding one of FileNames, or a local file or directory. Entries are sorted by name.
+func Load(location string, opts source.Options) (*Index, error) {
+	data, err := read(location, opts)
+	if err != nil {
+		return nil, i18n.Errorf("cannot read template index %s: %w", location, err)
+	}
+
+	// JSON is valid YAML, so one parser reads both formats
+	idx := &Index{}
+	if err := yaml.Unmarshal(data, idx); err != nil {
+		return nil, i18n.Errorf("failed to parse template index %s: %w", location, err)
+	}
+	seen := map[string]bool{}
+	for i, e := range idx.Templates {
+		if e.Name == "" {
+			return nil, i18n.Errorf("template index %s: entry %d has no name", location, i+1)
+		}
+		if e.Source == "" {
+			return nil, i18n.Errorf("template index %s: '%s' has no source", location, e.Name)
+		}
+		if seen[e.Name] {
+			return nil, i18n.Errorf("template index %s lists '%s' more than once", location, e.Name)
+		}
+		seen[e.Name] = true
+	}
+	sort.Slice(idx.Templates, func(i, j int) bool { return idx.Templates[i].Name < idx.Templates[j].Name })
+	return idx, nil
+}
+
+// Entry returns the named template of the index
+func (idx *Index) Entry(name string) (*Entry, error) {
+	for i := range idx.Templates {
+		if idx.Templates[i].Name == name {
+			return &idx.Templates[i], nil
+		}
+	}
+	return nil, i18n.Errorf("template '%s' is not in the index", name)
+}
+
+// read returns the content of the index file at location
+func read(location string, opts source.Options) ([]byte, error) {
+	if (strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")) && isIndexFile(location) {
+		return download(location)
+	}
+	if info, err := os.Stat(location); err == nil {
+		if !info.IsDir() {
+			return os.ReadFile(location)
+		}
+		return readDir(location)
+	}
+
+	src, err := source.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if src.Kind() != "git" {
+		return nil, i18n.Errorf("an index must be a .yaml or .json file, a directory or a git repository")
+	}
+	dir, cleanup, err := src.Fetch(opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	return readDir(dir)
+}
+
+// isIndexFile reports whether the URL names an index file rather than a repository
+func isIndexFile(url string) bool {
+	url, _, _ = strings.Cut(url, "?")
+	switch strings.ToLower(filepath.Ext(url)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// readDir returns the first of FileNames found in dir
+func readDir(dir string) ([]byte, error) {
+	for _, name := range FileNames {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil || !os.IsNotExist(err) {
+			return data, err
+		}
+	}
+	return nil, i18n.Errorf("no %s found", strings.Join(FileNames, ", "))
+}
+
+// download returns the body of url
+func download(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,342 @@
+package lang
+
+// nodeTasks are the npm scripts shared by the Node-based languages
+func nodeTasks(run string) map[string]string {
+	return map[string]string{
+		"build": "{{run}} build",
+		"test":  "{{run}} test",
+		"lint":  "{{run}} lint",
+		"run":   run,
+	}
+}
+
+// nodeDockerignore keeps installed packages and build output of the Node-based languages out of images
+var nodeDockerignore = []string{"node_modules", "npm-debug.log*", "dist", "build", "coverage", ".next"}
+
+// builtins is the language knowledge shipped with Foundry
+var builtins = []Language{
+	{
+		Name:           "Go",
+		Extensions:     []string{".go", ".mod"},
+		Indicators:     []string{"go.mod"},
+		Binary:         "go",
+		PostSteps:      []string{"go mod tidy", "go build"},
+		Hints:          []string{"go mod tidy", "go build"},
+		Validate:       []string{"go vet ./..."},
+		Tool:           "go",
+		AsdfTool:       "golang",
+		VersionCommand: []string{"go", "version"},
+		DirenvLayout:   "layout go",
+		Tasks: map[string]string{
+			"build": "go build ./...",
+			"test":  "go test ./...",
+			"lint":  "go vet ./...",
+			"run":   "go run .",
+		},
+		ReleaseType:  "go",
+		DockerImage:  "golang:{{version}}",
+		Dockerignore: []string{"bin", "vendor", "*.test", "*.out"},
+	},
+	{
+		Name:           "Python",
+		Extensions:     []string{".py"},
+		Indicators:     []string{"requirements.txt", "Pipfile"},
+		Binary:         "python3",
+		PostSteps:      []string{"(test -f requirements.txt && {{install}} || true)", "{{run}} main.py"},
+		Hints:          []string{"{{install}}", "{{run}} main.py"},
+		Validate:       []string{"python3 -m compileall -q ."},
+		Ecosystem:      "python",
+		Tool:           "python",
+		AsdfTool:       "python",
+		VersionCommand: []string{"python3", "--version"},
+		DirenvLayout:   "layout python3",
+		Tasks: map[string]string{
+			"build": "python3 -m compileall -q .",
+			"test":  "python3 -m pytest",
+			"lint":  "python3 -m ruff check .",
+			"run":   "{{run}} main.py",
+		},
+		ReleaseType:  "python",
+		DockerImage:  "python:{{version}}",
+		Dockerignore: []string{"__pycache__", "*.py[cod]", ".venv", "venv", ".pytest_cache", ".mypy_cache", "*.egg-info", "dist", "build"},
+	},
+	{
+		Name:           "JavaScript",
+		Extensions:     []string{".js"},
+		Indicators:     []string{"package.json"},
+		Binary:         "node",
+		PostSteps:      []string{"{{install}}", "{{run}} dev"},
+		Hints:          []string{"{{install}}", "{{run}} dev"},
+		Validate:       []string{"npm ls --depth=0"},
+		Ecosystem:      "javascript",
+		Gitignore:      "Node",
+		Tool:           "node",
+		AsdfTool:       "nodejs",
+		VersionCommand: []string{"node", "--version"},
+		Tasks:          nodeTasks("{{run}} start"),
+		ReleaseType:    "node",
+		DockerImage:    "node:{{version}}",
+		Dockerignore:   nodeDockerignore,
+	},
+	{
+		Name:           "TypeScript",
+		Extensions:     []string{".ts"},
+		Indicators:     []string{"tsconfig.json"},
+		Binary:         "tsc",
+		PostSteps:      []string{"{{install}}", "{{run}} dev"},
+		Hints:          []string{"{{install}}", "{{run}} dev"},
+		Validate:       []string{"npm ls --depth=0"},
+		Ecosystem:      "javascript",
+		Gitignore:      "Node",
+		Tool:           "node",
+		AsdfTool:       "nodejs",
+		VersionCommand: []string{"node", "--version"},
+		Tasks:          nodeTasks("{{run}} start"),
+		ReleaseType:    "node",
+		DockerImage:    "node:{{version}}",
+		Dockerignore:   nodeDockerignore,
+	},
+	{
+		Name:           "React",
+		Extensions:     []string{".jsx", ".tsx"},
+		PostSteps:      []string{"{{install}}", "{{run}} dev"},
+		Hints:          []string{"{{install}}", "{{run}} dev"},
+		Validate:       []string{"npm ls --depth=0"},
+		Ecosystem:      "javascript",
+		Gitignore:      "Node",
+		Tool:           "node",
+		AsdfTool:       "nodejs",
+		VersionCommand: []string{"node", "--version"},
+		Tasks:          nodeTasks("{{run}} dev"),
+		ReleaseType:    "node",
+		DockerImage:    "node:{{version}}",
+		Dockerignore:   nodeDockerignore,
+	},
+	{
+		Name:           "Vue",
+		Extensions:     []string{".vue"},
+		Validate:       []string{"npm ls --depth=0"},
+		Ecosystem:      "javascript",
+		Gitignore:      "Node",
+		Tool:           "node",
+		AsdfTool:       "nodejs",
+		VersionCommand: []string{"node", "--version"},
+		Tasks:          nodeTasks("{{run}} dev"),
+		ReleaseType:    "node",
+		DockerImage:    "node:{{version}}",
+		Dockerignore:   nodeDockerignore,
+	},
+	{
+		Name:           "Rust",
+		Extensions:     []string{".rs"},
+		Indicators:     []string{"Cargo.toml"},
+		Binary:         "rustc",
+		Hints:          []string{"cargo build", "cargo run"},
+		Validate:       []string{"cargo check --quiet"},
+		Tool:           "rust",
+		AsdfTool:       "rust",
+		VersionCommand: []string{"rustc", "--version"},
+		Tasks: map[string]string{
+			"build": "cargo build",
+			"test":  "cargo test",
+			"lint":  "cargo clippy",
+			"run":   "cargo run",
+		},
+		ReleaseType:  "rust",
+		DockerImage:  "rust:{{version}}",
+		Dockerignore: []string{"target"},
+	},
+	{
+		Name:           "Java",
+		Extensions:     []string{".java"},
+		Indicators:     []string{"pom.xml", "build.gradle"},
+		Binary:         "javac",
+		Tool:           "java",
+		AsdfTool:       "java",
+		VersionCommand: []string{"java", "-version"},
+		Tasks: map[string]string{
+			"build": "mvn -q package",
+			"test":  "mvn test",
+			"lint":  "mvn verify",
+			"run":   "mvn exec:java",
+		},
+		ReleaseType:  "maven",
+		Dockerignore: []string{"target", "build", ".gradle", "*.class"},
+	},
+	{
+		Name:       "Kotlin",
+		Extensions: []string{".kt"},
+		Binary:     "kotlinc",
+	},
+	{
+		Name:       "C++",
+		Extensions: []string{".cpp"},
+		Binary:     "g++",
+	},
+	{
+		Name:       "C",
+		Extensions: []string{".c"},
+		Binary:     "gcc",
+	},
+	{
+		Name:       "C/C++",
+		Indicators: []string{"Makefile"},
+		Gitignore:  "C++",
+	},
+	{
+		Name:       "C#",
+		Extensions: []string{".cs"},
+		Binary:     "csc",
+		Gitignore:  "VisualStudio",
+	},
+	{
+		Name:           "PHP",
+		Extensions:     []string{".php"},
+		Indicators:     []string{"composer.json"},
+		Binary:         "php",
+		Tool:           "php",
+		AsdfTool:       "php",
+		VersionCommand: []string{"php", "--version"},
+		ReleaseType:    "php",
+	},
+	{
+		Name:           "Ruby",
+		Extensions:     []string{".rb"},
+		Indicators:     []string{"Gemfile"},
+		Binary:         "ruby",
+		Tool:           "ruby",
+		AsdfTool:       "ruby",
+		VersionCommand: []string{"ruby", "--version"},
+		DirenvLayout:   "layout ruby",
+		Tasks: map[string]string{
+			"build": "bundle install",
+			"test":  "bundle exec rake test",
+			"lint":  "bundle exec rubocop",
+			"run":   "ruby main.rb",
+		},
+		ReleaseType:  "ruby",
+		DockerImage:  "ruby:{{version}}",
+		Dockerignore: []string{".bundle", "vendor/bundle", "log", "tmp", "coverage"},
+	},
+	{
+		Name:       "Swift",
+		Extensions: []string{".swift"},
+		Binary:     "swift",
+	},
+	{
+		Name:       "Elixir",
+		Extensions: []string{".ex", ".exs"},
+		Indicators: []string{"mix.exs"},
+		Binary:     "elixir",
+		PostSteps:  []string{"mix deps.get", "mix compile"},
+		Hints:      []string{"mix deps.get", "mix run"},
+	},
+	{
+		Name:       "Zig",
+		Extensions: []string{".zig"},
+		Indicators: []string{"build.zig", "build.zig.zon"},
+		Binary:     "zig",
+		PostSteps:  []string{"zig build"},
+		Hints:      []string{"zig build", "zig build run"},
+	},
+	{
+		Name:       "Dart",
+		Extensions: []string{".dart"},
+		Indicators: []string{"pubspec.yaml"},
+		Binary:     "dart",
+		PostSteps:  []string{"dart pub get"},
+		Hints:      []string{"dart pub get", "dart run"},
+	},
+	{
+		Name:      "Flutter",
+		Binary:    "flutter",
+		PostSteps: []string{"flutter pub get"},
+		Hints:     []string{"flutter pub get", "flutter run"},
+		Gitignore: "Dart",
+	},
+	{
+		Name:       "Scala",
+		Extensions: []string{".scala", ".sc"},
+		Indicators: []string{"build.sbt"},
+		Binary:     "scala",
+		PostSteps:  []string{"sbt compile"},
+		Hints:      []string{"sbt compile", "sbt run"},
+	},
+	{
+		Name:       "Haskell",
+		Extensions: []string{".hs", ".cabal"},
+		Indicators: []string{"stack.yaml"},
+		Binary:     "ghc",
+		PostSteps:  []string{"cabal update", "cabal build"},
+		Hints:      []string{"cabal build", "cabal run"},
+	},
+	{
+		Name:       "Deno",
+		Indicators: []string{"deno.json", "deno.jsonc"},
+		Binary:     "deno",
+		PostSteps:  []string{"deno install"},
+		Hints:      []string{"deno install", "deno task dev"},
+		Gitignore:  "Node",
+	},
+	{
+		Name:       "Bun",
+		Indicators: []string{"bun.lockb", "bunfig.toml"},
+		Binary:     "bun",
+		PostSteps:  []string{"bun install"},
+		Hints:      []string{"bun install", "bun run dev"},
+		Gitignore:  "Node",
+	},
+
+	// Frameworks
+	{
+		Name:      "Next.js",
+		Base:      "React",
+		Markers:   []Marker{{File: "next.config.js"}, {File: "next.config.mjs"}, {File: "next.config.ts"}},
+		PostSteps: []string{"{{install}}"},
+		Hints:     []string{"{{install}}", "{{run}} dev  # http://localhost:3000"},
+	},
+	{
+		Name:      "Django",
+		Base:      "Python",
+		Markers:   []Marker{{File: "manage.py"}},
+		PostSteps: []string{"(test -f requirements.txt && {{install}} || true)", "{{run}} manage.py migrate"},
+		Hints:     []string{"{{run}} manage.py migrate", "{{run}} manage.py runserver  # http://localhost:8000"},
+	},
+	{
+		Name:      "FastAPI",
+		Base:      "Python",
+		Markers:   []Marker{{File: "requirements.txt", Contains: "fastapi"}, {File: "pyproject.toml", Contains: "fastapi"}},
+		PostSteps: []string{"(test -f requirements.txt && {{install}} || true)"},
+		Hints:     []string{"{{install}}", "uvicorn main:app --reload  # http://localhost:8000"},
+	},
+	{
+		Name:      "Flask",
+		Base:      "Python",
+		Markers:   []Marker{{File: "requirements.txt", Contains: "flask"}, {File: "pyproject.toml", Contains: "flask"}},
+		PostSteps: []string{"(test -f requirements.txt && {{install}} || true)"},
+		Hints:     []string{"{{install}}", "flask run  # http://localhost:5000"},
+	},
+	{
+		Name:      "Spring Boot",
+		Base:      "Java",
+		Markers:   []Marker{{File: "pom.xml", Contains: "spring-boot"}, {File: "build.gradle", Contains: "org.springframework.boot"}},
+		PostSteps: []string{"(test -x mvnw && ./mvnw -q -DskipTests package || true)"},
+		Hints:     []string{"./mvnw spring-boot:run  # http://localhost:8080"},
+		Tasks:     map[string]string{"run": "./mvnw spring-boot:run"},
+	},
+	{
+		Name:      "Rails",
+		Base:      "Ruby",
+		Markers:   []Marker{{File: "Gemfile", Contains: "rails"}},
+		PostSteps: []string{"bundle install", "bin/rails db:prepare"},
+		Hints:     []string{"bin/rails server  # http://localhost:3000"},
+		Tasks:     map[string]string{"run": "bin/rails server"},
+	},
+	{
+		Name:      "Phoenix",
+		Base:      "Elixir",
+		Markers:   []Marker{{File: "mix.exs", Contains: ":phoenix"}},
+		PostSteps: []string{"mix deps.get", "mix compile"},
+		Hints:     []string{"mix ecto.setup", "mix phx.server  # http://localhost:4000"},
+	},
+}
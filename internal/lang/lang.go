@@ -0,0 +1,258 @@
+package lang
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/fsys"
+)
+
+// Language describes everything Foundry knows about a language or framework:
+// how to recognise it, how to set up and describe a new project, and how to pin its toolchain.
+type Language struct {
+	Name string `yaml:"name"`
+
+	// Frameworks name the language they build on and inherit its settings;
+	// they are recognised by marker files in the project root
+	Base    string   `yaml:"base,omitempty"`
+	Markers []Marker `yaml:"markers,omitempty"`
+
+	// Detection: file extensions and specific file names found in templates,
+	// and the executable foundry detect looks for
+	Extensions []string `yaml:"extensions,omitempty"`
+	Indicators []string `yaml:"indicators,omitempty"`
+	Binary     string   `yaml:"binary,omitempty"`
+
+	// Project setup: commands run after creation and next steps printed for the user
+	PostSteps []string `yaml:"post_steps,omitempty"`
+	Hints     []string `yaml:"hints,omitempty"`
+
+	// Commands checking a new project is coherent (it compiles, its dependencies resolve),
+	// run by foundry new --validate to catch template bugs when a project is created
+	Validate []string `yaml:"validate,omitempty"`
+
+	// Package manager ecosystem ("javascript", "python") resolving {{install}} and {{run}} in commands
+	Ecosystem string `yaml:"ecosystem,omitempty"`
+
+	// Name of the github/gitignore template (defaults to Name)
+	Gitignore string `yaml:"gitignore,omitempty"`
+
+	// Patterns the generated .dockerignore keeps out of the build context (dependencies, build output)
+	Dockerignore []string `yaml:"dockerignore,omitempty"`
+
+	// Toolchain: mise and asdf tool names, the command printing the installed version,
+	// and the direnv layout activating it
+	Tool           string   `yaml:"tool,omitempty"`
+	AsdfTool       string   `yaml:"asdf_tool,omitempty"`
+	VersionCommand []string `yaml:"version_command,omitempty"`
+	DirenvLayout   string   `yaml:"direnv_layout,omitempty"`
+
+	// Commands behind the generated Makefile/Taskfile targets (build, test, lint, run)
+	Tasks map[string]string `yaml:"tasks,omitempty"`
+
+	// release-please release type
+	ReleaseType string `yaml:"release_type,omitempty"`
+
+	// Base image of generated Dockerfiles; {{version}} becomes the pinned runtime version
+	DockerImage string `yaml:"docker_image,omitempty"`
+}
+
+// Marker identifies a framework by a file in the project root, optionally containing some text
+type Marker struct {
+	File     string `yaml:"file"`
+	Contains string `yaml:"contains,omitempty"`
+}
+
+// GitignoreName returns the github/gitignore template name for the language
+func (l *Language) GitignoreName() string {
+	if l.Gitignore != "" {
+		return l.Gitignore
+	}
+	return l.Name
+}
+
+// Image returns the language's official Docker image at version ("latest" when empty),
+// or "" when the language has none
+func (l *Language) Image(version string) string {
+	if l.DockerImage == "" {
+		return ""
+	}
+	if version == "" {
+		version = "latest"
+	}
+	return strings.ReplaceAll(l.DockerImage, "{{version}}", version)
+}
+
+var (
+	registry []*Language
+	byName   map[string]*Language
+)
+
+func init() {
+	registry = nil
+	byName = make(map[string]*Language)
+	for i := range builtins {
+		l := builtins[i]
+		add(&l)
+	}
+}
+
+// add appends a language to the registry
+func add(l *Language) {
+	registry = append(registry, l)
+	byName[l.Name] = l
+}
+
+// Get returns the language registered under name
+func Get(name string) (*Language, bool) {
+	l, ok := byName[name]
+	return l, ok
+}
+
+// All returns every registered language in registration order
+func All() []*Language {
+	return registry
+}
+
+// Resolve returns the settings for a language or framework.
+// Frameworks are merged over their base language so unset fields are inherited.
+func Resolve(name string) (*Language, bool) {
+	l, ok := byName[name]
+	if !ok {
+		return nil, false
+	}
+	base, ok := byName[l.Base]
+	if l.Base == "" || !ok {
+		return l, true
+	}
+	resolved := *base
+	resolved.Tasks = nil
+	merge(&resolved, base)
+	merge(&resolved, l)
+	resolved.Name = l.Name
+	resolved.Base = l.Base
+	resolved.Markers = l.Markers
+	return &resolved, true
+}
+
+// DetectFramework returns the first registered framework whose markers match the root of dir
+func DetectFramework(dir string) string {
+	for _, l := range registry {
+		for _, m := range l.Markers {
+			data, err := fsys.ReadFile(filepath.Join(dir, m.File))
+			if err != nil {
+				continue
+			}
+			if m.Contains == "" || strings.Contains(string(data), m.Contains) {
+				return l.Name
+			}
+		}
+	}
+	return ""
+}
+
+// Names returns the names of all registered languages, sorted
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for _, l := range registry {
+		names = append(names, l.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Indicators maps file names and extensions to the language they indicate.
+// Specific file names are keyed as is, extensions with their leading dot.
+func Indicators() map[string]string {
+	indicators := make(map[string]string)
+	for _, l := range registry {
+		for _, ext := range l.Extensions {
+			indicators[ext] = l.Name
+		}
+		for _, name := range l.Indicators {
+			indicators[name] = l.Name
+		}
+	}
+	return indicators
+}
+
+// Apply merges user-defined languages into the registry.
+// Fields set on an override replace the built-in value; unknown names register new languages.
+func Apply(overrides []Language) {
+	for i := range overrides {
+		o := overrides[i]
+		if strings.TrimSpace(o.Name) == "" {
+			continue
+		}
+		existing, ok := byName[o.Name]
+		if !ok {
+			add(&o)
+			continue
+		}
+		merge(existing, &o)
+	}
+}
+
+// merge copies the non-empty fields of o onto l
+func merge(l, o *Language) {
+	if o.Base != "" {
+		l.Base = o.Base
+	}
+	if len(o.Markers) > 0 {
+		l.Markers = o.Markers
+	}
+	if len(o.Extensions) > 0 {
+		l.Extensions = o.Extensions
+	}
+	if len(o.Indicators) > 0 {
+		l.Indicators = o.Indicators
+	}
+	if o.Binary != "" {
+		l.Binary = o.Binary
+	}
+	if len(o.PostSteps) > 0 {
+		l.PostSteps = o.PostSteps
+	}
+	if len(o.Hints) > 0 {
+		l.Hints = o.Hints
+	}
+	if len(o.Validate) > 0 {
+		l.Validate = o.Validate
+	}
+	if o.Ecosystem != "" {
+		l.Ecosystem = o.Ecosystem
+	}
+	if o.Gitignore != "" {
+		l.Gitignore = o.Gitignore
+	}
+	if len(o.Dockerignore) > 0 {
+		l.Dockerignore = o.Dockerignore
+	}
+	if o.Tool != "" {
+		l.Tool = o.Tool
+	}
+	if o.AsdfTool != "" {
+		l.AsdfTool = o.AsdfTool
+	}
+	if len(o.VersionCommand) > 0 {
+		l.VersionCommand = o.VersionCommand
+	}
+	if o.DirenvLayout != "" {
+		l.DirenvLayout = o.DirenvLayout
+	}
+	if len(o.Tasks) > 0 {
+		if l.Tasks == nil {
+			l.Tasks = make(map[string]string)
+		}
+		for name, command := range o.Tasks {
+			l.Tasks[name] = command
+		}
+	}
+	if o.ReleaseType != "" {
+		l.ReleaseType = o.ReleaseType
+	}
+	if o.DockerImage != "" {
+		l.DockerImage = o.DockerImage
+	}
+}
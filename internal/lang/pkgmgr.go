@@ -0,0 +1,90 @@
+package lang
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// PackageManager describes the commands behind the {{install}} and {{run}} tokens in steps and tasks
+type PackageManager struct {
+	Name     string
+	Install  string
+	Run      string
+	LockFile string // presence in a project means the team already uses this package manager
+}
+
+// packageManagers lists the package managers of each ecosystem in default preference order
+var packageManagers = map[string][]PackageManager{
+	"javascript": {
+		{Name: "npm", Install: "npm install", Run: "npm run", LockFile: "package-lock.json"},
+		{Name: "pnpm", Install: "pnpm install", Run: "pnpm run", LockFile: "pnpm-lock.yaml"},
+		{Name: "yarn", Install: "yarn install", Run: "yarn run", LockFile: "yarn.lock"},
+		{Name: "bun", Install: "bun install", Run: "bun run", LockFile: "bun.lockb"},
+	},
+	"python": {
+		{Name: "pip", Install: "pip install -r requirements.txt", Run: "python"},
+		{Name: "uv", Install: "uv pip install -r requirements.txt", Run: "uv run python", LockFile: "uv.lock"},
+		{Name: "poetry", Install: "poetry install", Run: "poetry run python", LockFile: "poetry.lock"},
+	},
+}
+
+// preferences holds the user's package manager order per ecosystem
+var preferences = map[string][]string{}
+
+// SetPackageManagerPreferences sets the preferred package managers per ecosystem, most preferred first
+func SetPackageManagerPreferences(prefs map[string][]string) {
+	preferences = make(map[string][]string)
+	for ecosystem, names := range prefs {
+		preferences[strings.ToLower(ecosystem)] = names
+	}
+}
+
+// ChoosePackageManager picks the package manager for an ecosystem.
+// A lock file in projectDir wins; otherwise the first preferred package manager that is installed,
+// falling back to the ecosystem's default.
+func ChoosePackageManager(ecosystem, projectDir string) (PackageManager, bool) {
+	candidates, ok := packageManagers[ecosystem]
+	if !ok {
+		return PackageManager{}, false
+	}
+
+	if projectDir != "" {
+		for _, pm := range candidates {
+			if pm.LockFile == "" {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(projectDir, pm.LockFile)); err == nil {
+				return pm, true
+			}
+		}
+	}
+
+	for _, name := range preferences[ecosystem] {
+		for _, pm := range candidates {
+			if pm.Name != name {
+				continue
+			}
+			if _, err := exec.LookPath(pm.Name); err == nil {
+				return pm, true
+			}
+		}
+	}
+	return candidates[0], true
+}
+
+// Expand replaces the {{install}} and {{run}} tokens in commands using the package manager
+// chosen for the language's ecosystem. Commands without tokens are returned unchanged.
+func Expand(l *Language, projectDir string, commands []string) []string {
+	pm, ok := ChoosePackageManager(l.Ecosystem, projectDir)
+	expanded := make([]string, 0, len(commands))
+	for _, c := range commands {
+		if ok {
+			c = strings.ReplaceAll(c, "{{install}}", pm.Install)
+			c = strings.ReplaceAll(c, "{{run}}", pm.Run)
+		}
+		expanded = append(expanded, c)
+	}
+	return expanded
+}
@@ -0,0 +1,57 @@
+// Package license holds the texts of common open-source licenses, keyed by SPDX identifier,
+// so projects can get a LICENSE file matching the configured license.
+package license
+
+import (
+	"sort"
+	"strings"
+)
+
+// License is a license whose full text Foundry can write
+type License struct {
+	ID   string // SPDX identifier, e.g. "Apache-2.0"
+	Name string
+	text string // with {{YEAR}} and {{AUTHOR}} where the copyright line goes
+}
+
+// Text returns the license text with the copyright year and holder filled in
+func (l *License) Text(year, author string) string {
+	if author == "" {
+		author = "the authors"
+	}
+	return strings.NewReplacer("{{YEAR}}", year, "{{AUTHOR}}", author).Replace(l.text)
+}
+
+var licenses = map[string]*License{}
+
+// register adds a license; called from init functions
+func register(l *License) {
+	licenses[strings.ToLower(l.ID)] = l
+}
+
+// Lookup returns the license with the SPDX identifier id, matched case-insensitively
+func Lookup(id string) (*License, bool) {
+	l, ok := licenses[strings.ToLower(strings.TrimSpace(id))]
+	return l, ok
+}
+
+// IDs returns the SPDX identifiers of all known licenses, sorted
+func IDs() []string {
+	ids := make([]string, 0, len(licenses))
+	for _, l := range licenses {
+		ids = append(ids, l.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Canonical returns id spelled as its SPDX identifier when the license is known, else id itself
+func Canonical(id string) string {
+	if l, ok := Lookup(id); ok {
+		return l.ID
+	}
+	return strings.TrimSpace(id)
+}
+
+// FileNames are the names a license file commonly has at the root of a project
+var FileNames = []string{"LICENSE", "LICENSE.md", "LICENSE.txt", "LICENCE", "LICENCE.md", "COPYING", "UNLICENSE"}
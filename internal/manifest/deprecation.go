@@ -0,0 +1,41 @@
+package manifest
+
+import (
+	"time"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// SunsetLayout is the date format of a deprecation's sunset
+const SunsetLayout = "2006-01-02"
+
+// Deprecation marks a template as deprecated, optionally pointing at its replacement and the
+// date after which it is no longer supported
+type Deprecation struct {
+	Message     string `yaml:"message,omitempty"`
+	Replacement string `yaml:"replacement,omitempty"` // saved template name or template source to use instead
+	Sunset      string `yaml:"sunset,omitempty"`      // YYYY-MM-DD
+}
+
+// SunsetDate returns the parsed sunset date; ok is false when none is set or it does not parse
+func (d *Deprecation) SunsetDate() (date time.Time, ok bool) {
+	if d.Sunset == "" {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(SunsetLayout, d.Sunset)
+	return date, err == nil
+}
+
+// PastSunset reports whether now is after the sunset date
+func (d *Deprecation) PastSunset(now time.Time) bool {
+	date, ok := d.SunsetDate()
+	return ok && now.After(date.AddDate(0, 0, 1))
+}
+
+// validate checks the sunset date parses
+func (d *Deprecation) validate() error {
+	if _, err := time.Parse(SunsetLayout, d.Sunset); d.Sunset != "" && err != nil {
+		return i18n.Errorf("deprecated: sunset '%s' is not a date like 2025-12-31", d.Sunset)
+	}
+	return nil
+}
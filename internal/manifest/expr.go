@@ -0,0 +1,206 @@
+package manifest
+
+import (
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// EvalExpr evaluates the expression of a computed variable against vars. Expressions join
+// quoted strings and variables with +, call the functions in utils.CaseFuncs (lower(PROJECT_NAME)),
+// compare with == and != (case-insensitively, as in when conditions) and choose with
+// cond ? a : b, where cond holds when it is neither empty nor false. Variable names are
+// case-insensitive; an unknown one is an error. With nil vars only the syntax is checked.
+func EvalExpr(expr string, vars map[string]string) (string, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return "", i18n.Errorf("invalid expression %q: %w", expr, err)
+	}
+	p := &exprParser{tokens: tokens, vars: vars}
+	value, err := p.expr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = i18n.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return "", i18n.Errorf("invalid expression %q: %w", expr, err)
+	}
+	return value, nil
+}
+
+// exprToken is a string literal, a name or an operator
+type exprToken struct {
+	text    string
+	literal bool // a quoted string, with text unquoted
+}
+
+// tokenize splits an expression into tokens
+func tokenize(expr string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, i18n.Errorf("unterminated string")
+			}
+			tokens = append(tokens, exprToken{text: expr[i+1 : i+1+end], literal: true})
+			i += end + 2
+		case strings.HasPrefix(expr[i:], "==") || strings.HasPrefix(expr[i:], "!="):
+			tokens = append(tokens, exprToken{text: expr[i : i+2]})
+			i += 2
+		case strings.ContainsRune("+?:(),", rune(c)):
+			tokens = append(tokens, exprToken{text: string(c)})
+			i++
+		default:
+			start := i
+			for i < len(expr) && isName(expr[start:i+1]) {
+				i++
+			}
+			if i == start {
+				return nil, i18n.Errorf("unexpected %q", string(c))
+			}
+			tokens = append(tokens, exprToken{text: expr[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+// exprParser evaluates tokens by recursive descent:
+//
+//	expr    = compare [ "?" expr ":" expr ]
+//	compare = concat [ ( "==" | "!=" ) concat ]
+//	concat  = primary { "+" primary }
+//	primary = string | name | name "(" expr ")" | "(" expr ")"
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	vars   map[string]string
+}
+
+// peek returns the next operator or name, or "" at the end
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) && !p.tokens[p.pos].literal {
+		return p.tokens[p.pos].text
+	}
+	return ""
+}
+
+// expect consumes the operator op
+func (p *exprParser) expect(op string) error {
+	if p.peek() != op {
+		if p.pos < len(p.tokens) {
+			return i18n.Errorf("expected %q, got %q", op, p.tokens[p.pos].text)
+		}
+		return i18n.Errorf("expected %q at the end", op)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *exprParser) expr() (string, error) {
+	cond, err := p.compare()
+	if err != nil || p.peek() != "?" {
+		return cond, err
+	}
+	p.pos++
+	yes, err := p.expr()
+	if err != nil {
+		return "", err
+	}
+	if err := p.expect(":"); err != nil {
+		return "", err
+	}
+	no, err := p.expr()
+	if err != nil {
+		return "", err
+	}
+	if cond != "" && !strings.EqualFold(cond, "false") {
+		return yes, nil
+	}
+	return no, nil
+}
+
+func (p *exprParser) compare() (string, error) {
+	left, err := p.concat()
+	if err != nil {
+		return "", err
+	}
+	op := p.peek()
+	if op != "==" && op != "!=" {
+		return left, nil
+	}
+	p.pos++
+	right, err := p.concat()
+	if err != nil {
+		return "", err
+	}
+	return boolString(strings.EqualFold(left, right) == (op == "==")), nil
+}
+
+func (p *exprParser) concat() (string, error) {
+	value, err := p.primary()
+	if err != nil {
+		return "", err
+	}
+	for p.peek() == "+" {
+		p.pos++
+		next, err := p.primary()
+		if err != nil {
+			return "", err
+		}
+		value += next
+	}
+	return value, nil
+}
+
+func (p *exprParser) primary() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", i18n.Errorf("unexpected end")
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	switch {
+	case tok.literal:
+		return tok.text, nil
+	case tok.text == "(":
+		value, err := p.expr()
+		if err != nil {
+			return "", err
+		}
+		return value, p.expect(")")
+	case !isName(tok.text):
+		return "", i18n.Errorf("unexpected %q", tok.text)
+	case p.peek() == "(":
+		fn, ok := utils.CaseFuncs[strings.ToLower(tok.text)]
+		if !ok {
+			return "", i18n.Errorf("unknown function %s (use %s)", tok.text, strings.Join(utils.SortedKeys(utils.CaseFuncs), ", "))
+		}
+		p.pos++
+		arg, err := p.expr()
+		if err != nil {
+			return "", err
+		}
+		return fn(arg), p.expect(")")
+	case strings.EqualFold(tok.text, "true"), strings.EqualFold(tok.text, "false"):
+		return strings.ToLower(tok.text), nil
+	case p.vars == nil:
+		return "", nil
+	}
+	value, ok := findVar(p.vars, tok.text)
+	if !ok {
+		return "", i18n.Errorf("unknown variable %s", tok.text)
+	}
+	return value, nil
+}
+
+// boolString returns "true" or "false"
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
@@ -0,0 +1,71 @@
+package manifest
+
+import (
+	"sort"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// Group is a section of related variables asked together, such as the database settings. Its
+// condition hides all of them at once, e.g. use_database for the host, port and name.
+type Group struct {
+	Name        string `yaml:"name"`
+	Title       string `yaml:"title,omitempty"` // shown above the group's prompts; the name when empty
+	Description string `yaml:"description,omitempty"`
+	When        string `yaml:"when,omitempty"`
+}
+
+// GroupOf returns the group named name, or nil
+func (m *Manifest) GroupOf(name string) *Group {
+	for i := range m.Groups {
+		if m.Groups[i].Name == name {
+			return &m.Groups[i]
+		}
+	}
+	return nil
+}
+
+// InPromptOrder returns the variables in the order they are asked: those without a group
+// first, then the groups in the order the manifest lists them, each keeping the declaration
+// order of its variables. A variable still comes after the ones its default refers to.
+func (m *Manifest) InPromptOrder() ([]Variable, error) {
+	rank := map[string]int{}
+	for i, g := range m.Groups {
+		rank[g.Name] = i + 1
+	}
+	sorted := append([]Variable(nil), m.Variables...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank[sorted[i].Group] < rank[sorted[j].Group]
+	})
+	return inDefaultOrder(sorted)
+}
+
+// Asks reports whether v is asked for, given the answers so far in vars: the conditions of
+// its group and of the variable itself must both hold
+func (m *Manifest) Asks(v Variable, vars map[string]string) bool {
+	if g := m.GroupOf(v.Group); g != nil {
+		if ok, _ := Eval(g.When, vars); !ok {
+			return false
+		}
+	}
+	ok, _ := Eval(v.When, vars)
+	return ok
+}
+
+// validateGroups checks that groups are named once each and that their conditions parse
+func (m *Manifest) validateGroups() error {
+	seen := map[string]bool{}
+	for _, g := range m.Groups {
+		if g.Name == "" {
+			return i18n.Errorf("groups: every group needs a name")
+		}
+		if seen[g.Name] {
+			return i18n.Errorf("group '%s' is declared twice", g.Name)
+		}
+		seen[g.Name] = true
+		if _, err := Eval(g.When, nil); err != nil {
+			return i18n.Errorf("group '%s': %w", g.Name, err)
+		}
+	}
+	return nil
+}
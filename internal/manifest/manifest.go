@@ -0,0 +1,481 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the manifest file at the root of a template
+const FileName = "foundry.yaml"
+
+// Manifest describes optional template metadata shipped alongside the template files
+type Manifest struct {
+	Name            string `yaml:"name,omitempty"`
+	Description     string `yaml:"description,omitempty"`
+	LongDescription string `yaml:"long_description,omitempty"`
+
+	// Registry metadata
+	Homepage          string   `yaml:"homepage,omitempty"`
+	Maintainer        string   `yaml:"maintainer,omitempty"`
+	MinFoundryVersion string   `yaml:"min_foundry_version,omitempty"`
+	Screenshots       []string `yaml:"screenshots,omitempty"`
+
+	// Free-form key-value labels such as team or tier, copied onto the saved template
+	Labels map[string]string `yaml:"labels,omitempty"`
+
+	// Set when the template should no longer be used for new projects
+	Deprecated *Deprecation `yaml:"deprecated,omitempty"`
+
+	// Version of the template itself; projects record it so foundry update can show what changed since
+	Version   string           `yaml:"version,omitempty"`
+	Changelog []ChangelogEntry `yaml:"changelog,omitempty"`
+
+	// Runtime versions the template targets, keyed by mise tool name (e.g. go: "1.22")
+	Runtimes map[string]string `yaml:"runtimes,omitempty"`
+
+	// Variables the user is asked for when instantiating the template
+	Variables []Variable `yaml:"variables,omitempty"`
+
+	// Sections variables are asked in, in order, such as the database settings
+	Groups []Group `yaml:"groups,omitempty"`
+
+	// Variables derived from the others with an expression, never asked for
+	Computed []Computed `yaml:"computed,omitempty"`
+
+	// Environment variables the template may read as {{ENV.NAME}}, such as GITHUB_USER or
+	// HTTP_PROXY; any other stays out of reach
+	Env []string `yaml:"env,omitempty"`
+
+	// How template files are rendered: placeholders (plain {{NAME}} substitution, the default),
+	// go (Go text/template, with conditionals such as {{ if .DOCKER }}...{{ end }}) or handlebars
+	// (Handlebars/Mustache, for templates written for other scaffolding tools)
+	Engine string `yaml:"engine,omitempty"`
+
+	// Syntax is accepted in place of engine, with the names other tools use: simple,
+	// gotemplate or handlebars
+	Syntax string `yaml:"syntax,omitempty"`
+
+	// Line endings of rendered text files: lf, crlf or auto (the platform's own); by default
+	// line_endings from the user config, else as they are in the template
+	LineEndings string `yaml:"line_endings,omitempty"`
+
+	// Commands run while creating a project from the template
+	Hooks Hooks `yaml:"hooks,omitempty"`
+
+	// Files kept only where a condition holds, e.g. setup.ps1 only when os == windows
+	Files []FileRule `yaml:"files,omitempty"`
+
+	// Files copied as they are, without placeholder replacement (.foundryignore-style patterns),
+	// such as lockfiles, minified assets or fixtures that contain {{ }} themselves
+	Verbatim []string `yaml:"verbatim,omitempty"`
+
+	// How foundry update merges files changed both in a project and in the template; first match wins
+	Merge []MergeRule `yaml:"merge,omitempty"`
+
+	// What foundry new prints once a project is created, such as the template's own next steps
+	Success *Success `yaml:"success,omitempty"`
+}
+
+// Variable declares a template variable that is prompted for unless given with --var
+type Variable struct {
+	Name   string `yaml:"name"`
+	Prompt string `yaml:"prompt,omitempty"`
+	// May refer to other variables and built-ins, e.g. github.com/{{GITHUB_USER}}/{{PROJECT_NAME}}
+	Default  string `yaml:"default,omitempty"`
+	Required bool   `yaml:"required,omitempty"`
+	Type     string `yaml:"type,omitempty"` // string (default) or list of comma-separated values
+
+	// Validation: the allowed values (offered as a menu), or a regular expression the whole value must match
+	Choices []string `yaml:"choices,omitempty"`
+	Pattern string   `yaml:"pattern,omitempty"`
+
+	// The group the variable is asked in, and a condition on the answers before it, such as
+	// use_database; when it does not hold the variable is not asked and keeps its default
+	Group string `yaml:"group,omitempty"`
+	When  string `yaml:"when,omitempty"`
+
+	// A secret such as an API key: asked with hidden input, masked wherever values are shown,
+	// and recorded in the stamp as a reference to an environment variable instead of its value
+	Sensitive bool `yaml:"sensitive,omitempty"`
+}
+
+// Computed is a variable whose value is the expression Value evaluated after the variables
+// are known, e.g. IMAGE: 'lower(OWNER) + "/" + PROJECT_NAME_KEBAB'; see EvalExpr
+type Computed struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// Variable types
+const (
+	TypeString = "string"
+	TypeList   = "list" // comma-separated values, e.g. --var features=auth,metrics
+)
+
+// IsList reports whether the variable holds a list of values
+func (v Variable) IsList() bool {
+	return v.Type == TypeList
+}
+
+// Check reports whether value is allowed for the variable: each value of a list must be one of
+// the choices and match the pattern. An empty value is left to the required check.
+func (v Variable) Check(value string) error {
+	if v.Sensitive && value != "" {
+		if err := v.check(value); err != nil {
+			return i18n.Errorf("the value given for %s is not valid (it must match %s)", v.Name, v.Pattern)
+		}
+		return nil
+	}
+	return v.check(value)
+}
+
+// check is Check, with the offending value in its error
+func (v Variable) check(value string) error {
+	values := []string{value}
+	if v.IsList() {
+		values = utils.SplitList(value)
+	}
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		if len(v.Choices) > 0 && !contains(v.Choices, value) {
+			return i18n.Errorf("'%s' is not a valid %s (choose from %s)", value, v.Name, strings.Join(v.Choices, ", "))
+		}
+		if v.Pattern != "" {
+			if re, err := regexp.Compile("^(?:" + v.Pattern + ")$"); err == nil && !re.MatchString(value) {
+				return i18n.Errorf("'%s' is not a valid %s (must match %s)", value, v.Name, v.Pattern)
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	// referencePattern matches a {{NAME}} or {{ENV.NAME}} reference in a variable default
+	referencePattern = regexp.MustCompile(`\{\{((?:ENV\.)?[A-Za-z_][A-Za-z0-9_]*)\}\}`)
+	// envNamePattern matches the environment variable names env can allow
+	envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// References returns the names the variable's default refers to as {{NAME}}
+func (v Variable) References() []string {
+	var names []string
+	for _, m := range referencePattern.FindAllStringSubmatch(v.Default, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// ResolveDefault returns the variable's default with each {{NAME}} replaced by its value in
+// values; references without a value are kept as written
+func (v Variable) ResolveDefault(values map[string]string) string {
+	return referencePattern.ReplaceAllStringFunc(v.Default, func(match string) string {
+		if value, ok := values[match[2:len(match)-2]]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// InDefaultOrder returns the variables ordered so that each comes after the variables its
+// default refers to, and otherwise in declaration order. Defaults referring to each other in a
+// cycle are an error.
+func (m *Manifest) InDefaultOrder() ([]Variable, error) {
+	return inDefaultOrder(m.Variables)
+}
+
+// inDefaultOrder orders vars so that each comes after the variables its default refers to
+func inDefaultOrder(vars []Variable) ([]Variable, error) {
+	byName := map[string]Variable{}
+	for _, v := range vars {
+		byName[v.Name] = v
+	}
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var ordered []Variable
+	var visit func(v Variable, path []string) error
+	visit = func(v Variable, path []string) error {
+		switch state[v.Name] {
+		case done:
+			return nil
+		case visiting:
+			return i18n.Errorf("variable defaults refer to each other: %s", strings.Join(append(path, v.Name), " → "))
+		}
+		state[v.Name] = visiting
+		for _, ref := range v.References() {
+			if dep, ok := byName[ref]; ok {
+				if err := visit(dep, append(path, v.Name)); err != nil {
+					return err
+				}
+			}
+		}
+		state[v.Name] = done
+		ordered = append(ordered, v)
+		return nil
+	}
+	for _, v := range vars {
+		if err := visit(v, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// EnvVars returns the environment variables the manifest allows as ENV.NAME built-ins, read
+// now; unset ones are empty
+func (m *Manifest) EnvVars() map[string]string {
+	vars := map[string]string{}
+	if m == nil {
+		return vars
+	}
+	for _, name := range m.Env {
+		vars[utils.EnvPrefix+name] = os.Getenv(name)
+	}
+	return vars
+}
+
+// ListVars returns the names of the variables declared as lists
+func (m *Manifest) ListVars() map[string]bool {
+	lists := map[string]bool{}
+	if m == nil {
+		return lists
+	}
+	for _, v := range m.Variables {
+		if v.IsList() {
+			lists[v.Name] = true
+		}
+	}
+	return lists
+}
+
+// MergeRule picks the merge driver for files matching Glob: json, yaml, lines, text or none
+type MergeRule struct {
+	Glob   string `yaml:"glob"`
+	Driver string `yaml:"driver"`
+}
+
+// ChangelogEntry lists the changes made in one template version
+type ChangelogEntry struct {
+	Version string   `yaml:"version"`
+	Changes []string `yaml:"changes"`
+}
+
+// ChangesSince returns the changelog entries newer than version, in manifest order.
+// Entries with unparsable versions are skipped.
+func (m *Manifest) ChangesSince(version string) []ChangelogEntry {
+	var entries []ChangelogEntry
+	for _, e := range m.Changelog {
+		if cmp, err := utils.CompareVersions(e.Version, version); err == nil && cmp > 0 {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Hooks lists shell commands run inside the new project
+type Hooks struct {
+	PostCreate []Hook `yaml:"post_create,omitempty"`
+}
+
+// Hook is a command run only when its condition holds. In foundry.yaml it is either
+// the command itself or a mapping with run (a script for Shell) or args (run directly) and when.
+type Hook struct {
+	Run   string   `yaml:"run,omitempty"`
+	Args  []string `yaml:"args,omitempty"`  // program and arguments, run without a shell
+	Shell string   `yaml:"shell,omitempty"` // bash, sh, zsh, powershell, pwsh or cmd; default depends on the OS
+	When  string   `yaml:"when,omitempty"`
+}
+
+// Shells are the interpreters a hook may name
+var Shells = []string{"bash", "sh", "zsh", "powershell", "pwsh", "cmd"}
+
+// String returns the hook's command line for display
+func (h Hook) String() string {
+	if len(h.Args) > 0 {
+		return strings.Join(h.Args, " ")
+	}
+	return h.Run
+}
+
+// UnmarshalYAML accepts a plain command string as a hook without condition
+func (h *Hook) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		h.Run, h.When = value.Value, ""
+		return nil
+	}
+	type plain Hook
+	return value.Decode((*plain)(h))
+}
+
+// FileRule keeps the files matching Path (a .foundryignore-style pattern) only when When holds
+type FileRule struct {
+	Path string `yaml:"path"`
+	When string `yaml:"when"`
+}
+
+// PostCreateHooks returns the post_create hooks whose condition holds for vars
+func (m *Manifest) PostCreateHooks(vars map[string]string) []Hook {
+	var hooks []Hook
+	for _, h := range m.Hooks.PostCreate {
+		if ok, _ := Eval(h.When, vars); ok {
+			hooks = append(hooks, h)
+		}
+	}
+	return hooks
+}
+
+// ExcludedFiles returns the patterns of files whose condition does not hold for vars
+func (m *Manifest) ExcludedFiles(vars map[string]string) []string {
+	var patterns []string
+	for _, f := range m.Files {
+		if ok, _ := Eval(f.When, vars); !ok {
+			patterns = append(patterns, f.Path)
+		}
+	}
+	return patterns
+}
+
+// validate checks the manifest's conditions parse
+func (m *Manifest) validate() error {
+	for _, h := range m.Hooks.PostCreate {
+		if (h.Run == "") == (len(h.Args) == 0) {
+			return i18n.Errorf("hook %q: set exactly one of run and args", h.String())
+		}
+		if h.Shell != "" && len(h.Args) > 0 {
+			return i18n.Errorf("hook %q: shell does not apply to args, which run without a shell", h.String())
+		}
+		if h.Shell != "" && !contains(Shells, h.Shell) {
+			return i18n.Errorf("hook %q: unknown shell '%s' (use %s)", h.String(), h.Shell, strings.Join(Shells, ", "))
+		}
+		if _, err := Eval(h.When, nil); err != nil {
+			return i18n.Errorf("hook %q: %w", h.String(), err)
+		}
+	}
+	for _, v := range m.Variables {
+		if v.Type != "" && v.Type != TypeString && v.Type != TypeList {
+			return i18n.Errorf("variable '%s': unknown type '%s' (use %s or %s)", v.Name, v.Type, TypeString, TypeList)
+		}
+		if v.Pattern != "" {
+			if _, err := regexp.Compile(v.Pattern); err != nil {
+				return i18n.Errorf("variable '%s': invalid pattern: %w", v.Name, err)
+			}
+		}
+		if v.Sensitive && len(v.Choices) > 0 {
+			return i18n.Errorf("variable '%s': a sensitive variable cannot have choices, which are shown in a menu", v.Name)
+		}
+		if v.Sensitive && !envNamePattern.MatchString(v.Name) {
+			return i18n.Errorf("variable '%s': a sensitive variable needs a name usable as an environment variable", v.Name)
+		}
+		if v.Group != "" && m.GroupOf(v.Group) == nil {
+			return i18n.Errorf("variable '%s': unknown group '%s'", v.Name, v.Group)
+		}
+		if _, err := Eval(v.When, nil); err != nil {
+			return i18n.Errorf("variable '%s': %w", v.Name, err)
+		}
+		// Defaults referring to other variables are checked once resolved
+		if len(v.References()) > 0 {
+			continue
+		}
+		if err := v.Check(v.Default); err != nil {
+			return i18n.Errorf("variable '%s': default: %w", v.Name, err)
+		}
+	}
+	if err := m.validateGroups(); err != nil {
+		return err
+	}
+	if _, err := m.InDefaultOrder(); err != nil {
+		return err
+	}
+	declared := map[string]bool{}
+	for _, v := range m.Variables {
+		declared[v.Name] = true
+	}
+	for _, c := range m.Computed {
+		if c.Name == "" || strings.TrimSpace(c.Value) == "" {
+			return i18n.Errorf("computed: set both name and value")
+		}
+		if declared[c.Name] {
+			return i18n.Errorf("computed '%s': already declared as a variable", c.Name)
+		}
+		declared[c.Name] = true
+		if _, err := EvalExpr(c.Value, nil); err != nil {
+			return i18n.Errorf("computed '%s': %w", c.Name, err)
+		}
+	}
+	for _, name := range m.Env {
+		if !envNamePattern.MatchString(name) {
+			return i18n.Errorf("env: invalid environment variable name '%s'", name)
+		}
+	}
+	if m.Syntax != "" {
+		if m.Engine != "" && utils.EngineName(m.Engine) != utils.EngineName(m.Syntax) {
+			return i18n.Errorf("engine '%s' and syntax '%s' disagree; set only one", m.Engine, m.Syntax)
+		}
+		m.Engine = m.Syntax
+	}
+	m.Engine = utils.EngineName(m.Engine)
+	if m.Engine != "" && !contains(utils.Engines, m.Engine) {
+		return i18n.Errorf("unknown engine '%s' (use %s)", m.Engine, strings.Join(utils.Engines, ", "))
+	}
+	if m.LineEndings != "" && !contains(utils.LineEndingModes, m.LineEndings) {
+		return i18n.Errorf("unknown line_endings '%s' (use %s)", m.LineEndings, strings.Join(utils.LineEndingModes, ", "))
+	}
+	if m.Deprecated != nil {
+		if err := m.Deprecated.validate(); err != nil {
+			return err
+		}
+	}
+	if err := m.Success.validate(); err != nil {
+		return err
+	}
+	for _, f := range m.Files {
+		if f.Path == "" {
+			return i18n.Errorf("files: entry without path")
+		}
+		if _, err := Eval(f.When, nil); err != nil {
+			return i18n.Errorf("files %q: %w", f.Path, err)
+		}
+	}
+	return nil
+}
+
+// contains reports whether list holds s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Load reads the manifest from the root of dir.
+// It returns nil without error when the template has no manifest.
+func Load(dir string) (*Manifest, error) {
+	data, err := fsys.ReadFile(filepath.Join(dir, FileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	if err := yaml.Unmarshal(data, m); err != nil {
+		return nil, i18n.Errorf("failed to parse %s: %w", FileName, err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, i18n.Errorf("invalid %s: %w", FileName, err)
+	}
+	return m, nil
+}
@@ -0,0 +1,72 @@
+package manifest
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// Mask is shown in place of the value of a sensitive variable
+const Mask = "********"
+
+// secretRefPrefix starts the reference a stamp records for a sensitive variable
+const secretRefPrefix = "env:"
+
+// SecretRef is what the stamp records in place of a sensitive variable's value: the environment
+// variable of the same name, which foundry update and foundry reproduce read the value from
+func SecretRef(name string) string {
+	return secretRefPrefix + name
+}
+
+// IsSensitive reports whether the manifest marks the variable named name as sensitive
+func (m *Manifest) IsSensitive(name string) bool {
+	if m == nil {
+		return false
+	}
+	for _, v := range m.Variables {
+		if v.Name == name {
+			return v.Sensitive
+		}
+	}
+	return false
+}
+
+// Display returns value as it may be shown for the variable named name: masked when sensitive
+func (m *Manifest) Display(name, value string) string {
+	if m.IsSensitive(name) && value != "" {
+		return Mask
+	}
+	return value
+}
+
+// Recorded returns vars as a stamp records them, with references in place of sensitive values
+func (m *Manifest) Recorded(vars map[string]string) map[string]string {
+	recorded := make(map[string]string, len(vars))
+	for k, v := range vars {
+		if m.IsSensitive(k) && v != "" {
+			v = SecretRef(k)
+		}
+		recorded[k] = v
+	}
+	return recorded
+}
+
+// ResolveSecrets replaces the references a stamp recorded for sensitive variables with the
+// values of their environment variables. It returns the names whose environment variable is
+// not set; those are left out of vars, so they are asked again.
+func (m *Manifest) ResolveSecrets(vars map[string]string) []string {
+	var unset []string
+	for k, v := range vars {
+		if !m.IsSensitive(k) || !strings.HasPrefix(v, secretRefPrefix) {
+			continue
+		}
+		if value, ok := os.LookupEnv(strings.TrimPrefix(v, secretRefPrefix)); ok {
+			vars[k] = value
+		} else {
+			delete(vars, k)
+			unset = append(unset, k)
+		}
+	}
+	sort.Strings(unset)
+	return unset
+}
@@ -0,0 +1,82 @@
+package manifest
+
+import "github.com/kajvans/foundry/internal/i18n"
+
+// Success customizes what foundry new prints once a project is created, so teams can point new
+// projects at their own documentation. Its text may hold placeholders. Locales holds translated
+// variants keyed by locale (nl); their fields replace the English ones in that locale.
+type Success struct {
+	Message   string             `yaml:"message,omitempty"`    // replaces "Project 'name' created successfully!"
+	NextSteps []string           `yaml:"next_steps,omitempty"` // replace the language's next steps
+	Links     []Link             `yaml:"links,omitempty"`      // documentation and onboarding pages
+	Locales   map[string]Success `yaml:"locales,omitempty"`
+}
+
+// Link is a page listed after the next steps
+type Link struct {
+	Title string `yaml:"title,omitempty"`
+	URL   string `yaml:"url"`
+}
+
+// Localized returns the fields of s for locale: those its translation for locale sets, the
+// English ones otherwise
+func (s *Success) Localized(locale string) Success {
+	if s == nil {
+		return Success{}
+	}
+	out := Success{Message: s.Message, NextSteps: s.NextSteps, Links: s.Links}
+	if l, ok := s.Locales[locale]; ok {
+		if l.Message != "" {
+			out.Message = l.Message
+		}
+		if len(l.NextSteps) > 0 {
+			out.NextSteps = l.NextSteps
+		}
+		if len(l.Links) > 0 {
+			out.Links = l.Links
+		}
+	}
+	return out
+}
+
+// CombineSuccess localizes the success blocks and layers them in order: a later message or list
+// of next steps replaces an earlier one, while links add up, leaving out repeated URLs
+func CombineSuccess(locale string, layers ...*Success) Success {
+	var combined Success
+	seen := map[string]bool{}
+	for _, layer := range layers {
+		s := layer.Localized(locale)
+		if s.Message != "" {
+			combined.Message = s.Message
+		}
+		if len(s.NextSteps) > 0 {
+			combined.NextSteps = s.NextSteps
+		}
+		for _, link := range s.Links {
+			if !seen[link.URL] {
+				seen[link.URL] = true
+				combined.Links = append(combined.Links, link)
+			}
+		}
+	}
+	return combined
+}
+
+// validate checks every link, translations included, has a URL
+func (s *Success) validate() error {
+	if s == nil {
+		return nil
+	}
+	variants := []Success{*s}
+	for _, l := range s.Locales {
+		variants = append(variants, l)
+	}
+	for _, v := range variants {
+		for _, link := range v.Links {
+			if link.URL == "" {
+				return i18n.Errorf("success: link %q without url", link.Title)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package manifest
+
+import (
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// Eval reports whether a when condition holds for vars. A condition compares a variable with a
+// value (os == windows, shell != pwsh), tests whether a comma-separated list variable holds a
+// value (features contains auth, !features contains auth) or tests a variable alone (wsl, !wsl);
+// comparisons are joined with && and ||, where && binds tighter. Variable names are
+// case-insensitive, so os reads {{OS}}. An empty condition always holds.
+func Eval(cond string, vars map[string]string) (bool, error) {
+	if strings.TrimSpace(cond) == "" {
+		return true, nil
+	}
+	for _, alternative := range strings.Split(cond, "||") {
+		all := true
+		for _, term := range strings.Split(alternative, "&&") {
+			ok, err := evalTerm(strings.TrimSpace(term), vars)
+			if err != nil {
+				return false, i18n.Errorf("invalid condition %q: %w", cond, err)
+			}
+			all = all && ok
+		}
+		if all {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evalTerm evaluates a single comparison or variable test
+func evalTerm(term string, vars map[string]string) (bool, error) {
+	if key, value, ok := strings.Cut(term, " contains "); ok {
+		key, value = strings.TrimSpace(key), unquote(strings.TrimSpace(value))
+		negate := strings.HasPrefix(key, "!")
+		key = strings.TrimSpace(strings.TrimPrefix(key, "!"))
+		if !isName(key) {
+			return false, i18n.Errorf("expected a variable name before contains, got %q", key)
+		}
+		found := false
+		for _, item := range utils.SplitList(lookupVar(vars, key)) {
+			if strings.EqualFold(item, value) {
+				found = true
+			}
+		}
+		return found != negate, nil
+	}
+	for _, op := range []string{"==", "!="} {
+		if key, value, ok := strings.Cut(term, op); ok {
+			key, value = strings.TrimSpace(key), unquote(strings.TrimSpace(value))
+			if !isName(key) {
+				return false, i18n.Errorf("expected a variable name before %s, got %q", op, key)
+			}
+			equal := strings.EqualFold(lookupVar(vars, key), value)
+			return equal == (op == "=="), nil
+		}
+	}
+
+	negate := strings.HasPrefix(term, "!")
+	key := strings.TrimSpace(strings.TrimPrefix(term, "!"))
+	if !isName(key) {
+		return false, i18n.Errorf("expected a variable name or comparison, got %q", term)
+	}
+	value := lookupVar(vars, key)
+	set := value != "" && !strings.EqualFold(value, "false")
+	return set != negate, nil
+}
+
+// lookupVar returns the variable named key, matching names case-insensitively
+func lookupVar(vars map[string]string, key string) string {
+	v, _ := findVar(vars, key)
+	return v
+}
+
+// findVar returns the variable named key, matching names case-insensitively, and whether it is set
+func findVar(vars map[string]string, key string) (string, bool) {
+	if v, ok := vars[strings.ToUpper(key)]; ok {
+		return v, true
+	}
+	for k, v := range vars {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// isName reports whether s is a variable name: letters, digits and underscores
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// unquote strips matching single or double quotes around a value
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,187 @@
+package merge
+
+import (
+	"strings"
+
+	"github.com/kajvans/foundry/internal/diff"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// Merge driver names, as used in the manifest's merge rules
+const (
+	JSON  = "json"  // deep merge of objects
+	YAML  = "yaml"  // deep merge of mappings, keeping comments
+	Lines = "lines" // append lines the template added
+	Text  = "text"  // line-based three-way merge
+	None  = "none"  // never merge; the file stays a conflict
+)
+
+// lineFiles are merged by appending missing lines unless a rule says otherwise
+var lineFiles = []string{".gitignore", ".dockerignore", ".foundryignore", ".env.example"}
+
+// Check reports rules that name an unknown driver
+func Check(rules []manifest.MergeRule) error {
+	for _, r := range rules {
+		switch r.Driver {
+		case JSON, YAML, Lines, Text, None:
+		default:
+			return i18n.Errorf("merge rule for '%s': unknown driver '%s' (use json, yaml, lines, text or none)", r.Glob, r.Driver)
+		}
+	}
+	return nil
+}
+
+// DriverFor returns the driver for a file: the first matching rule, else a default by file type
+func DriverFor(rel string, rules []manifest.MergeRule) string {
+	for _, r := range rules {
+		if utils.MatchGlob(r.Glob, rel) {
+			return r.Driver
+		}
+	}
+	for _, name := range lineFiles {
+		if utils.MatchGlob(name, rel) {
+			return Lines
+		}
+	}
+	switch {
+	case utils.MatchGlob("*.json", rel):
+		return JSON
+	case utils.MatchGlob("*.yaml", rel), utils.MatchGlob("*.yml", rel):
+		return YAML
+	}
+	return Text
+}
+
+// Merge combines the project's version (ours) with the template's (theirs), using base, the
+// template's previous output, to tell who changed what. base may be nil when it is unknown.
+// It reports false when the versions cannot be merged without a decision from the user.
+func Merge(driver string, base, ours, theirs []byte) ([]byte, bool, error) {
+	if utils.IsBinary(ours, 8000) || utils.IsBinary(theirs, 8000) {
+		return nil, false, nil
+	}
+	switch driver {
+	case JSON:
+		return mergeJSON(base, ours, theirs)
+	case YAML:
+		return mergeYAML(base, ours, theirs)
+	case Lines:
+		return appendLines(base, ours, theirs), true, nil
+	case Text:
+		if base == nil {
+			return nil, false, nil
+		}
+		merged, clean := threeWay(string(base), string(ours), string(theirs))
+		return []byte(merged), clean, nil
+	case None:
+		return nil, false, nil
+	}
+	return nil, false, i18n.Errorf("unknown merge driver '%s' (use json, yaml, lines, text or none)", driver)
+}
+
+// appendLines adds the lines theirs has that ours lacks to the end of ours.
+// Lines that were already in base are not added back, so lines the user deleted stay deleted.
+func appendLines(base, ours, theirs []byte) []byte {
+	have := map[string]bool{}
+	for _, l := range diff.Lines(string(ours)) {
+		have[l] = true
+	}
+	for _, l := range diff.Lines(string(base)) {
+		have[l] = true
+	}
+
+	out := string(ours)
+	if out != "" && !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	for _, l := range diff.Lines(string(theirs)) {
+		if !have[l] && strings.TrimSpace(l) != "" {
+			out += l + "\n"
+			have[l] = true
+		}
+	}
+	return []byte(out)
+}
+
+// threeWay merges the line changes of ours and theirs relative to base.
+// Overlapping changes are wrapped in conflict markers and reported as unclean.
+func threeWay(base, ours, theirs string) (string, bool) {
+	b := diff.Lines(base)
+	o := diff.Lines(ours)
+	t := diff.Lines(theirs)
+	toOurs := matches(b, o)
+	toTheirs := matches(b, t)
+
+	var out []string
+	clean := true
+	i, oi, ti := 0, 0, 0
+	for {
+		// The next base line kept by both sides anchors the end of this chunk
+		j := i
+		for j < len(b) && (toOurs[j] < 0 || toTheirs[j] < 0) {
+			j++
+		}
+		oe, te := len(o), len(t)
+		if j < len(b) {
+			oe, te = toOurs[j], toTheirs[j]
+		}
+
+		baseChunk, oursChunk, theirsChunk := b[i:j], o[oi:oe], t[ti:te]
+		switch {
+		case equal(oursChunk, baseChunk):
+			out = append(out, theirsChunk...)
+		case equal(theirsChunk, baseChunk), equal(oursChunk, theirsChunk):
+			out = append(out, oursChunk...)
+		default:
+			clean = false
+			out = append(out, "<<<<<<< yours")
+			out = append(out, oursChunk...)
+			out = append(out, "=======")
+			out = append(out, theirsChunk...)
+			out = append(out, ">>>>>>> template")
+		}
+
+		if j == len(b) {
+			break
+		}
+		out = append(out, b[j])
+		i, oi, ti = j+1, oe+1, te+1
+	}
+	if len(out) == 0 {
+		return "", clean
+	}
+	return strings.Join(out, "\n") + "\n", clean
+}
+
+// matches maps each line of a to its index in b along their common subsequence, or -1
+func matches(a, b []string) []int {
+	m := make([]int, len(a))
+	i, j := 0, 0
+	for _, op := range diff.Edits(a, b) {
+		switch op.Kind {
+		case ' ':
+			m[i] = j
+			i++
+			j++
+		case '-':
+			m[i] = -1
+			i++
+		case '+':
+			j++
+		}
+	}
+	return m
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
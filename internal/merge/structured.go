@@ -0,0 +1,234 @@
+package merge
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mergeYAML deep-merges YAML mappings, keeping the project's key order and comments
+func mergeYAML(base, ours, theirs []byte) ([]byte, bool, error) {
+	doc, merged, ok := mergeDocuments(base, ours, theirs)
+	if !ok {
+		return nil, false, nil
+	}
+	doc.Content[0] = merged
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, false, err
+	}
+	return buf.Bytes(), true, nil
+}
+
+// mergeJSON deep-merges JSON objects, keeping the project's key order and indentation
+func mergeJSON(base, ours, theirs []byte) ([]byte, bool, error) {
+	_, merged, ok := mergeDocuments(base, ours, theirs)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, merged, detectIndent(string(ours)), 0); err != nil {
+		return nil, false, err
+	}
+	if bytes.HasSuffix(ours, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), true, nil
+}
+
+// mergeDocuments parses the three versions (JSON parses as YAML) and merges their root nodes.
+// It returns the project's document so its comments can be kept.
+func mergeDocuments(base, ours, theirs []byte) (*yaml.Node, *yaml.Node, bool) {
+	o, t := parseRoot(ours), parseRoot(theirs)
+	if o == nil || t == nil {
+		return nil, nil, false
+	}
+	var b *yaml.Node
+	if doc := parseRoot(base); doc != nil {
+		b = doc.Content[0]
+	}
+	merged, clean := mergeNode(b, o.Content[0], t.Content[0])
+	return o, merged, clean
+}
+
+// parseRoot parses data into a document node, or returns nil if it is empty or invalid
+func parseRoot(data []byte) *yaml.Node {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	return &doc
+}
+
+// mergeNode merges the project's node with the template's. Mappings are merged key by key:
+// keys the template added are appended, keys it removed are dropped unless the user changed them,
+// and values only one side changed take that side. Anything changed on both sides is unclean.
+func mergeNode(base, ours, theirs *yaml.Node) (*yaml.Node, bool) {
+	switch {
+	case nodeEqual(ours, theirs):
+		return ours, true
+	case base != nil && nodeEqual(ours, base):
+		return theirs, true
+	case base != nil && nodeEqual(theirs, base):
+		return ours, true
+	case ours.Kind != yaml.MappingNode || theirs.Kind != yaml.MappingNode:
+		return ours, false
+	}
+	if base != nil && base.Kind != yaml.MappingNode {
+		base = nil
+	}
+
+	result := *ours
+	result.Content = nil
+	clean := true
+	for k := 0; k+1 < len(ours.Content); k += 2 {
+		key, ov := ours.Content[k], ours.Content[k+1]
+		tv, bv := lookup(theirs, key.Value), lookup(base, key.Value)
+		if tv == nil {
+			if bv != nil && nodeEqual(ov, bv) {
+				continue // removed by the template, untouched by the user
+			}
+			if bv != nil {
+				clean = false // removed by the template, changed by the user
+			}
+			result.Content = append(result.Content, key, ov)
+			continue
+		}
+		merged, ok := mergeNode(bv, ov, tv)
+		clean = clean && ok
+		result.Content = append(result.Content, key, merged)
+	}
+	for k := 0; k+1 < len(theirs.Content); k += 2 {
+		key, tv := theirs.Content[k], theirs.Content[k+1]
+		if lookup(ours, key.Value) != nil {
+			continue
+		}
+		bv := lookup(base, key.Value)
+		switch {
+		case bv == nil:
+			result.Content = append(result.Content, key, tv) // new in the template
+		case !nodeEqual(tv, bv):
+			clean = false // removed by the user, changed by the template
+		}
+	}
+	return &result, clean
+}
+
+// lookup returns the value of key in a mapping node, or nil
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil {
+		return nil
+	}
+	for k := 0; k+1 < len(mapping.Content); k += 2 {
+		if mapping.Content[k].Value == key {
+			return mapping.Content[k+1]
+		}
+	}
+	return nil
+}
+
+// nodeEqual compares the data of two nodes, ignoring comments and formatting
+func nodeEqual(a, b *yaml.Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Kind == yaml.AliasNode {
+		return nodeEqual(a.Alias, b)
+	}
+	if b.Kind == yaml.AliasNode {
+		return nodeEqual(a, b.Alias)
+	}
+	if a.Kind != b.Kind || len(a.Content) != len(b.Content) {
+		return false
+	}
+	if a.Kind == yaml.ScalarNode && (a.Value != b.Value || a.ShortTag() != b.ShortTag()) {
+		return false
+	}
+	for i := range a.Content {
+		if !nodeEqual(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// detectIndent returns the indentation of the first indented line, defaulting to two spaces
+func detectIndent(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) < len(line) {
+			return line[:len(line)-len(trimmed)]
+		}
+	}
+	return "  "
+}
+
+// writeJSON renders a node parsed from JSON back to JSON, keeping key order
+func writeJSON(buf *bytes.Buffer, n *yaml.Node, indent string, level int) error {
+	pad := strings.Repeat(indent, level)
+	switch n.Kind {
+	case yaml.AliasNode:
+		return writeJSON(buf, n.Alias, indent, level)
+	case yaml.MappingNode:
+		if len(n.Content) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		buf.WriteString("{\n")
+		for k := 0; k+1 < len(n.Content); k += 2 {
+			buf.WriteString(pad + indent)
+			writeString(buf, n.Content[k].Value)
+			buf.WriteString(": ")
+			if err := writeJSON(buf, n.Content[k+1], indent, level+1); err != nil {
+				return err
+			}
+			if k+2 < len(n.Content) {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(pad + "}")
+	case yaml.SequenceNode:
+		if len(n.Content) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[\n")
+		for i, item := range n.Content {
+			buf.WriteString(pad + indent)
+			if err := writeJSON(buf, item, indent, level+1); err != nil {
+				return err
+			}
+			if i+1 < len(n.Content) {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(pad + "]")
+	default:
+		switch n.ShortTag() {
+		case "!!null":
+			buf.WriteString("null")
+		case "!!bool", "!!int", "!!float":
+			buf.WriteString(n.Value)
+		default:
+			writeString(buf, n.Value)
+		}
+	}
+	return nil
+}
+
+// writeString writes s as a JSON string without escaping HTML characters
+func writeString(buf *bytes.Buffer, s string) {
+	var tmp bytes.Buffer
+	enc := json.NewEncoder(&tmp)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	buf.Write(bytes.TrimSuffix(tmp.Bytes(), []byte("\n")))
+}
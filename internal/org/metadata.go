@@ -0,0 +1,88 @@
+package org
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultMetadataFile is where the service metadata is written when the schema names no file
+const defaultMetadataFile = "service.yaml"
+
+// Metadata is the schema of the service metadata file stamped into every new project,
+// read by downstream service catalogs
+type Metadata struct {
+	File   string          `yaml:"file,omitempty"` // path in the project; .json writes JSON, anything else YAML
+	Fields []MetadataField `yaml:"fields"`
+}
+
+// MetadataField is one entry of the metadata file. Value may use any placeholder
+// ({{OWNER}}, {{RUNTIME}}, ...), filled from the project's variables and built-ins.
+type MetadataField struct {
+	Name     string `yaml:"name"`
+	Value    string `yaml:"value"`
+	Required bool   `yaml:"required,omitempty"`
+	Prompt   string `yaml:"prompt,omitempty"` // asked in interactive mode when a required value is missing
+}
+
+// Path returns the metadata file's path relative to the project
+func (m *Metadata) Path() string {
+	if m.File == "" {
+		return defaultMetadataFile
+	}
+	return filepath.ToSlash(m.File)
+}
+
+// Resolve fills every field's value from vars and returns them in schema order, together
+// with the required fields left empty or holding unresolved placeholders
+func (m *Metadata) Resolve(projectName, author string, vars map[string]string) (values []string, missing []MetadataField) {
+	for _, f := range m.Fields {
+		value := strings.TrimSpace(utils.ReplacePlaceholders(f.Value, projectName, author, vars))
+		if strings.Contains(value, "{{") {
+			value = ""
+		}
+		if f.Required && value == "" {
+			missing = append(missing, f)
+		}
+		values = append(values, value)
+	}
+	return values, missing
+}
+
+// Render returns the metadata file for the resolved values, in the format its extension names
+func (m *Metadata) Render(values []string) ([]byte, error) {
+	if strings.EqualFold(filepath.Ext(m.Path()), ".json") {
+		var buf bytes.Buffer
+		buf.WriteString("{\n")
+		for i, f := range m.Fields {
+			key, _ := json.Marshal(f.Name)
+			value, _ := json.Marshal(values[i])
+			fmt.Fprintf(&buf, "  %s: %s", key, value)
+			if i+1 < len(m.Fields) {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString("}\n")
+		return buf.Bytes(), nil
+	}
+
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	for i, f := range m.Fields {
+		doc.Content = append(doc.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: f.Name},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: values[i]})
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
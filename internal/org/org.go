@@ -0,0 +1,185 @@
+package org
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/generate"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/manifest"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvVar overrides the org_config location from the user config
+const EnvVar = "FOUNDRY_ORG_CONFIG"
+
+// cacheFile is the copy of a remote org config kept next to the user config
+const cacheFile = "org-config.yaml"
+
+// Config is the organization-wide configuration a platform team shares with developers
+type Config struct {
+	// Golden paths: named combinations of template, components and policies
+	Bundles map[string]Bundle `yaml:"bundles,omitempty"`
+
+	// Schema of the service metadata file written into every new project
+	Metadata *Metadata `yaml:"metadata,omitempty"`
+
+	// Limits on template hooks, combined with the user's hook_policy (the stricter wins)
+	HookPolicy config.HookPolicy `yaml:"hook_policy,omitempty"`
+
+	// Refuse to create projects from templates their foundry.yaml marks deprecated
+	BlockDeprecated bool `yaml:"block_deprecated,omitempty"`
+
+	// The shared template registry: templates everyone uses as shared/<name>
+	Templates []SharedTemplate `yaml:"templates,omitempty"`
+
+	// Hosts foundry new may clone git templates from
+	GitHosts GitHosts `yaml:"git_hosts,omitempty"`
+
+	// Schedule, reviewers and labels of the dependabot and renovate components
+	DependencyUpdates *generate.DependencyUpdates `yaml:"dependency_updates,omitempty"`
+
+	// What foundry new prints once a project is created, such as links to internal documentation;
+	// user config and templates can override the message and next steps, and add links
+	Success *manifest.Success `yaml:"success,omitempty"`
+}
+
+// GitHosts is the allowlist of hosts git templates may come from, so a typo in a --git URL
+// does not scaffold a project from a look-alike repository
+type GitHosts struct {
+	Allowed []string `yaml:"allowed,omitempty"` // host names; "*.example.com" matches its subdomains
+	Warn    bool     `yaml:"warn,omitempty"`    // warn about other hosts instead of refusing them
+}
+
+// Allows reports whether templates may be cloned from host. Without an allowlist every host is allowed.
+func (g GitHosts) Allows(host string) bool {
+	if len(g.Allowed) == 0 {
+		return true
+	}
+	host = strings.ToLower(host)
+	for _, pattern := range g.Allowed {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// Bundle is a golden path instantiated with foundry new --bundle
+type Bundle struct {
+	Description string            `yaml:"description,omitempty"`
+	Template    string            `yaml:"template"`             // saved template name or any template source
+	Components  []string          `yaml:"components,omitempty"` // generated as with --with
+	Variables   map[string]string `yaml:"variables,omitempty"`  // defaults; --var overrides them
+	Policies    Policies          `yaml:"policies,omitempty"`
+}
+
+// Policies are rules a bundle enforces on the projects created from it
+type Policies struct {
+	RequireGit   bool     `yaml:"require_git,omitempty"`  // refuse --no-git and --output-archive
+	Reproducible bool     `yaml:"reproducible,omitempty"` // always create as with --reproducible
+	Protected    []string `yaml:"protected,omitempty"`    // globs recorded as protected in the project stamp
+}
+
+// Location returns where the org config is read from: $FOUNDRY_ORG_CONFIG, else org_config
+// from the user config. It is empty when no org config is set up.
+func Location(cfg *config.Config) string {
+	if env := os.Getenv(EnvVar); env != "" {
+		return env
+	}
+	return cfg.OrgConfig
+}
+
+// Load reads the org config set up for cfg, or returns an empty config when there is none.
+// Remote configs are cached next to the user config and the cached copy is used when offline.
+func Load(cfg *config.Config) (*Config, error) {
+	location := Location(cfg)
+	if location == "" {
+		return &Config{}, nil
+	}
+
+	var data []byte
+	var err error
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		data, err = fetch(location)
+	} else {
+		data, err = os.ReadFile(location)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("cannot read org config %s: %w", location, err)
+	}
+
+	c := &Config{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, i18n.Errorf("failed to parse org config %s: %w", location, err)
+	}
+	return c, nil
+}
+
+// Bundle returns the named bundle
+func (c *Config) Bundle(name string) (*Bundle, error) {
+	b, ok := c.Bundles[name]
+	if !ok {
+		if len(c.Bundles) == 0 {
+			return nil, i18n.Errorf("bundle '%s' not found: no org config with bundles is set up (foundry config --org-config <path|url>)", name)
+		}
+		return nil, i18n.Errorf("bundle '%s' not found (available: %s)", name, strings.Join(c.BundleNames(), ", "))
+	}
+	return &b, nil
+}
+
+// BundleNames returns the names of all bundles, sorted
+func (c *Config) BundleNames() []string {
+	names := make([]string, 0, len(c.Bundles))
+	for name := range c.Bundles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fetch downloads a remote org config, refreshing the cached copy, or falls back to the cache
+func fetch(url string) ([]byte, error) {
+	cache := ""
+	if dir, err := config.Dir(); err == nil {
+		cache = filepath.Join(dir, cacheFile)
+	}
+
+	data, err := download(url)
+	if err != nil {
+		if cache != "" {
+			if cached, cacheErr := os.ReadFile(cache); cacheErr == nil {
+				return cached, nil
+			}
+		}
+		return nil, err
+	}
+	if cache != "" {
+		_ = os.WriteFile(cache, data, 0644)
+	}
+	return data, nil
+}
+
+// download returns the body of url
+func download(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
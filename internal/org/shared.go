@@ -0,0 +1,128 @@
+package org
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+// SharedPrefix is the namespace of shared templates, as in foundry new -t shared/go-api
+const SharedPrefix = "shared/"
+
+// SharedTemplate is a template published in the org config for everyone to use. Unlike a
+// personal template it has no local path: it is fetched from its source when used.
+type SharedTemplate struct {
+	Name        string            `yaml:"name"`
+	Source      string            `yaml:"source"` // git URL, archive or bucket
+	SHA256      string            `yaml:"sha256,omitempty"`
+	Language    string            `yaml:"language,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+
+	// Metadata carried over from the published template
+	Homepage          string   `yaml:"homepage,omitempty"`
+	Maintainer        string   `yaml:"maintainer,omitempty"`
+	MinFoundryVersion string   `yaml:"min_foundry_version,omitempty"`
+	Screenshots       []string `yaml:"screenshots,omitempty"`
+}
+
+// Template returns the shared template as a template with the shared scope, named shared/<name>
+func (s SharedTemplate) Template() config.Template {
+	return config.Template{
+		Name:        SharedPrefix + s.Name,
+		Language:    s.Language,
+		Description: s.Description,
+		Source:      s.Source,
+		SHA256:      s.SHA256,
+		Labels:      s.Labels,
+		Scope:       config.ScopeShared,
+
+		Homepage:          s.Homepage,
+		Maintainer:        s.Maintainer,
+		MinFoundryVersion: s.MinFoundryVersion,
+		Screenshots:       s.Screenshots,
+	}
+}
+
+// Shared returns the shared template called name, with or without the shared/ prefix
+func (c *Config) Shared(name string) (*SharedTemplate, error) {
+	name = strings.TrimPrefix(name, SharedPrefix)
+	for _, t := range c.Templates {
+		if t.Name == name {
+			return &t, nil
+		}
+	}
+	if len(c.Templates) == 0 {
+		return nil, i18n.Errorf("shared template '%s' not found: the org config has no shared templates", name)
+	}
+	return nil, i18n.Errorf("shared template '%s' not found (see foundry template list --scope shared)", name)
+}
+
+// Publish adds tmpl to the shared templates in the org config file at location, replacing a
+// shared template of the same name. The rest of the file, comments included, is kept as it is.
+func Publish(location string, tmpl SharedTemplate) error {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return i18n.Errorf("cannot publish to the remote org config %s; publish to a local checkout of it and push that", location)
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(location)
+	if err != nil && !os.IsNotExist(err) {
+		return i18n.Errorf("cannot read org config %s: %w", location, err)
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return i18n.Errorf("failed to parse org config %s: %w", location, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return i18n.Errorf("failed to parse org config %s: expected a mapping at the top level", location)
+	}
+
+	entry := &yaml.Node{}
+	if err := entry.Encode(tmpl); err != nil {
+		return err
+	}
+	templates := mappingValue(root, "templates")
+	if templates == nil {
+		templates = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "templates"}, templates)
+	}
+	replaced := false
+	for i, item := range templates.Content {
+		if name := mappingValue(item, "name"); name != nil && name.Value == tmpl.Name {
+			templates.Content[i] = entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		templates.Content = append(templates.Content, entry)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	return os.WriteFile(location, buf.Bytes(), 0644)
+}
+
+// mappingValue returns the value node for key in a YAML mapping, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -1,22 +1,165 @@
 package post
 
 import (
+	"os"
 	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/lang"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/utils"
 )
 
-// RunLanguagePost executes language-specific setup commands inside projectDir.
-// It is safe: failures do not abort; they return error to be handled by caller.
-func RunLanguagePost(language, projectDir string) error {
-	var cmd *exec.Cmd
-	switch language {
-	case "Go":
-		cmd = exec.Command("bash", "-lc", "cd \""+projectDir+"\" && go mod tidy && go build")
-	case "JavaScript", "TypeScript", "React":
-		cmd = exec.Command("bash", "-lc", "cd \""+projectDir+"\" && npm install && npm run dev")
-	case "Python":
-		cmd = exec.Command("bash", "-lc", "cd \""+projectDir+"\" && (test -f requirements.txt && pip install -r requirements.txt || true) && python main.py")
-	default:
+// Environ turns built-in variables (OS, SHELL, ...) into FOUNDRY_<NAME> environment entries,
+// so post steps and hooks can choose commands for the machine they run on
+func Environ(vars map[string]string) []string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		// ENV.NAME values come from the environment the commands already inherit
+		if !strings.HasPrefix(name, utils.EnvPrefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	env := make([]string, 0, len(names))
+	for _, name := range names {
+		env = append(env, "FOUNDRY_"+name+"="+vars[name])
+	}
+	return env
+}
+
+// RunLanguagePost executes language-specific setup commands inside projectDir, with env
+// added to the environment. It is safe: failures do not abort; they return error to be handled by caller.
+func RunLanguagePost(language, projectDir string, env []string) error {
+	l, ok := lang.Resolve(language)
+	if !ok || len(l.PostSteps) == 0 {
 		return nil
 	}
+	for _, step := range lang.Expand(l, projectDir, l.PostSteps) {
+		cmd, err := shellCommand("", step)
+		if err != nil {
+			return err
+		}
+		cmd.Dir = projectDir
+		cmd.Env = append(os.Environ(), env...)
+		if err := cmd.Run(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LanguageSteps returns the language-specific setup commands RunLanguagePost runs in projectDir,
+// as hooks so their programs can be listed
+func LanguageSteps(language, projectDir string) []manifest.Hook {
+	l, ok := lang.Resolve(language)
+	if !ok {
+		return nil
+	}
+	var steps []manifest.Hook
+	for _, step := range lang.Expand(l, projectDir, l.PostSteps) {
+		steps = append(steps, manifest.Hook{Run: step})
+	}
+	return steps
+}
+
+// RunLanguagePostInContainer executes the language-specific setup commands in a container of
+// image with projectDir mounted, so the toolchain need not be installed locally
+func RunLanguagePostInContainer(language, projectDir, image string, env []string) error {
+	l, ok := lang.Resolve(language)
+	if !ok || len(l.PostSteps) == 0 {
+		return nil
+	}
+	script := strings.Join(lang.Expand(l, projectDir, l.PostSteps), " && ")
+	cmd, err := containerCommand(manifest.Hook{Run: script}, image, projectDir, env)
+	if err != nil {
+		return err
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
+
+// HookOptions controls how template hooks run
+type HookOptions struct {
+	Env    []string          // added to the hooks' environment
+	Policy config.HookPolicy // programs hooks may invoke, and whether they run in a container
+	Image  string            // container image when Policy.Container is set and names none
+}
+
+// RunHooks runs template hooks one by one inside projectDir, streaming their output.
+// Hooks with args run the program directly; the others run their script in the hook's shell.
+// Nothing runs if a hook invokes a program the policy refuses. It stops at the first failing hook.
+func RunHooks(hooks []manifest.Hook, projectDir string, opts HookOptions) error {
+	if err := CheckPolicy(hooks, opts.Policy); err != nil {
+		return err
+	}
+	image := opts.Policy.Image
+	if image == "" {
+		image = opts.Image
+	}
+	if opts.Policy.Container && image == "" {
+		return i18n.Errorf("hooks must run in a container, but no image is known for this language; set image in hook_policy")
+	}
+
+	for _, h := range hooks {
+		var cmd *exec.Cmd
+		var err error
+		switch {
+		case opts.Policy.Container:
+			cmd, err = containerCommand(h, image, projectDir, opts.Env)
+		case len(h.Args) > 0:
+			cmd = exec.Command(h.Args[0], h.Args[1:]...)
+		default:
+			cmd, err = shellCommand(h.Shell, h.Run)
+		}
+		if err != nil {
+			return i18n.Errorf("hook %q: %w", h.String(), err)
+		}
+		cmd.Dir = projectDir
+		cmd.Env = append(os.Environ(), opts.Env...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return i18n.Errorf("hook %q failed: %w", h.String(), err)
+		}
+	}
+	return nil
+}
+
+// DefaultShell returns the shell scripts run in when none is named: bash where available,
+// else sh, and on Windows without bash PowerShell (pwsh when installed)
+func DefaultShell() string {
+	if _, err := exec.LookPath("bash"); err == nil {
+		return "bash"
+	}
+	if runtime.GOOS != "windows" {
+		return "sh"
+	}
+	if _, err := exec.LookPath("pwsh"); err == nil {
+		return "pwsh"
+	}
+	return "powershell"
+}
+
+// shellCommand returns the command running script in shell ("" for DefaultShell)
+func shellCommand(shell, script string) (*exec.Cmd, error) {
+	if shell == "" {
+		shell = DefaultShell()
+	}
+	switch shell {
+	case "bash", "zsh":
+		return exec.Command(shell, "-lc", script), nil
+	case "sh":
+		return exec.Command("sh", "-c", script), nil
+	case "powershell", "pwsh":
+		return exec.Command(shell, "-NoProfile", "-NonInteractive", "-Command", script), nil
+	case "cmd":
+		return exec.Command("cmd", "/C", script), nil
+	}
+	return nil, i18n.Errorf("unknown shell '%s'", shell)
+}
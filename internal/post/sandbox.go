@@ -0,0 +1,175 @@
+package post
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/manifest"
+)
+
+var (
+	// substitutions matches $(...) and `...`, whose commands run even inside double quotes
+	substitutions = regexp.MustCompile("\\$\\(([^()]*)\\)|`([^`]*)`")
+	// quoted matches quoted strings, which are arguments rather than commands
+	quoted = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'[^']*'`)
+	// separators splits a script into simple commands
+	separators = regexp.MustCompile(`&&|\|\||[;&|\n(){}]|\$\(|` + "`")
+)
+
+// shellKeywords start a segment without naming a program
+var shellKeywords = map[string]bool{
+	"if": true, "then": true, "else": true, "elif": true, "fi": true, "do": true, "done": true,
+	"while": true, "until": true, "!": true, "time": true, "esac": true,
+}
+
+// shellBuiltins run inside the shell without starting a program, so policies do not list them
+var shellBuiltins = map[string]bool{
+	"cd": true, "echo": true, "printf": true, "test": true, "[": true, "[[": true, "true": true,
+	"false": true, "exit": true, "export": true, "set": true, "unset": true, "read": true,
+	":": true, "pwd": true, "shift": true, "return": true, "local": true,
+}
+
+// Programs returns the programs a hook invokes. For scripts this is a best-effort reading of
+// every simple command, including command substitutions, leaving out shell builtins;
+// args hooks name exactly one program.
+func Programs(h manifest.Hook) []string {
+	if len(h.Args) > 0 {
+		return []string{programName(h.Args[0])}
+	}
+
+	var segments []string
+	for _, m := range substitutions.FindAllStringSubmatch(h.Run, -1) {
+		segments = append(segments, m[1]+m[2])
+	}
+	segments = append(segments, separators.Split(quoted.ReplaceAllString(h.Run, "''"), -1)...)
+
+	seen := map[string]bool{}
+	var programs []string
+	for _, segment := range segments {
+		for _, word := range strings.Fields(segment) {
+			if shellKeywords[word] || isAssignment(word) {
+				continue
+			}
+			if word == "for" || word == "case" || word == "select" {
+				break // loop and case headers list words, not commands
+			}
+			if name := programName(word); name != "" && !shellBuiltins[name] && !seen[name] {
+				seen[name] = true
+				programs = append(programs, name)
+			}
+			break
+		}
+	}
+	return programs
+}
+
+// CheckPolicy returns an error naming every program the hooks invoke that policy does not allow
+func CheckPolicy(hooks []manifest.Hook, policy config.HookPolicy) error {
+	var problems []string
+	for _, h := range hooks {
+		for _, program := range Programs(h) {
+			if reason := refused(program, policy); reason != "" {
+				problems = append(problems, fmt.Sprintf("%q runs %s, which is %s", h.String(), program, reason))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return i18n.Errorf("hook policy refuses:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// refused explains why policy does not allow program, or returns "" when it does
+func refused(program string, policy config.HookPolicy) string {
+	for _, denied := range policy.Deny {
+		if matchProgram(denied, program) {
+			return "denied"
+		}
+	}
+	if len(policy.Allow) == 0 {
+		return ""
+	}
+	for _, allowed := range policy.Allow {
+		if matchProgram(allowed, program) {
+			return ""
+		}
+	}
+	return "not in the allowlist"
+}
+
+// matchProgram matches a program name against a policy entry, which may be a glob
+func matchProgram(pattern, program string) bool {
+	matched, _ := filepath.Match(strings.ToLower(pattern), strings.ToLower(program))
+	return matched
+}
+
+// programName returns the bare name of a program: no directory, quotes or Windows extension
+func programName(word string) string {
+	word = strings.Trim(word, `"'`)
+	name := filepath.Base(strings.ReplaceAll(word, `\`, "/"))
+	for _, ext := range []string{".exe", ".cmd", ".bat", ".com"} {
+		if strings.HasSuffix(strings.ToLower(name), ext) {
+			name = name[:len(name)-len(ext)]
+		}
+	}
+	if name == "." || name == "/" {
+		return ""
+	}
+	return name
+}
+
+// isAssignment reports whether word is an environment assignment prefix such as FOO=bar
+func isAssignment(word string) bool {
+	name, _, ok := strings.Cut(word, "=")
+	return ok && name != "" && !strings.ContainsAny(name, "/$-")
+}
+
+// containerRuntime returns the docker-compatible CLI used to run hooks in a container
+func containerRuntime() (string, error) {
+	for _, name := range []string{"docker", "podman"} {
+		if _, err := exec.LookPath(name); err == nil {
+			return name, nil
+		}
+	}
+	return "", i18n.Errorf("running hooks in a container needs docker or podman")
+}
+
+// containerCommand returns the command running h in image with projectDir mounted as the working directory
+func containerCommand(h manifest.Hook, image, projectDir string, env []string) (*exec.Cmd, error) {
+	cli, err := containerRuntime()
+	if err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"run", "--rm", "-v", abs + ":/work", "-w", "/work"}
+	// Files created in the container belong to the user, who has no home directory there
+	if uid, gid := os.Getuid(), os.Getgid(); uid >= 0 {
+		args = append(args, "--user", fmt.Sprintf("%d:%d", uid, gid), "-e", "HOME=/tmp")
+	}
+	for _, e := range env {
+		args = append(args, "-e", e)
+	}
+	args = append(args, image)
+	switch h.Shell {
+	case "powershell", "pwsh", "cmd":
+		return nil, i18n.Errorf("shell '%s' is not available in containers; use sh, bash or args", h.Shell)
+	case "":
+		h.Shell = "sh" // official images do not all ship bash
+	}
+	if len(h.Args) > 0 {
+		args = append(args, h.Args...)
+	} else {
+		args = append(args, h.Shell, "-c", h.Run)
+	}
+	return exec.Command(cli, args...), nil
+}
@@ -0,0 +1,53 @@
+package post
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/lang"
+)
+
+// Check is the outcome of one validation command
+type Check struct {
+	Command string
+	Skipped bool   // the program is not installed, so the command did not run
+	Err     error  // nil when the command succeeded
+	Output  string // combined output, kept when the command failed
+}
+
+// Validate runs the language's validate commands inside projectDir, with env added to the
+// environment, and reports each of them. Commands whose program is not installed are skipped
+// rather than failed, so validation never blames the template for a missing toolchain.
+func Validate(language, projectDir string, env []string) []Check {
+	l, ok := lang.Resolve(language)
+	if !ok || len(l.Validate) == 0 {
+		return nil
+	}
+	var checks []Check
+	for _, command := range lang.Expand(l, projectDir, l.Validate) {
+		check := Check{Command: command}
+		if fields := strings.Fields(command); len(fields) > 0 {
+			if _, err := exec.LookPath(programName(fields[0])); err != nil {
+				check.Skipped = true
+				checks = append(checks, check)
+				continue
+			}
+		}
+		cmd, err := shellCommand("", command)
+		if err != nil {
+			check.Err = err
+			checks = append(checks, check)
+			continue
+		}
+		cmd.Dir = projectDir
+		cmd.Env = append(os.Environ(), env...)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			check.Err = err
+			check.Output = strings.TrimSpace(string(out))
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
@@ -0,0 +1,134 @@
+package project
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// IsArchiveName reports whether file has an archive suffix WriteArchive can produce
+func IsArchiveName(file string) bool {
+	lower := strings.ToLower(file)
+	for _, suffix := range []string{".tar.gz", ".tgz", ".tar", ".zip"} {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteArchive packs the project in dir into file (.tar.gz, .tgz, .tar or .zip).
+// Entries are stored under the project's directory name, as if the project had been created in place.
+func WriteArchive(dir, file string) error {
+	if !IsArchiveName(file) {
+		return i18n.Errorf("unsupported archive format: %s (use .tar.gz, .tgz, .tar or .zip)", file)
+	}
+
+	out, err := fsys.Create(file)
+	if err != nil {
+		return i18n.Errorf("cannot create archive: %w", err)
+	}
+	lower := strings.ToLower(file)
+	if strings.HasSuffix(lower, ".zip") {
+		err = writeZip(dir, out)
+	} else {
+		err = writeTar(dir, out, !strings.HasSuffix(lower, ".tar"))
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fsys.Remove(file)
+		return i18n.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
+
+// walkProject calls fn for every directory and regular file in dir with its slash-separated archive name
+func walkProject(dir string, fn func(path, name string, info os.FileInfo) error) error {
+	prefix := filepath.Base(dir)
+	return fsys.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		return fn(path, filepath.ToSlash(filepath.Join(prefix, rel)), info)
+	})
+}
+
+// writeTar writes dir as a (gzipped) tarball to w
+func writeTar(dir string, w io.Writer, gzipped bool) error {
+	if gzipped {
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	defer tw.Close()
+
+	return walkProject(dir, func(path, name string, info os.FileInfo) error {
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		return copyInto(tw, path)
+	})
+}
+
+// writeZip writes dir as a zip file to w
+func writeZip(dir string, w io.Writer) error {
+	zw := zip.NewWriter(w)
+	defer zw.Close()
+
+	return walkProject(dir, func(path, name string, info os.FileInfo) error {
+		hdr, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+		if info.IsDir() {
+			hdr.Name += "/"
+		} else {
+			hdr.Method = zip.Deflate
+		}
+		fw, err := zw.CreateHeader(hdr)
+		if err != nil || info.IsDir() {
+			return err
+		}
+		return copyInto(fw, path)
+	})
+}
+
+// copyInto streams the file at path into w
+func copyInto(w io.Writer, path string) error {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,57 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// baseDirName is the directory inside .foundry holding the merge base
+const baseDirName = "base"
+
+// BaseDir returns where a project keeps the template output Foundry last wrote.
+// foundry update uses it as the common ancestor when merging files changed on both sides.
+func BaseDir(projectDir string) string {
+	return filepath.Join(projectDir, stamp.Dir, baseDirName)
+}
+
+// SaveBase makes the files of fromDir the merge base of the project, for every path in
+// digests whose file in fromDir has the recorded digest. Other recorded paths keep their
+// previous base, and paths without a record are dropped.
+func SaveBase(fromDir, projectDir string, digests map[string]string) error {
+	baseDir := BaseDir(projectDir)
+	staging := baseDir + ".new"
+	fsys.RemoveAll(staging)
+
+	for rel, digest := range digests {
+		src := filepath.Join(fromDir, filepath.FromSlash(rel))
+		if current, err := utils.FileDigest(src); err != nil || current != digest {
+			src = filepath.Join(baseDir, filepath.FromSlash(rel))
+		}
+		info, err := fsys.Stat(src)
+		if err != nil {
+			continue // no base known for this file
+		}
+		dst := filepath.Join(staging, filepath.FromSlash(rel))
+		if err := fsys.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			fsys.RemoveAll(staging)
+			return err
+		}
+		if err := copyFile(src, dst, info.Mode().Perm()); err != nil {
+			fsys.RemoveAll(staging)
+			return err
+		}
+	}
+
+	if err := fsys.RemoveAll(baseDir); err != nil {
+		fsys.RemoveAll(staging)
+		return err
+	}
+	if _, err := fsys.Stat(staging); os.IsNotExist(err) {
+		return nil
+	}
+	return fsys.Rename(staging, baseDir)
+}
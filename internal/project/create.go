@@ -1,16 +1,21 @@
 package project
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/manifest"
 	"github.com/kajvans/foundry/internal/utils"
 )
 
 // CreateFromTemplate copies the template to the target directory with placeholder replacement
+// in file contents and in file and directory names
 func CreateFromTemplate(tmpl *config.Template, projectName, targetDir, author string, extraVars map[string]string) error {
 	if err := ensureTargetDir(targetDir); err != nil {
 		return err
@@ -23,9 +28,76 @@ func CreateFromTemplate(tmpl *config.Template, projectName, targetDir, author st
 
 	targetInsideSource := isTargetInsideSource(absSourceDir, absTargetDir)
 
-	ignores := utils.LoadIgnorePatterns(absSourceDir, ".foundryignore")
+	ignores, err := templateIgnores(absSourceDir, extraVars)
+	if err != nil {
+		return err
+	}
+	m, err := manifest.Load(absSourceDir)
+	if err != nil {
+		return err
+	}
+
+	render := func(rel, content string, vars map[string]string) (string, error) {
+		content, err := utils.ExpandIncludes(absSourceDir, rel, content)
+		if err != nil {
+			return "", err
+		}
+		return utils.ReplacePlaceholders(content, projectName, author, vars), nil
+	}
+	switch engineOf(tmpl, m) {
+	case utils.EngineGo:
+		render = func(rel, content string, vars map[string]string) (string, error) {
+			content, err := utils.ExpandIncludes(absSourceDir, rel, content)
+			if err != nil {
+				return "", err
+			}
+			return utils.RenderTemplate(rel, content, projectName, author, vars, m.ListVars())
+		}
+	case utils.EngineHandlebars:
+		render = func(rel, content string, vars map[string]string) (string, error) {
+			content, err := utils.ExpandIncludes(absSourceDir, rel, content)
+			if err != nil {
+				return "", err
+			}
+			return utils.RenderHandlebars(rel, content, projectName, author, vars, m.ListVars())
+		}
+	}
+	lineEndings := LineEndings
+	if m != nil && m.LineEndings != "" {
+		lineEndings = m.LineEndings
+	}
+	if lineEndings != "" {
+		renderText := render
+		render = func(rel, content string, vars map[string]string) (string, error) {
+			content, err := renderText(rel, content, vars)
+			return utils.NormalizeLineEndings(content, lineEndings), err
+		}
+	}
+	if verbatim := verbatimPatterns(absSourceDir, m); len(verbatim) > 0 {
+		renderContent := render
+		render = func(rel, content string, vars map[string]string) (string, error) {
+			if utils.MatchIgnore(filepath.ToSlash(rel), verbatim) {
+				return content, nil
+			}
+			return renderContent(rel, content, vars)
+		}
+	}
+	rename := func(rel string) ([]fileCopy, error) {
+		return expandPath(rel, projectName, author, extraVars, m.ListVars())
+	}
+	return copyTree(tmpl.Path, targetDir, absSourceDir, targetInsideSource, ignores, render, rename)
+}
 
-	return copyTree(tmpl.Path, targetDir, absSourceDir, targetInsideSource, projectName, author, extraVars, ignores)
+// engineOf returns how a template's files are rendered: with the engine saved for the template,
+// else the one its foundry.yaml names, else plain placeholders
+func engineOf(tmpl *config.Template, m *manifest.Manifest) string {
+	switch {
+	case tmpl.Engine != "":
+		return tmpl.Engine
+	case m != nil && m.Engine != "":
+		return m.Engine
+	}
+	return utils.EnginePlaceholders
 }
 
 // PreviewSummary holds information about what would be generated
@@ -35,25 +107,43 @@ type PreviewSummary struct {
 	Template    string
 	Language    string
 	Files       []string
+
+	// Placeholders found in each planned file, in file order; files without any are left out
+	Substitutions []FileSubstitutions
 }
 
-// PreviewFromTemplate walks the template and reports planned file outputs without writing
+// PreviewFromTemplate walks the template and reports planned file outputs, and the values their
+// placeholders would receive, without writing
 func PreviewFromTemplate(tmpl *config.Template, projectName, targetDir, author string, extraVars map[string]string) (*PreviewSummary, error) {
 	absTargetDir, absSourceDir, err := resolvePaths(targetDir, tmpl.Path)
 	if err != nil {
 		return nil, err
 	}
 	targetInsideSource := isTargetInsideSource(absSourceDir, absTargetDir)
-	ignores := utils.LoadIgnorePatterns(absSourceDir, ".foundryignore")
+	ignores, err := templateIgnores(absSourceDir, extraVars)
+	if err != nil {
+		return nil, err
+	}
+	m, err := manifest.Load(absSourceDir)
+	if err != nil {
+		return nil, err
+	}
+	engine := engineOf(tmpl, m)
+	verbatim := verbatimPatterns(absSourceDir, m)
 
 	files := []string{}
-	err = filepath.Walk(tmpl.Path, func(srcPath string, info os.FileInfo, err error) error {
+	var dirs []string
+	var substitutions []FileSubstitutions
+	err = fsys.Walk(tmpl.Path, func(srcPath string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 		if info.IsDir() && shouldSkipDir(info.Name()) {
 			return filepath.SkipDir
 		}
+		if !info.IsDir() && isJunkFile(info.Name()) {
+			return nil
+		}
 		if targetInsideSource {
 			relSrcFromSource, _ := filepath.Rel(absSourceDir, srcPath)
 			relTarget, _ := filepath.Rel(absSourceDir, targetDir)
@@ -69,34 +159,101 @@ func PreviewFromTemplate(tmpl *config.Template, projectName, targetDir, author s
 		if err != nil {
 			return err
 		}
-		if utils.MatchIgnore(filepath.ToSlash(relPath), ignores) {
+		if utils.MatchIgnore(filepath.ToSlash(relPath), ignores) || utils.GlobalIgnored(relPath, info.IsDir()) {
 			if info.IsDir() {
 				return filepath.SkipDir
 			}
 			return nil
 		}
-		if relPath == "." {
+		if relPath == "." || relPath == manifest.FileName || relPath == KeepFile {
 			return nil
 		}
-		dstPath := filepath.Join(targetDir, relPath)
-		files = append(files, dstPath)
+		text := filepath.ToSlash(relPath)
+		if !info.IsDir() && info.Mode().IsRegular() {
+			content, err := fsys.ReadFile(srcPath)
+			if err != nil {
+				return i18n.Errorf("failed to read %s: %w", srcPath, err)
+			}
+			if !utils.IsBinaryFile(relPath, content) && !utils.MatchIgnore(filepath.ToSlash(relPath), verbatim) {
+				decoded, _ := utils.DecodeText(content)
+				expanded, err := utils.ExpandIncludes(absSourceDir, relPath, decoded)
+				if err != nil {
+					return err
+				}
+				text += "\n" + expanded
+			}
+		}
+		copies, err := expandPath(relPath, projectName, author, extraVars, m.ListVars())
+		if err != nil {
+			return err
+		}
+		for _, c := range copies {
+			dstPath := filepath.Join(targetDir, c.rel)
+			files = append(files, dstPath)
+			if info.IsDir() {
+				dirs = append(dirs, dstPath)
+			}
+			if subs := findSubstitutions(text, engine, substitutionValues(projectName, author, c.vars)); len(subs) > 0 {
+				substitutions = append(substitutions, FileSubstitutions{File: dstPath, Substitutions: subs})
+			}
+		}
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
+	for _, dir := range dirs {
+		if !slices.ContainsFunc(files, func(f string) bool { return strings.HasPrefix(f, dir+string(os.PathSeparator)) }) {
+			files = append(files, filepath.Join(dir, GitkeepFile))
+		}
+	}
 	return &PreviewSummary{
 		ProjectName: projectName,
 		TargetDir:   targetDir,
 		Template:    tmpl.Name,
 		Language:    tmpl.Language,
 		Files:       files,
+
+		Substitutions: substitutions,
 	}, nil
 }
 
+// templateIgnores returns the patterns of template files left out of the project: those in
+// .foundryignore and those whose files condition in the manifest does not hold for vars
+func templateIgnores(templateDir string, vars map[string]string) ([]string, error) {
+	ignores := utils.LoadIgnorePatterns(templateDir, ".foundryignore")
+	m, err := manifest.Load(templateDir)
+	if err != nil {
+		return nil, err
+	}
+	if m != nil {
+		ignores = append(ignores, m.ExcludedFiles(vars)...)
+	}
+	return ignores, nil
+}
+
+// KeepFile lists, one .foundryignore-style pattern per line, template files copied verbatim
+const KeepFile = ".foundrykeep"
+
+// VerbatimPatterns returns the patterns of template files copied without placeholder
+// replacement: the manifest's verbatim list and the lines of .foundrykeep
+func VerbatimPatterns(templateDir string) []string {
+	m, _ := manifest.Load(templateDir)
+	return verbatimPatterns(templateDir, m)
+}
+
+// verbatimPatterns is VerbatimPatterns for an already loaded manifest, which may be nil
+func verbatimPatterns(templateDir string, m *manifest.Manifest) []string {
+	patterns := utils.LoadIgnorePatterns(templateDir, KeepFile)
+	if m != nil {
+		patterns = append(patterns, m.Verbatim...)
+	}
+	return patterns
+}
+
 func ensureTargetDir(targetDir string) error {
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+	if err := fsys.MkdirAll(targetDir, 0755); err != nil {
+		return i18n.Errorf("failed to create directory: %w", err)
 	}
 	return nil
 }
@@ -104,11 +261,11 @@ func ensureTargetDir(targetDir string) error {
 func resolvePaths(targetDir, sourceDir string) (string, string, error) {
 	absTargetDir, err := filepath.Abs(targetDir)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get absolute target path: %w", err)
+		return "", "", i18n.Errorf("failed to get absolute target path: %w", err)
 	}
 	absSourceDir, err := filepath.Abs(sourceDir)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get absolute source path: %w", err)
+		return "", "", i18n.Errorf("failed to get absolute source path: %w", err)
 	}
 	if realTarget, err := filepath.EvalSymlinks(absTargetDir); err == nil {
 		absTargetDir = realTarget
@@ -124,44 +281,290 @@ func isTargetInsideSource(absSourceDir, absTargetDir string) bool {
 	return relErr == nil && !strings.HasPrefix(relTarget, "..")
 }
 
-func copyTree(sourceRoot, targetRoot, absSourceDir string, targetInsideSource bool, projectName, author string, extraVars map[string]string, ignores []string) error {
-	walker := func(srcPath string, info os.FileInfo, err error) error {
+// renderFunc turns the content of the template file rel into the content written to the
+// project, with vars as the variables
+type renderFunc func(rel, content string, vars map[string]string) (string, error)
+
+// fileCopy is a path a template file or directory is written to, and the variables it is
+// rendered with
+type fileCopy struct {
+	rel  string
+	vars map[string]string
+}
+
+// renameFunc turns the template path rel into the paths written to the project
+type renameFunc func(rel string) ([]fileCopy, error)
+
+// ItemVar is the variable holding the current item in files generated once per item of a list
+const ItemVar = "ITEM"
+
+// expandPath returns the project paths of the template path rel. An element holding the
+// placeholder of a list variable is written once per item, so features/{{FEATURES}}.go becomes
+// features/auth.go and features/metrics.go, each rendered with {{ITEM}} set to its item; with no
+// items the path is left out. Other placeholders are replaced as by renderPath.
+func expandPath(rel, projectName, author string, vars map[string]string, lists map[string]bool) ([]fileCopy, error) {
+	copies := []fileCopy{{rel: filepath.ToSlash(rel), vars: vars}}
+	for _, name := range utils.SortedKeys(lists) {
+		placeholder := "{{" + name + "}}"
+		var expanded []fileCopy
+		for _, c := range copies {
+			if !strings.Contains(c.rel, placeholder) {
+				expanded = append(expanded, c)
+				continue
+			}
+			for _, item := range utils.SplitList(vars[name]) {
+				if !validPathElement(item) {
+					return nil, i18n.Errorf("template path %s renders to the invalid name %q", c.rel, item)
+				}
+				itemVars := make(map[string]string, len(c.vars)+1)
+				for k, v := range c.vars {
+					itemVars[k] = v
+				}
+				itemVars[ItemVar] = item
+				expanded = append(expanded, fileCopy{rel: strings.ReplaceAll(c.rel, placeholder, item), vars: itemVars})
+			}
+		}
+		copies = expanded
+	}
+	for i, c := range copies {
+		rendered, err := renderPath(c.rel, projectName, author, c.vars)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if skip, skipDir := shouldSkipEntry(info, srcPath, sourceRoot, targetRoot, absSourceDir, targetInsideSource, ignores); skip {
-			if skipDir {
-				return filepath.SkipDir
+		copies[i].rel = rendered
+	}
+	return copies, nil
+}
+
+// renderPath replaces the placeholders in each element of the template path rel, so
+// cmd/{{PROJECT_NAME}}/main.go becomes cmd/my-api/main.go. An element that renders empty
+// or to a path of its own is an error, so a variable cannot move files outside the project.
+func renderPath(rel, projectName, author string, vars map[string]string) (string, error) {
+	if !strings.Contains(rel, "{{") {
+		return rel, nil
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for i, part := range parts {
+		rendered := utils.ReplacePlaceholders(part, projectName, author, vars)
+		if !validPathElement(rendered) {
+			return "", i18n.Errorf("template path %s renders to the invalid name %q", filepath.ToSlash(rel), rendered)
+		}
+		parts[i] = rendered
+	}
+	return filepath.FromSlash(strings.Join(parts, "/")), nil
+}
+
+// validPathElement reports whether name can stand for a single element of a project path
+func validPathElement(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// projectPath joins rel to the project directory, refusing paths that would end up outside it
+func projectPath(targetRoot, rel string) (string, error) {
+	dst := filepath.Join(targetRoot, rel)
+	if inner, err := filepath.Rel(targetRoot, dst); err != nil || !filepath.IsLocal(inner) {
+		return "", i18n.Errorf("template path %s leaves the project directory", filepath.ToSlash(rel))
+	}
+	return dst, nil
+}
+
+// Workers is how many template files are copied and rendered at the same time. With 1, the
+// default, files are written one by one in walk order.
+var Workers = 1
+
+// copyJob is a template file waiting to be copied into the project
+type copyJob struct {
+	src, dst, rel string
+	mode          os.FileMode
+	vars          map[string]string
+}
+
+// LineEndings converts the line endings of rendered text files to lf, crlf or auto (the
+// platform's own) for templates whose foundry.yaml does not choose; line_endings in the config
+// sets it, and empty leaves them as they are
+var LineEndings = ""
+
+// FollowSymlinks copies what template symlinks point to instead of re-creating the links;
+// follow_symlinks in the config sets it
+var FollowSymlinks = false
+
+// GitkeepFile is written into directories that are empty in the template, so the project's
+// first commit keeps them
+const GitkeepFile = ".gitkeep"
+
+func copyTree(sourceRoot, targetRoot, absSourceDir string, targetInsideSource bool, ignores []string, render renderFunc, rename renameFunc) error {
+	var jobs []copyJob
+	var dirs []string
+	// walkFrom visits the tree at root, whose entries are at relRoot in the template; links to
+	// directories that are followed are walked from their target, which chain must not hold
+	var walkFrom func(root, relRoot string, chain []string) filepath.WalkFunc
+	walkFrom = func(root, relRoot string, chain []string) filepath.WalkFunc {
+		return func(srcPath string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			inner, _ := filepath.Rel(root, srcPath)
+			relPath := filepath.Join(relRoot, inner)
+			if skip, skipDir := shouldSkipEntry(info, srcPath, relPath, targetRoot, absSourceDir, targetInsideSource, ignores); skip {
+				if skipDir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			copies, err := rename(relPath)
+			if err != nil {
+				return err
+			}
+			mode := info.Mode()
+			if mode&os.ModeSymlink != 0 {
+				if !FollowSymlinks {
+					for _, c := range copies {
+						dstPath, err := projectPath(targetRoot, c.rel)
+						if err != nil {
+							return err
+						}
+						if err := copySymlink(srcPath, dstPath); err != nil {
+							return err
+						}
+					}
+					return nil
+				}
+				target, err := fsys.Stat(srcPath)
+				if err != nil {
+					return i18n.Errorf("failed to follow symlink %s: %w", srcPath, err)
+				}
+				if target.IsDir() {
+					resolved, err := filepath.EvalSymlinks(srcPath)
+					if err != nil {
+						return i18n.Errorf("failed to follow symlink %s: %w", srcPath, err)
+					}
+					for _, dir := range chain {
+						if resolved == dir || strings.HasPrefix(dir, resolved+string(os.PathSeparator)) {
+							return i18n.Errorf("symlink %s points to a directory containing it", srcPath)
+						}
+					}
+					return fsys.Walk(resolved, walkFrom(resolved, relPath, append(chain[:len(chain):len(chain)], resolved)))
+				}
+				mode = target.Mode()
+			}
+			for _, c := range copies {
+				dstPath, err := projectPath(targetRoot, c.rel)
+				if err != nil {
+					return err
+				}
+				if info.IsDir() {
+					if err := ensureDir(dstPath, mode); err != nil {
+						return err
+					}
+					dirs = append(dirs, dstPath)
+					continue
+				}
+				if Workers <= 1 {
+					if err := copyFileWithReplacements(srcPath, dstPath, filepath.ToSlash(relPath), mode, c.vars, render); err != nil {
+						return err
+					}
+					continue
+				}
+				// Directories exist once the walk is done, so the files can be written in any order
+				jobs = append(jobs, copyJob{src: srcPath, dst: dstPath, rel: filepath.ToSlash(relPath), mode: mode, vars: c.vars})
 			}
 			return nil
 		}
-		dstPath := joinDest(targetRoot, sourceRoot, srcPath)
-		if info.IsDir() {
-			return ensureDir(dstPath, info.Mode())
+	}
+	root := sourceRoot
+	if resolved, err := filepath.EvalSymlinks(absSourceDir); err == nil {
+		root = resolved
+	}
+	if err := fsys.Walk(sourceRoot, walkFrom(sourceRoot, "", []string{root})); err != nil {
+		return err
+	}
+	if err := runCopyJobs(jobs, render, Workers); err != nil {
+		return err
+	}
+	return addGitkeeps(dirs)
+}
+
+// copySymlink re-creates the template link src at dst with the same target
+func copySymlink(src, dst string) error {
+	target, err := fsys.Readlink(src)
+	if err != nil {
+		return i18n.Errorf("failed to read symlink %s: %w", src, err)
+	}
+	if err := fsys.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := fsys.Symlink(target, dst); err != nil {
+		return i18n.Errorf("failed to create symlink %s: %w", dst, err)
+	}
+	return nil
+}
+
+// addGitkeeps writes a GitkeepFile into each of dirs that is still empty once every file is copied
+func addGitkeeps(dirs []string) error {
+	for _, dir := range dirs {
+		entries, err := fsys.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			if err := fsys.WriteFile(filepath.Join(dir, GitkeepFile), nil, 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// runCopyJobs copies the files with the given number of workers. Of several failures the one
+// for the file first in walk order is returned, so errors do not depend on scheduling.
+func runCopyJobs(jobs []copyJob, render renderFunc, workers int) error {
+	errs := make([]error, len(jobs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				j := jobs[i]
+				errs[i] = copyFileWithReplacements(j.src, j.dst, j.rel, j.mode, j.vars, render)
+			}
+		}()
+	}
+	for i := range jobs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
 		}
-		return copyFileWithReplacements(srcPath, dstPath, projectName, author, info.Mode(), extraVars)
 	}
-	return filepath.Walk(sourceRoot, walker)
+	return nil
 }
 
-func shouldSkipEntry(info os.FileInfo, srcPath, sourceRoot, targetRoot, absSourceDir string, targetInsideSource bool, ignores []string) (skip bool, skipDir bool) {
+func shouldSkipEntry(info os.FileInfo, srcPath, relPath, targetRoot, absSourceDir string, targetInsideSource bool, ignores []string) (skip bool, skipDir bool) {
 	if info.IsDir() && shouldSkipDir(info.Name()) {
 		return true, true
 	}
+	if !info.IsDir() && isJunkFile(info.Name()) {
+		return true, false
+	}
 	if targetInsideSource && isTargetOrChild(srcPath, absSourceDir, targetRoot) {
 		if info.IsDir() {
 			return true, true
 		}
 		return true, false
 	}
-	relPath, err := filepath.Rel(sourceRoot, srcPath)
-	if err != nil {
-		return false, false
-	}
 	if relPath == "." {
 		return true, false
 	}
-	if utils.MatchIgnore(filepath.ToSlash(relPath), ignores) {
+	// The manifest and keep file describe the template and are not part of the generated project
+	if relPath == manifest.FileName || relPath == KeepFile {
+		return true, false
+	}
+	if utils.MatchIgnore(filepath.ToSlash(relPath), ignores) || utils.GlobalIgnored(relPath, info.IsDir()) {
 		if info.IsDir() {
 			return true, true
 		}
@@ -176,13 +579,8 @@ func isTargetOrChild(srcPath, absSourceDir, targetRoot string) bool {
 	return relSrcFromSource == relTarget || strings.HasPrefix(relSrcFromSource+string(os.PathSeparator), relTarget+string(os.PathSeparator))
 }
 
-func joinDest(targetRoot, sourceRoot, srcPath string) string {
-	relPath, _ := filepath.Rel(sourceRoot, srcPath)
-	return filepath.Join(targetRoot, relPath)
-}
-
 func ensureDir(path string, mode os.FileMode) error {
-	return os.MkdirAll(path, mode)
+	return fsys.MkdirAll(path, mode)
 }
 
 func shouldSkipDir(name string) bool {
@@ -193,14 +591,45 @@ func shouldSkipDir(name string) bool {
 	return false
 }
 
-func copyFileWithReplacements(src, dst, projectName, author string, mode os.FileMode, extraVars map[string]string) error {
-	content, err := os.ReadFile(src)
+// SkipJunk leaves OS and editor junk files out of created projects, whatever the ignore files
+// say; keep_junk_files in the config turns it off
+var SkipJunk = true
+
+// junkFiles are the lower-case name patterns of files operating systems and editors leave behind
+var junkFiles = []string{".ds_store", "._*", "thumbs.db", "ehthumbs.db", "desktop.ini", "*.swp", "*.swo"}
+
+// isJunkFile reports whether the file name is OS or editor junk that SkipJunk leaves out
+func isJunkFile(name string) bool {
+	if !SkipJunk {
+		return false
+	}
+	name = strings.ToLower(name)
+	for _, pattern := range junkFiles {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func copyFileWithReplacements(src, dst, rel string, mode os.FileMode, vars map[string]string, render renderFunc) error {
+	content, err := fsys.ReadFile(src)
 	if err != nil {
-		return fmt.Errorf("failed to read %s: %w", src, err)
+		return i18n.Errorf("failed to read %s: %w", src, err)
 	}
-	if utils.IsBinary(content, 8000) { // use same default as cmd
-		return os.WriteFile(dst, content, mode)
+	if !utils.IsBinaryFile(rel, content) {
+		// UTF-16 files are rendered as UTF-8 and written back in their own encoding
+		text, enc := utils.DecodeText(content)
+		rendered, err := render(rel, text, vars)
+		if err != nil {
+			return err
+		}
+		content = utils.EncodeText(rendered, enc)
+	}
+	if err := fsys.WriteFile(dst, content, mode); err != nil {
+		return err
 	}
-	contentStr := utils.ReplacePlaceholders(string(content), projectName, author, extraVars)
-	return os.WriteFile(dst, []byte(contentStr), mode)
+	// WriteFile leaves existing files alone and is subject to the umask, which could drop the
+	// executable bits of scripts
+	return fsys.Chmod(dst, mode.Perm())
 }
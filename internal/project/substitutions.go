@@ -0,0 +1,77 @@
+package project
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+var (
+	// placeholderPattern matches a {{NAME}} or {{ENV.NAME}} placeholder; ${{ ... }} expressions do not match
+	placeholderPattern = regexp.MustCompile(`(^|[^$])\{\{((?:ENV\.)?[A-Za-z_][A-Za-z0-9_]*)\}\}`)
+	// actionPattern matches a Go template action and fieldPattern the .NAME fields inside it
+	actionPattern = regexp.MustCompile(`\{\{(.*?)\}\}`)
+	fieldPattern  = regexp.MustCompile(`(?:^|[^A-Za-z0-9_])\.((?:ENV\.)?[A-Za-z_][A-Za-z0-9_]*)`)
+	// handlebarsPattern matches a handlebars output tag, {{name}}, {{{name}}} or {{helper name}};
+	// escaped \{{ and ${{ ... }} do not match
+	handlebarsPattern = regexp.MustCompile(`(^|[^$\\])\{\{\{?~?&?\s*(?:[A-Za-z]+\s+)?((?:ENV\.)?[A-Za-z_][A-Za-z0-9_]*)\s*~?\}\}`)
+)
+
+// Substitution is a placeholder found in a template file and the value it receives
+type Substitution struct {
+	Name     string
+	Value    string
+	Count    int  // occurrences in the file's path and content
+	Resolved bool // false when no variable supplies a value
+}
+
+// FileSubstitutions lists the placeholders of one planned file, sorted by name
+type FileSubstitutions struct {
+	File          string
+	Substitutions []Substitution
+}
+
+// substitutionValues returns every value a placeholder can receive: the project name forms,
+// the author and the variables
+func substitutionValues(projectName, author string, vars map[string]string) map[string]string {
+	values := utils.ProjectNameVars(projectName)
+	values["AUTHOR"] = author
+	for k, v := range vars {
+		values[k] = v
+	}
+	return values
+}
+
+// findSubstitutions returns the placeholders in text with the values they receive. With the
+// go engine, the .NAME fields of template actions count too, and with handlebars the variables
+// of {{{name}}} and {{helper name}}. Unknown names are only reported when written in capitals,
+// since lower-case braces usually belong to other tools.
+func findSubstitutions(text, engine string, values map[string]string) []Substitution {
+	counts := map[string]int{}
+	pattern := placeholderPattern
+	if engine == utils.EngineHandlebars {
+		pattern = handlebarsPattern
+	}
+	for _, m := range pattern.FindAllStringSubmatch(text, -1) {
+		counts[m[2]]++
+	}
+	if engine == utils.EngineGo {
+		for _, action := range actionPattern.FindAllStringSubmatch(text, -1) {
+			for _, m := range fieldPattern.FindAllStringSubmatch(action[1], -1) {
+				counts[m[1]]++
+			}
+		}
+	}
+
+	var subs []Substitution
+	for name, count := range counts {
+		value, ok := values[name]
+		if !ok && !leftoverPattern.MatchString("{{"+name+"}}") {
+			continue
+		}
+		subs = append(subs, Substitution{Name: name, Value: value, Count: count, Resolved: ok})
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Name < subs[j].Name })
+	return subs
+}
@@ -0,0 +1,287 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/backup"
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/merge"
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// Action is what Sync did, or would do, with a rendered file
+type Action string
+
+const (
+	Created   Action = "created"   // not in the project yet, written
+	Updated   Action = "updated"   // unmodified since Foundry wrote it, replaced
+	Unchanged Action = "unchanged" // already identical, not rewritten
+	Merged    Action = "merged"    // changed in the project and the template, combined by a merge driver
+	Kept      Action = "kept"      // edited in the project while the template did not change it
+	Removed   Action = "removed"   // deleted from the project after Foundry wrote it, not recreated
+	Protected Action = "protected" // matches a protected glob, never touched
+	Conflict  Action = "conflict"  // changed both in the project and the template, left alone
+)
+
+// actionOrder is the order actions are listed in summaries
+var actionOrder = []Action{Created, Updated, Merged, Unchanged, Kept, Removed, Protected, Conflict}
+
+// SyncResult lists rendered files by what Sync did with them
+type SyncResult struct {
+	Files map[Action][]string
+
+	// Digests of the files as Foundry last wrote or confirmed them, to record in the stamp
+	Digests map[string]string
+}
+
+// Count returns the number of files that got action a
+func (r *SyncResult) Count(a Action) int {
+	return len(r.Files[a])
+}
+
+// Summary returns the non-zero counts, e.g. "2 created, 1 updated, 5 unchanged"
+func (r *SyncResult) Summary() string {
+	var parts []string
+	for _, a := range actionOrder {
+		if n := r.Count(a); n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, a))
+		}
+	}
+	if len(parts) == 0 {
+		return "no files"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Digests returns the SHA-256 of every regular file under dir, keyed by slash-separated
+// relative path. Foundry's own .foundry directory and .git are skipped.
+func Digests(dir string) (map[string]string, error) {
+	digests := map[string]string{}
+	err := fsys.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && (info.Name() == ".git" || info.Name() == stamp.Dir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		digest, err := utils.FileDigest(path)
+		if err != nil {
+			return err
+		}
+		digests[filepath.ToSlash(rel)] = digest
+		return nil
+	})
+	return digests, err
+}
+
+// SyncOptions controls how Sync applies a rendered template to a project
+type SyncOptions struct {
+	// Digests recorded when Foundry last wrote the project; they tell files the user edited
+	// apart from files Foundry may refresh. Without a record, differing files are conflicts.
+	Previous map[string]string
+
+	// Merge rules from the template manifest, picking a driver for files changed on both sides
+	Merge []manifest.MergeRule
+
+	// Globs of project paths Sync must never create, change or merge
+	Protected []string
+
+	DryRun bool            // report what would happen without writing anything
+	Backup *backup.Session // receives files before they are overwritten; nil keeps no backups
+}
+
+// Sync brings projectDir in line with a freshly rendered copy of its template in renderedDir.
+// Files changed both in the project and the template are merged when their driver can combine
+// them cleanly, with the project's merge base as the common ancestor; the rest are conflicts.
+func Sync(renderedDir, projectDir string, opts SyncOptions) (*SyncResult, error) {
+	if err := merge.Check(opts.Merge); err != nil {
+		return nil, err
+	}
+	previous := opts.Previous
+	rendered, err := Digests(renderedDir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(rendered))
+	for rel := range rendered {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+
+	result := &SyncResult{Files: map[Action][]string{}, Digests: map[string]string{}}
+	for _, rel := range paths {
+		digest := rendered[rel]
+		dst := filepath.Join(projectDir, filepath.FromSlash(rel))
+		recorded, wasWritten := previous[rel]
+
+		if isProtected(rel, opts.Protected) {
+			if current, err := utils.FileDigest(dst); err == nil && current == digest {
+				result.Files[Unchanged] = append(result.Files[Unchanged], rel)
+				result.Digests[rel] = digest
+				continue
+			}
+			result.Files[Protected] = append(result.Files[Protected], rel)
+			if wasWritten {
+				result.Digests[rel] = recorded
+			}
+			continue
+		}
+
+		var action Action
+		current, err := utils.FileDigest(dst)
+		switch {
+		case os.IsNotExist(err) && wasWritten:
+			action = Removed
+		case os.IsNotExist(err):
+			action = Created
+		case err != nil:
+			return result, err
+		case current == digest:
+			action = Unchanged
+		case wasWritten && current == recorded:
+			action = Updated
+		case wasWritten && recorded == digest:
+			action = Kept
+		default:
+			action = Conflict
+		}
+
+		if action == Conflict {
+			merged, ok, err := mergeFile(renderedDir, projectDir, rel, merge.DriverFor(rel, opts.Merge))
+			if err != nil {
+				return result, err
+			}
+			if ok {
+				action = Merged
+				if !opts.DryRun {
+					if err := writeMerged(projectDir, rel, merged, opts.Backup); err != nil {
+						return result, err
+					}
+				}
+			}
+		}
+		result.Files[action] = append(result.Files[action], rel)
+
+		switch action {
+		case Created, Updated, Merged, Unchanged:
+			result.Digests[rel] = digest
+		case Kept, Conflict, Removed:
+			// The record stays as it was, so the file is judged the same way next time
+			if wasWritten {
+				result.Digests[rel] = recorded
+			}
+		}
+
+		if opts.DryRun || (action != Created && action != Updated) {
+			continue
+		}
+		if err := Apply(renderedDir, projectDir, rel, opts.Backup); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// isProtected reports whether rel matches one of the protected globs
+func isProtected(rel string, globs []string) bool {
+	for _, g := range globs {
+		if utils.MatchGlob(g, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeFile runs the merge driver on the project's and the template's version of rel
+func mergeFile(renderedDir, projectDir, rel, driver string) ([]byte, bool, error) {
+	ours, err := fsys.ReadFile(filepath.Join(projectDir, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil, false, err
+	}
+	theirs, err := fsys.ReadFile(filepath.Join(renderedDir, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil, false, err
+	}
+	// Without a base the drivers can only add what is new on either side
+	base, err := fsys.ReadFile(filepath.Join(BaseDir(projectDir), filepath.FromSlash(rel)))
+	if err != nil {
+		base = nil
+	}
+	return merge.Merge(driver, base, ours, theirs)
+}
+
+// writeMerged replaces the project's copy of rel with merged content, saving it to bk first
+func writeMerged(projectDir, rel string, merged []byte, bk *backup.Session) error {
+	if err := bk.Save(rel); err != nil {
+		return err
+	}
+	dst := filepath.Join(projectDir, filepath.FromSlash(rel))
+	info, err := fsys.Stat(dst)
+	if err != nil {
+		return err
+	}
+	if err := fsys.WriteFile(dst, merged, info.Mode().Perm()); err != nil {
+		return i18n.Errorf("failed to write %s: %w", rel, err)
+	}
+	return nil
+}
+
+// Apply copies the rendered file rel over the project's copy, saving the latter to bk first
+func Apply(renderedDir, projectDir, rel string, bk *backup.Session) error {
+	if err := bk.Save(rel); err != nil {
+		return err
+	}
+	src := filepath.Join(renderedDir, filepath.FromSlash(rel))
+	dst := filepath.Join(projectDir, filepath.FromSlash(rel))
+	info, err := fsys.Stat(src)
+	if err != nil {
+		return err
+	}
+	if err := fsys.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return i18n.Errorf("failed to create directory for %s: %w", rel, err)
+	}
+	if err := copyFile(src, dst, info.Mode().Perm()); err != nil {
+		return i18n.Errorf("failed to write %s: %w", rel, err)
+	}
+	return nil
+}
+
+// Resolve moves a file out of the conflicts into action a, recording digest for it
+func (r *SyncResult) Resolve(rel string, a Action, digest string) {
+	conflicts := r.Files[Conflict]
+	for i, c := range conflicts {
+		if c == rel {
+			r.Files[Conflict] = append(conflicts[:i:i], conflicts[i+1:]...)
+			break
+		}
+	}
+	r.Files[a] = append(r.Files[a], rel)
+	r.Digests[rel] = digest
+}
+
+// copyFile copies src to dst with the given permissions
+func copyFile(src, dst string, mode os.FileMode) error {
+	data, err := fsys.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return fsys.WriteFile(dst, data, mode)
+}
@@ -0,0 +1,61 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// leftoverPattern matches a {{NAME}} placeholder that survived rendering. ${{ ... }} expressions,
+// such as GitHub Actions syntax, and lower-case or spaced braces used by other tools do not match.
+var leftoverPattern = regexp.MustCompile(`(^|[^$])\{\{((?:ENV\.)?[A-Z][A-Z0-9_]*)\}\}`)
+
+// Leftover is a placeholder left in a generated file, usually a typo or a variable the
+// template uses without declaring it
+type Leftover struct {
+	File string // slash-separated path relative to the project
+	Line int
+	Name string
+}
+
+// UnresolvedPlaceholders returns the {{NAME}} placeholders left in the text files of a newly
+// created project. Foundry's own files, .git and dependency directories are not searched, nor
+// are the files matching verbatim, which the template copies without replacing placeholders.
+func UnresolvedPlaceholders(dir string, verbatim []string) ([]Leftover, error) {
+	var leftovers []Leftover
+	err := fsys.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && (info.Name() == stamp.Dir || shouldSkipDir(info.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		content, err := fsys.ReadFile(path)
+		if err != nil || utils.IsBinaryFile(path, content) {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || utils.MatchIgnore(filepath.ToSlash(rel), verbatim) {
+			return err
+		}
+		text, _ := utils.DecodeText(content)
+		for i, line := range strings.Split(text, "\n") {
+			for _, m := range leftoverPattern.FindAllStringSubmatch(line, -1) {
+				leftovers = append(leftovers, Leftover{File: filepath.ToSlash(rel), Line: i + 1, Name: m[2]})
+			}
+		}
+		return nil
+	})
+	return leftovers, err
+}
@@ -0,0 +1,125 @@
+package sbom
+
+import (
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+type cdxDoc struct {
+	BOMFormat    string         `json:"bomFormat"`
+	SpecVersion  string         `json:"specVersion"`
+	SerialNumber string         `json:"serialNumber"`
+	Version      int            `json:"version"`
+	Metadata     cdxMetadata    `json:"metadata"`
+	Components   []cdxComponent `json:"components"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type               string        `json:"type"`
+	BOMRef             string        `json:"bom-ref,omitempty"`
+	Name               string        `json:"name"`
+	Version            string        `json:"version,omitempty"`
+	Description        string        `json:"description,omitempty"`
+	Hashes             []cdxHash     `json:"hashes,omitempty"`
+	ExternalReferences []cdxRef      `json:"externalReferences,omitempty"`
+	Properties         []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxRef struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// cycloneDXDocument lists the template, generator components, downloaded files and the files
+// Foundry wrote as components of the project
+func cycloneDXDocument(st *stamp.Stamp) cdxDoc {
+	doc := cdxDoc{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + documentID(st),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: created(st),
+			Tools: cdxTools{Components: []cdxComponent{{
+				Type:    "application",
+				Name:    "foundry",
+				Version: st.FoundryVersion,
+			}}},
+			Component: cdxComponent{Type: "application", BOMRef: "project", Name: st.ProjectName},
+		},
+	}
+
+	template := cdxComponent{
+		Type:        "data",
+		BOMRef:      "template",
+		Name:        templateName(st),
+		Version:     templateVersion(st),
+		Description: "Foundry template",
+	}
+	if location, kind := remoteSource(st); kind == "git" {
+		template.ExternalReferences = []cdxRef{{"vcs", location}}
+	} else if location != "" {
+		template.ExternalReferences = []cdxRef{{"distribution", location}}
+	}
+	if st.TemplateVersion != "" && st.TemplateCommit != "" {
+		template.Properties = append(template.Properties, cdxProperty{"foundry:template_version", st.TemplateVersion})
+	}
+	if st.Bundle != "" {
+		template.Properties = append(template.Properties, cdxProperty{"foundry:bundle", st.Bundle})
+	}
+	doc.Components = append(doc.Components, template)
+
+	for _, name := range st.Components {
+		doc.Components = append(doc.Components, cdxComponent{
+			Type:        "application",
+			BOMRef:      "component:" + name,
+			Name:        name,
+			Version:     st.FoundryVersion,
+			Description: "Foundry generator",
+		})
+	}
+
+	for _, o := range st.Origins {
+		c := cdxComponent{
+			Type:               "file",
+			BOMRef:             "origin:" + o.Path,
+			Name:               o.Path,
+			Description:        "Third-party file downloaded by Foundry",
+			ExternalReferences: []cdxRef{{"distribution", o.URL}},
+		}
+		if o.Digest != "" {
+			c.Hashes = []cdxHash{{"SHA-256", o.Digest}}
+		}
+		doc.Components = append(doc.Components, c)
+	}
+
+	for _, path := range utils.SortedKeys(st.Files) {
+		doc.Components = append(doc.Components, cdxComponent{
+			Type:   "file",
+			BOMRef: "file:" + path,
+			Name:   path,
+			Hashes: []cdxHash{{"SHA-256", st.Files[path]}},
+		})
+	}
+	return doc
+}
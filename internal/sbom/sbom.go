@@ -0,0 +1,135 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/source"
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// Supported document formats
+const (
+	FormatSPDX      = "spdx"      // SPDX 2.3 JSON, limited to packages, files and relationships
+	FormatCycloneDX = "cyclonedx" // CycloneDX 1.5 JSON
+)
+
+// Formats lists the valid --sbom and --format values
+var Formats = []string{FormatSPDX, FormatCycloneDX}
+
+// FileName returns the name the document is kept under in the project's stamp directory
+func FileName(format string) string {
+	if format == FormatCycloneDX {
+		return "sbom.cdx.json"
+	}
+	return "sbom.spdx.json"
+}
+
+// Path returns where the project keeps its document of the given format
+func Path(projectDir, format string) string {
+	return filepath.Join(projectDir, stamp.Dir, FileName(format))
+}
+
+// Generate describes what Foundry generated for the stamped project: the template it came from,
+// the components Foundry's generators wrote, downloaded third-party files and the digest of
+// every file as Foundry wrote it
+func Generate(st *stamp.Stamp, format string) ([]byte, error) {
+	var doc any
+	switch format {
+	case FormatSPDX:
+		doc = spdxDocument(st)
+	case FormatCycloneDX:
+		doc = cycloneDXDocument(st)
+	default:
+		return nil, i18n.Errorf("unknown SBOM format '%s' (use %s)", format, strings.Join(Formats, ", "))
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Write refreshes the document the stamp asks for; it does nothing when the project has none
+func Write(projectDir string, st *stamp.Stamp) error {
+	if st == nil || st.SBOM == "" {
+		return nil
+	}
+	data, err := Generate(st, st.SBOM)
+	if err != nil {
+		return err
+	}
+	if err := fsys.MkdirAll(filepath.Join(projectDir, stamp.Dir), 0755); err != nil {
+		return i18n.Errorf("cannot create %s directory: %w", stamp.Dir, err)
+	}
+	return fsys.WriteFile(Path(projectDir, st.SBOM), data, 0644)
+}
+
+// created returns when the project was generated; reproducible stamps carry only the pinned date
+func created(st *stamp.Stamp) string {
+	if st.CreatedAt != "" {
+		return st.CreatedAt
+	}
+	if st.Reproducible != nil {
+		if t, err := time.Parse("2006-01-02", st.Reproducible.Date); err == nil {
+			return t.Format(time.RFC3339)
+		}
+	}
+	return time.Unix(0, 0).UTC().Format(time.RFC3339)
+}
+
+// documentID derives a stable UUID from the stamp, so regenerating the document of an unchanged
+// project yields the same identifiers
+func documentID(st *stamp.Stamp) string {
+	seed := strings.Join([]string{st.ProjectName, st.Template, st.Bundle, st.Source, created(st)}, "\x00")
+	b := []byte(utils.Digest([]byte(seed))[:32])
+	b[12] = '5' // name-based version
+	b[16] = "89ab"[b[16]%4]
+	return fmt.Sprintf("%s-%s-%s-%s-%s", b[0:8], b[8:12], b[12:16], b[16:20], b[20:32])
+}
+
+// templateName names the template the project came from, falling back to its source
+func templateName(st *stamp.Stamp) string {
+	switch {
+	case st.Template != "":
+		return st.Template
+	case st.Source != "":
+		return st.Source
+	}
+	return "unknown"
+}
+
+// templateVersion prefers the template's commit over its manifest version, as the more exact pin
+func templateVersion(st *stamp.Stamp) string {
+	if st.TemplateCommit != "" {
+		return st.TemplateCommit
+	}
+	return st.TemplateVersion
+}
+
+// remoteSource returns the template's source when it is a location others can fetch it from,
+// along with its kind; local directories are left out of the document
+func remoteSource(st *stamp.Stamp) (location, kind string) {
+	if st.Source == "" {
+		return "", ""
+	}
+	src, err := source.Parse(st.Source)
+	if err != nil || !source.IsRemote(src) {
+		return "", ""
+	}
+	return st.Source, src.Kind()
+}
+
+// toolName is how documents name Foundry as their creator
+func toolName(st *stamp.Stamp) string {
+	if st.FoundryVersion == "" {
+		return "foundry"
+	}
+	return "foundry-" + st.FoundryVersion
+}
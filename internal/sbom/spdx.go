@@ -0,0 +1,144 @@
+package sbom
+
+import (
+	"fmt"
+
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// noAssertion is SPDX's value for information the document does not provide
+const noAssertion = "NOASSERTION"
+
+type spdxDoc struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Files             []spdxFile         `json:"files,omitempty"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string `json:"name"`
+	SPDXID           string `json:"SPDXID"`
+	VersionInfo      string `json:"versionInfo,omitempty"`
+	DownloadLocation string `json:"downloadLocation"`
+	FilesAnalyzed    bool   `json:"filesAnalyzed"`
+	Supplier         string `json:"supplier,omitempty"`
+	Comment          string `json:"comment,omitempty"`
+}
+
+type spdxFile struct {
+	FileName  string         `json:"fileName"`
+	SPDXID    string         `json:"SPDXID"`
+	Checksums []spdxChecksum `json:"checksums"`
+	Comment   string         `json:"comment,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxRelationship struct {
+	Element string `json:"spdxElementId"`
+	Type    string `json:"relationshipType"`
+	Related string `json:"relatedSpdxElement"`
+}
+
+// spdxDocument describes the project as a package generated from the template package, with
+// one package per generator component and downloaded file, and the files Foundry wrote
+func spdxDocument(st *stamp.Stamp) spdxDoc {
+	doc := spdxDoc{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              st.ProjectName,
+		DocumentNamespace: "https://spdx.org/spdxdocs/" + st.ProjectName + "-" + documentID(st),
+		CreationInfo: spdxCreationInfo{
+			Created:  created(st),
+			Creators: []string{"Tool: " + toolName(st)},
+		},
+	}
+	relate := func(element, kind, related string) {
+		doc.Relationships = append(doc.Relationships, spdxRelationship{element, kind, related})
+	}
+
+	doc.Packages = append(doc.Packages, spdxPackage{
+		Name:             st.ProjectName,
+		SPDXID:           "SPDXRef-Project",
+		DownloadLocation: noAssertion,
+		FilesAnalyzed:    false,
+	})
+	relate("SPDXRef-DOCUMENT", "DESCRIBES", "SPDXRef-Project")
+
+	template := spdxPackage{
+		Name:             templateName(st),
+		SPDXID:           "SPDXRef-Template",
+		VersionInfo:      templateVersion(st),
+		DownloadLocation: noAssertion,
+		Comment:          "Foundry template",
+	}
+	if location, _ := remoteSource(st); location != "" {
+		template.DownloadLocation = location
+	}
+	if st.Bundle != "" {
+		template.Comment = "Foundry template from bundle " + st.Bundle
+	}
+	doc.Packages = append(doc.Packages, template)
+	relate("SPDXRef-Project", "GENERATED_FROM", "SPDXRef-Template")
+
+	for i, name := range st.Components {
+		id := fmt.Sprintf("SPDXRef-Component-%d", i+1)
+		doc.Packages = append(doc.Packages, spdxPackage{
+			Name:             name,
+			SPDXID:           id,
+			VersionInfo:      st.FoundryVersion,
+			DownloadLocation: noAssertion,
+			Supplier:         "Tool: foundry",
+			Comment:          "Foundry generator",
+		})
+		relate("SPDXRef-Project", "GENERATED_FROM", id)
+	}
+
+	for i, o := range st.Origins {
+		id := fmt.Sprintf("SPDXRef-Origin-%d", i+1)
+		doc.Packages = append(doc.Packages, spdxPackage{
+			Name:             o.Path,
+			SPDXID:           id,
+			DownloadLocation: o.URL,
+			Comment:          "Third-party file downloaded by Foundry",
+		})
+		relate("SPDXRef-Project", "CONTAINS", id)
+		if o.Digest != "" {
+			fileID := fmt.Sprintf("SPDXRef-OriginFile-%d", i+1)
+			doc.Files = append(doc.Files, spdxFile{
+				FileName:  "./" + o.Path,
+				SPDXID:    fileID,
+				Checksums: []spdxChecksum{{"SHA256", o.Digest}},
+				Comment:   "As downloaded from " + o.URL,
+			})
+			relate(fileID, "GENERATED_FROM", id)
+		}
+	}
+
+	for i, path := range utils.SortedKeys(st.Files) {
+		id := fmt.Sprintf("SPDXRef-File-%d", i+1)
+		doc.Files = append(doc.Files, spdxFile{
+			FileName:  "./" + path,
+			SPDXID:    id,
+			Checksums: []spdxChecksum{{"SHA256", st.Files[path]}},
+		})
+		relate("SPDXRef-Project", "CONTAINS", id)
+	}
+	return doc
+}
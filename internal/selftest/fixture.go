@@ -0,0 +1,41 @@
+package selftest
+
+// fixture is the template the self-test scaffolds from; it uses built-in placeholders,
+// a manifest variable and a version so update has something to compare
+var fixture = map[string]string{
+	"go.mod": "module {{PROJECT_NAME}}\n\ngo 1.22\n",
+	"main.go": `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("{{GREETING}} from {{PROJECT_NAME}}")
+}
+`,
+	"README.md": "# {{PROJECT_NAME}}\n\nCreated by {{AUTHOR}}.\n",
+	"foundry.yaml": `name: selftest
+description: Fixture template for foundry selftest
+version: 1.0.0
+variables:
+  - name: GREETING
+    prompt: Greeting
+    default: Hello
+`,
+}
+
+// fixtureUpdate is the next version of the fixture: one changed file, one new file
+var fixtureUpdate = map[string]string{
+	"README.md": "# {{PROJECT_NAME}}\n\nCreated by {{AUTHOR}}.\n\nSee NOTES.md.\n",
+	"NOTES.md":  "Notes for {{PROJECT_NAME}}\n",
+	"foundry.yaml": `name: selftest
+description: Fixture template for foundry selftest
+version: 1.1.0
+changelog:
+  - version: 1.1.0
+    changes: ["Add NOTES.md"]
+variables:
+  - name: GREETING
+    prompt: Greeting
+    default: Hello
+`,
+}
@@ -0,0 +1,128 @@
+// Package selftest runs Foundry's main flows end to end: adding a template, creating projects
+// with variables, dry runs and updates. It drives a foundry binary the way a user would, against
+// a fixture template in a temporary directory with its own config, so it doubles as a smoke test
+// for packagers and as executable documentation of the flows.
+package selftest
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// Harness runs the steps in a temporary directory holding the config, the fixture template
+// and the generated projects
+type Harness struct {
+	Binary string // foundry binary under test
+	Dir    string // temporary root directory
+	env    []string
+}
+
+// Result is the outcome of one step
+type Result struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// New prepares a harness for binary in a fresh temporary directory
+func New(binary string) (*Harness, error) {
+	dir, err := os.MkdirTemp("", "foundry-selftest-*")
+	if err != nil {
+		return nil, i18n.Errorf("failed to create temporary directory: %w", err)
+	}
+	h := &Harness{Binary: binary, Dir: dir}
+	for _, sub := range []string{"home", "work"} {
+		if err := os.MkdirAll(h.path(sub), 0755); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+	}
+	if err := writeFiles(h.path("template"), fixture); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	// The run must not see the user's config, org config, locale or terminal
+	for _, e := range os.Environ() {
+		name, _, _ := strings.Cut(e, "=")
+		if strings.HasPrefix(name, "FOUNDRY_") || name == "HOME" || name == "USERPROFILE" || strings.HasPrefix(name, "LC_") || name == "LANG" {
+			continue
+		}
+		h.env = append(h.env, e)
+	}
+	h.env = append(h.env, "HOME="+h.path("home"), "USERPROFILE="+h.path("home"), "LANG=C", "NO_COLOR=1", "FOUNDRY_NON_INTERACTIVE=1")
+	return h, nil
+}
+
+// Close removes the harness directory
+func (h *Harness) Close() error {
+	return os.RemoveAll(h.Dir)
+}
+
+// Run runs the steps in order and reports each result to report as it finishes.
+// Steps build on each other, so the run stops at the first failure.
+func (h *Harness) Run(report func(Result)) []Result {
+	var results []Result
+	for _, s := range steps {
+		start := time.Now()
+		err := s.run(h)
+		r := Result{Name: s.name, Err: err, Duration: time.Since(start)}
+		results = append(results, r)
+		if report != nil {
+			report(r)
+		}
+		if err != nil {
+			break
+		}
+	}
+	return results
+}
+
+// Steps returns the names of the steps, in the order they run
+func Steps() []string {
+	names := make([]string, len(steps))
+	for i, s := range steps {
+		names[i] = s.name
+	}
+	return names
+}
+
+// foundry runs the binary with the harness config and returns its stdout.
+// A failing command returns an error holding its stderr.
+func (h *Harness) foundry(args ...string) (string, error) {
+	args = append([]string{"--config", h.path("config.yaml")}, args...)
+	cmd := exec.Command(h.Binary, args...)
+	cmd.Dir = h.path("work")
+	cmd.Env = h.env
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), i18n.Errorf("foundry %s: %v\n%s", strings.Join(args[2:], " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// path returns a path inside the harness directory
+func (h *Harness) path(elem ...string) string {
+	return filepath.Join(append([]string{h.Dir}, elem...)...)
+}
+
+// writeFiles writes files, keyed by slash-separated path, under dir
+func writeFiles(dir string, files map[string]string) error {
+	for rel, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package selftest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/stamp"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// author is the author name the steps configure and expect in generated files
+const author = "Foundry Selftest"
+
+// step is one flow of the self-test
+type step struct {
+	name string
+	run  func(h *Harness) error
+}
+
+// steps are the flows exercised, in order; later steps use what earlier ones created
+var steps = []step{
+	{"config: set the author", stepConfig},
+	{"template add: save the fixture template", stepTemplateAdd},
+	{"new --dry-run: preview without writing", stepDryRun},
+	{"new --var: create a project", stepNew},
+	{"new --quiet: print only the project path", stepQuiet},
+	{"update: apply a new template version", stepUpdate},
+}
+
+func stepConfig(h *Harness) error {
+	if _, err := h.foundry("config", "--user", author); err != nil {
+		return err
+	}
+	out, err := h.foundry("config", "--view")
+	if err != nil {
+		return err
+	}
+	return expectContains("config --view", out, author)
+}
+
+func stepTemplateAdd(h *Harness) error {
+	if _, err := h.foundry("template", "add", "fixture", h.path("template"), "--description", "selftest fixture"); err != nil {
+		return err
+	}
+	out, err := h.foundry("template", "list", "--quiet")
+	if err != nil {
+		return err
+	}
+	return expectContains("template list", out, "fixture")
+}
+
+func stepDryRun(h *Harness) error {
+	if _, err := h.foundry("new", "preview", "--template", "fixture", "--dry-run", "--no-git", "--no-post"); err != nil {
+		return err
+	}
+	if _, err := os.Stat(h.path("work", "preview")); err == nil {
+		return i18n.Errorf("--dry-run created %s", h.path("work", "preview"))
+	}
+	return nil
+}
+
+func stepNew(h *Harness) error {
+	if _, err := h.foundry("new", "demo", "--template", "fixture", "--var", "GREETING=Howdy", "--no-git", "--no-post"); err != nil {
+		return err
+	}
+	checks := map[string]string{
+		"go.mod":    "module demo",
+		"main.go":   `"Howdy from demo"`,
+		"README.md": "Created by " + author + ".",
+	}
+	for _, rel := range utils.SortedKeys(checks) {
+		if err := expectFile(h.path("work", "demo", rel), checks[rel]); err != nil {
+			return err
+		}
+	}
+	if _, err := os.Stat(h.path("work", "demo", "foundry.yaml")); err == nil {
+		return i18n.Errorf("the manifest foundry.yaml was copied into the project")
+	}
+	return expectFile(stamp.Path(h.path("work", "demo")), "template_version: 1.0.0")
+}
+
+func stepQuiet(h *Harness) error {
+	out, err := h.foundry("new", "quiet", "--template", "fixture", "--no-git", "--no-post", "--quiet")
+	if err != nil {
+		return err
+	}
+	want := h.path("work", "quiet")
+	if got := strings.TrimSpace(out); !samePath(got, want) {
+		return i18n.Errorf("new --quiet printed %q, expected only %q", out, want)
+	}
+	return expectFile(filepath.Join(want, "main.go"), `"Hello from quiet"`)
+}
+
+func stepUpdate(h *Harness) error {
+	// A local edit that update must keep
+	mainGo := h.path("work", "demo", "main.go")
+	data, err := os.ReadFile(mainGo)
+	if err != nil {
+		return err
+	}
+	local := "\n// local change kept by update\n"
+	if err := os.WriteFile(mainGo, append(data, local...), 0644); err != nil {
+		return err
+	}
+
+	if err := writeFiles(h.path("template"), fixtureUpdate); err != nil {
+		return err
+	}
+	if _, err := h.foundry("template", "update", "fixture"); err != nil {
+		return err
+	}
+	if _, err := h.foundry("update", "--path", "demo"); err != nil {
+		return err
+	}
+
+	checks := map[string]string{
+		"NOTES.md":  "Notes for demo",
+		"README.md": "See NOTES.md.",
+		"main.go":   strings.TrimSpace(local),
+	}
+	for _, rel := range utils.SortedKeys(checks) {
+		if err := expectFile(h.path("work", "demo", rel), checks[rel]); err != nil {
+			return err
+		}
+	}
+	return expectFile(stamp.Path(h.path("work", "demo")), "template_version: 1.1.0")
+}
+
+// expectFile checks that the file at path contains want
+func expectFile(path, want string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return expectContains(filepath.Base(path), string(data), want)
+}
+
+// expectContains checks that the output of what contains want
+func expectContains(what, got, want string) error {
+	if !strings.Contains(got, want) {
+		return i18n.Errorf("%s does not contain %q:\n%s", what, want, got)
+	}
+	return nil
+}
+
+// samePath reports whether two paths name the same file, resolving symlinks such as macOS's /var
+func samePath(a, b string) bool {
+	if ra, err := filepath.EvalSymlinks(a); err == nil {
+		a = ra
+	}
+	if rb, err := filepath.EvalSymlinks(b); err == nil {
+		b = rb
+	}
+	return a == b
+}
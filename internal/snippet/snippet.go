@@ -0,0 +1,179 @@
+package snippet
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+// dirName is the directory next to the user config holding one YAML file per snippet
+const dirName = "snippets"
+
+// Snippet is a small piece of reusable text, such as a license header or a Makefile target,
+// inserted into existing files with its {{PLACEHOLDERS}} filled in
+type Snippet struct {
+	Name        string `yaml:"-"`
+	Description string `yaml:"description,omitempty"`
+	Content     string `yaml:"content"`
+}
+
+// placeholderPattern matches {{NAME}} placeholders, the syntax templates use
+var placeholderPattern = regexp.MustCompile(`\{\{([A-Za-z0-9_]+)\}\}`)
+
+// Dir returns where snippets are stored
+func Dir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, dirName), nil
+}
+
+// ValidateName checks that name can be used as a snippet file name
+func ValidateName(name string) error {
+	if name == "" {
+		return i18n.Errorf("snippet name cannot be empty")
+	}
+	if strings.ContainsAny(name, `/\:*?"<>|`) || strings.HasPrefix(name, ".") {
+		return i18n.Errorf("snippet name contains invalid characters")
+	}
+	return nil
+}
+
+// path returns the file of the named snippet
+func path(name string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".yaml"), nil
+}
+
+// Exists reports whether a snippet with the name is saved
+func Exists(name string) bool {
+	p, err := path(name)
+	if err != nil {
+		return false
+	}
+	_, err = fsys.Stat(p)
+	return err == nil
+}
+
+// Save writes s to the snippet directory, replacing a snippet with the same name
+func Save(s *Snippet) error {
+	if err := ValidateName(s.Name); err != nil {
+		return err
+	}
+	p, err := path(s.Name)
+	if err != nil {
+		return err
+	}
+	if err := fsys.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return i18n.Errorf("cannot create snippet directory: %w", err)
+	}
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return fsys.WriteFile(p, data, 0644)
+}
+
+// Load reads the named snippet
+func Load(name string) (*Snippet, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	p, err := path(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := fsys.ReadFile(p)
+	if os.IsNotExist(err) {
+		return nil, i18n.Errorf("snippet '%s' not found", name)
+	} else if err != nil {
+		return nil, err
+	}
+	s := &Snippet{Name: name}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, i18n.Errorf("failed to parse snippet %s: %w", name, err)
+	}
+	return s, nil
+}
+
+// List returns all saved snippets, sorted by name
+func List() ([]*Snippet, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := fsys.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var snippets []*Snippet
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".yaml")
+		if e.IsDir() || !ok {
+			continue
+		}
+		s, err := Load(name)
+		if err != nil {
+			return nil, err
+		}
+		snippets = append(snippets, s)
+	}
+	sort.Slice(snippets, func(i, j int) bool { return snippets[i].Name < snippets[j].Name })
+	return snippets, nil
+}
+
+// Placeholders returns the names of the {{PLACEHOLDERS}} in the snippet, in order of first use
+func (s *Snippet) Placeholders() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range placeholderPattern.FindAllStringSubmatch(s.Content, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// Insert returns content with text inserted on its own lines: after the first line containing
+// marker, or before it when before is set. Without a marker the text is appended.
+func Insert(content, text, marker string, before bool) (string, error) {
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if marker == "" {
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		return content + text, nil
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, marker) {
+			continue
+		}
+		if before {
+			return strings.Join(lines[:i], "") + text + strings.Join(lines[i:], ""), nil
+		}
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		return strings.Join(lines[:i], "") + line + text + strings.Join(lines[i+1:], ""), nil
+	}
+	return "", i18n.Errorf("marker %q not found", marker)
+}
@@ -0,0 +1,234 @@
+package source
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// archiveSuffixes lists the archive formats that can hold a template
+var archiveSuffixes = []string{".tar.gz", ".tgz", ".tar", ".zip"}
+
+// archive is a template packed in a tarball or zip file, on disk or behind an http(s) URL
+type archive struct {
+	location string
+}
+
+func newArchive(location string) Source {
+	if archiveFormat(location) == "" {
+		return nil
+	}
+	if !hasScheme(location, "http", "https") {
+		if info, err := os.Stat(location); err != nil || info.IsDir() {
+			return nil
+		}
+	}
+	return &archive{location: location}
+}
+
+func (a *archive) Kind() string     { return "archive" }
+func (a *archive) Location() string { return a.location }
+
+// Fetch downloads the archive if needed and extracts it into a temporary directory
+func (a *archive) Fetch(opts Options) (string, func(), error) {
+	tmpDir, err := os.MkdirTemp("", "foundry-archive-")
+	if err != nil {
+		return "", noCleanup, i18n.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	file := a.location
+	if hasScheme(a.location, "http", "https") {
+		file = filepath.Join(tmpDir, "download"+archiveFormat(a.location))
+		if err := download(a.location, file); err != nil {
+			cleanup()
+			return "", noCleanup, err
+		}
+	}
+
+	if err := verifyChecksum(file, opts.SHA256); err != nil {
+		cleanup()
+		return "", noCleanup, err
+	}
+
+	dir, err := Extract(file, filepath.Join(tmpDir, "template"))
+	if err != nil {
+		cleanup()
+		return "", noCleanup, err
+	}
+	return dir, cleanup, nil
+}
+
+// archiveFormat returns the archive suffix of name, or "" if it is not an archive
+func archiveFormat(name string) string {
+	lower := strings.ToLower(name)
+	if i := strings.IndexAny(lower, "?#"); i >= 0 && hasScheme(lower, "http", "https") {
+		lower = lower[:i]
+	}
+	for _, suffix := range archiveSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return suffix
+		}
+	}
+	return ""
+}
+
+// download saves the body of url into file
+func download(url, file string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return i18n.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return i18n.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	out, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		return i18n.Errorf("failed to download %s: %w", url, err)
+	}
+	return out.Close()
+}
+
+// Extract unpacks a tar, tar.gz or zip file into dest. When the archive holds a single
+// top-level directory, as release tarballs usually do, that directory is returned.
+func Extract(file, dest string) (string, error) {
+	var err error
+	switch archiveFormat(file) {
+	case ".zip":
+		err = extractZip(file, dest)
+	case ".tar.gz", ".tgz":
+		err = extractTar(file, dest, true)
+	case ".tar":
+		err = extractTar(file, dest, false)
+	default:
+		err = i18n.Errorf("unsupported archive format: %s", file)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	entries, err := os.ReadDir(dest)
+	if err == nil && len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(dest, entries[0].Name()), nil
+	}
+	return dest, nil
+}
+
+// extractTar unpacks regular files and directories from a (gzipped) tarball
+func extractTar(file, dest string, gzipped bool) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if gzipped {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return i18n.Errorf("failed to read %s: %w", file, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return i18n.Errorf("failed to read %s: %w", file, err)
+		}
+		target, err := safeJoin(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeEntry(target, tr, os.FileMode(hdr.Mode).Perm()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractZip unpacks regular files and directories from a zip file
+func extractZip(file, dest string) error {
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return i18n.Errorf("failed to read %s: %w", file, err)
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		target, err := safeJoin(dest, zf.Name)
+		if err != nil {
+			return err
+		}
+		if zf.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		err = writeEntry(target, rc, zf.Mode().Perm())
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// safeJoin joins an archive entry name onto dest, refusing entries that escape it
+func safeJoin(dest, name string) (string, error) {
+	target := filepath.Join(dest, name)
+	if target != dest && !strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
+		return "", i18n.Errorf("archive entry escapes destination: %s", name)
+	}
+	return target, nil
+}
+
+// writeEntry writes the contents of an archive entry to target
+func writeEntry(target string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if mode == 0 {
+		mode = 0644
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
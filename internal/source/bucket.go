@@ -0,0 +1,125 @@
+package source
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// bucket is a template in cloud object storage: s3://, gs:// or az://account/container/path.
+// Objects are downloaded with the provider's CLI, so its ambient credentials apply.
+type bucket struct {
+	location string
+	scheme   string
+}
+
+func newBucket(location string) Source {
+	for _, scheme := range []string{"s3", "gs", "az"} {
+		if hasScheme(location, scheme) {
+			return &bucket{location: location, scheme: scheme}
+		}
+	}
+	return nil
+}
+
+func (b *bucket) Kind() string     { return "bucket" }
+func (b *bucket) Location() string { return b.location }
+
+// Fetch downloads an archive object and extracts it, or downloads every object under a prefix
+func (b *bucket) Fetch(opts Options) (string, func(), error) {
+	tmpDir, err := os.MkdirTemp("", "foundry-bucket-")
+	if err != nil {
+		return "", noCleanup, i18n.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	dest := filepath.Join(tmpDir, "template")
+	isArchive := archiveFormat(b.location) != ""
+	if opts.SHA256 != "" && !isArchive {
+		cleanup()
+		return "", noCleanup, i18n.Errorf("checksums can only pin archive objects, not the prefix %s", b.location)
+	}
+	if isArchive {
+		dest = filepath.Join(tmpDir, "download"+archiveFormat(b.location))
+	}
+
+	args, err := b.downloadCommand(dest, isArchive)
+	if err != nil {
+		cleanup()
+		return "", noCleanup, err
+	}
+	if _, err := exec.LookPath(args[0]); err != nil {
+		cleanup()
+		return "", noCleanup, i18n.Errorf("%s sources need the %s CLI on PATH", b.scheme, args[0])
+	}
+	if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+		cleanup()
+		return "", noCleanup, i18n.Errorf("failed to download %s: %v: %s", b.location, err, strings.TrimSpace(string(out)))
+	}
+
+	if !isArchive {
+		// Azure batch downloads keep the blob prefix as directories below dest
+		if b.scheme == "az" {
+			if parts := strings.SplitN(strings.TrimPrefix(b.location, "az://"), "/", 3); len(parts) == 3 && parts[2] != "" {
+				dest = filepath.Join(dest, filepath.FromSlash(strings.TrimRight(parts[2], "/")))
+			}
+		}
+		return dest, cleanup, nil
+	}
+	if err := verifyChecksum(dest, opts.SHA256); err != nil {
+		cleanup()
+		return "", noCleanup, err
+	}
+	dir, err := Extract(dest, filepath.Join(tmpDir, "template"))
+	if err != nil {
+		cleanup()
+		return "", noCleanup, err
+	}
+	return dir, cleanup, nil
+}
+
+// downloadCommand returns the CLI invocation that copies the object (or prefix) to dest
+func (b *bucket) downloadCommand(dest string, single bool) ([]string, error) {
+	switch b.scheme {
+	case "s3":
+		if single {
+			return []string{"aws", "s3", "cp", "--only-show-errors", b.location, dest}, nil
+		}
+		return []string{"aws", "s3", "cp", "--only-show-errors", "--recursive", b.location, dest}, nil
+	case "gs":
+		if single {
+			return []string{"gcloud", "storage", "cp", b.location, dest}, nil
+		}
+		if err := os.MkdirAll(dest, 0755); err != nil {
+			return nil, err
+		}
+		return []string{"gcloud", "storage", "cp", "--recursive", strings.TrimRight(b.location, "/") + "/*", dest}, nil
+	case "az":
+		// az://account/container/path/to/blob
+		parts := strings.SplitN(strings.TrimPrefix(b.location, "az://"), "/", 3)
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return nil, i18n.Errorf("invalid Azure location %s, expected az://account/container/path", b.location)
+		}
+		account, container, name := parts[0], parts[1], ""
+		if len(parts) == 3 {
+			name = parts[2]
+		}
+		if single {
+			return []string{"az", "storage", "blob", "download", "--auth-mode", "login", "--only-show-errors",
+				"--account-name", account, "--container-name", container, "--name", name, "--file", dest}, nil
+		}
+		if err := os.MkdirAll(dest, 0755); err != nil {
+			return nil, err
+		}
+		args := []string{"az", "storage", "blob", "download-batch", "--auth-mode", "login", "--only-show-errors",
+			"--account-name", account, "--source", container, "--destination", dest}
+		if name != "" {
+			args = append(args, "--pattern", strings.TrimRight(name, "/")+"/*")
+		}
+		return args, nil
+	}
+	return nil, i18n.Errorf("unsupported bucket scheme: %s", b.scheme)
+}
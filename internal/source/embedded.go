@@ -0,0 +1,71 @@
+package source
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// embeddedPrefix marks a template compiled into the binary
+const embeddedPrefix = "embedded:"
+
+// embeddedTemplates holds the templates registered with RegisterEmbedded
+var embeddedTemplates = map[string]fs.FS{}
+
+// RegisterEmbedded makes a file system (usually an embed.FS) available as embedded:<name>
+func RegisterEmbedded(name string, fsys fs.FS) {
+	embeddedTemplates[name] = fsys
+}
+
+// embedded is a template compiled into the binary
+type embedded struct {
+	name string
+}
+
+func newEmbedded(location string) Source {
+	name, ok := strings.CutPrefix(location, embeddedPrefix)
+	if !ok || name == "" {
+		return nil
+	}
+	return &embedded{name: name}
+}
+
+func (e *embedded) Kind() string     { return "embedded" }
+func (e *embedded) Location() string { return embeddedPrefix + e.name }
+
+// Fetch writes the embedded files to a temporary directory
+func (e *embedded) Fetch(opts Options) (string, func(), error) {
+	fsys, ok := embeddedTemplates[e.name]
+	if !ok {
+		return "", noCleanup, i18n.Errorf("no embedded template named '%s'", e.name)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "foundry-embedded-")
+	if err != nil {
+		return "", noCleanup, i18n.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	err = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(tmpDir, filepath.FromSlash(path))
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+	if err != nil {
+		cleanup()
+		return "", noCleanup, i18n.Errorf("failed to unpack embedded template '%s': %w", e.name, err)
+	}
+	return tmpDir, cleanup, nil
+}
@@ -0,0 +1,171 @@
+package source
+
+import (
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/gitcache"
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// git is a template in a Git repository
+type git struct {
+	url string
+}
+
+// Git returns a git source for url without guessing, for callers that know the location is a repository
+func Git(url string) Source {
+	return &git{url: strings.TrimPrefix(url, "git+")}
+}
+
+func newGit(location string) Source {
+	switch {
+	case strings.HasPrefix(location, "git+"):
+		return &git{url: strings.TrimPrefix(location, "git+")}
+	case strings.HasPrefix(location, "git@"), hasScheme(location, "git", "ssh", "file"):
+		return &git{url: location}
+	case hasScheme(location, "http", "https"):
+		return &git{url: location}
+	case strings.HasSuffix(location, ".git"):
+		return &git{url: location}
+	}
+	return nil
+}
+
+// ValidateGitURL checks url is something git clone accepts: a URL with a scheme git speaks,
+// an scp-style user@host:path or an existing local path. A URL starting with "-" is refused,
+// so it can never be taken for a git option.
+func ValidateGitURL(url string) error {
+	url = strings.TrimPrefix(url, "git+")
+	switch {
+	case strings.TrimSpace(url) == "":
+		return i18n.Errorf("git URL is empty")
+	case strings.HasPrefix(url, "-"):
+		return i18n.Errorf("git URL %q must not start with '-'", url)
+	case strings.ContainsAny(url, " \t\r\n"):
+		return i18n.Errorf("git URL %q must not contain whitespace", url)
+	case hasScheme(url, "http", "https", "ssh", "git"):
+		_, rest, _ := strings.Cut(url, "://")
+		if host, _, _ := strings.Cut(rest, "/"); host == "" {
+			return i18n.Errorf("git URL %q has no host", url)
+		}
+		return nil
+	case hasScheme(url, "file"):
+		return nil
+	case strings.Contains(url, "://"):
+		return i18n.Errorf("git URL %q uses an unsupported scheme (use https, ssh, git or file)", url)
+	}
+	if user, rest, ok := strings.Cut(url, "@"); ok && user != "" && strings.Contains(rest, ":") {
+		return nil
+	}
+	if _, err := os.Stat(url); err == nil {
+		return nil
+	}
+	return i18n.Errorf("%q is not a git URL (e.g. https://github.com/user/repo or git@github.com:user/repo.git) or an existing path", url)
+}
+
+// GitHost returns the normalized host of a git URL: lower case, without user, port or trailing
+// dot. It is empty for repositories on the local file system.
+func GitHost(url string) (string, error) {
+	if err := ValidateGitURL(url); err != nil {
+		return "", err
+	}
+	url = strings.TrimPrefix(url, "git+")
+	var host string
+	if hasScheme(url, "file") {
+		return "", nil
+	} else if _, rest, ok := strings.Cut(url, "://"); ok {
+		host, _, _ = strings.Cut(rest, "/")
+	} else if user, rest, ok := strings.Cut(url, "@"); ok && user != "" && strings.Contains(rest, ":") {
+		host, _, _ = strings.Cut(rest, ":")
+	} else {
+		return "", nil
+	}
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, ".")), nil
+}
+
+func (g *git) Kind() string     { return "git" }
+func (g *git) Location() string { return g.url }
+
+// Fetch clones the repository into a temporary directory, through the mirror cache when enabled.
+// The clone keeps its .git directory so callers can carry the history over.
+func (g *git) Fetch(opts Options) (string, func(), error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", noCleanup, i18n.Errorf("git is needed to fetch %s but was not found on PATH", g.url)
+	}
+	tmpDir, err := os.MkdirTemp("", "foundry-git-")
+	if err != nil {
+		return "", noCleanup, i18n.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	remote := g.url
+	if opts.CacheDir != "" {
+		mirror, stale, err := gitcache.Mirror(opts.CacheDir, g.url, opts.CacheTTL)
+		if err != nil {
+			opts.warn("Template cache unavailable, cloning directly: %v", err)
+		} else {
+			if stale {
+				opts.warn("Could not refresh %s, using cached copy", g.url)
+			}
+			remote = "file://" + filepath.ToSlash(mirror)
+		}
+	}
+
+	cloneDir := filepath.Join(tmpDir, "template")
+	args := []string{"clone", "--quiet"}
+	if opts.Depth > 0 && opts.Ref == "" {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	out, err := exec.Command("git", append(args, "--", remote, cloneDir)...).CombinedOutput()
+	if err != nil {
+		cleanup()
+		return "", noCleanup, i18n.Errorf("failed to clone git repository: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	if opts.Ref != "" {
+		out, err := exec.Command("git", "-C", cloneDir, "checkout", "--quiet", opts.Ref).CombinedOutput()
+		if err != nil {
+			cleanup()
+			return "", noCleanup, i18n.Errorf("failed to check out %s: %v: %s", opts.Ref, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return cloneDir, cleanup, nil
+}
+
+// Commit returns the commit checked out in dir, or "" when dir is not a git clone
+func Commit(dir string) string {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		return ""
+	}
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// Log returns the one-line summaries of the commits in dir after from, newest first
+func Log(dir, from string) ([]string, error) {
+	out, err := exec.Command("git", "-C", dir, "log", "--oneline", "--no-decorate", from+"..HEAD").CombinedOutput()
+	if err != nil {
+		return nil, i18n.Errorf("git log: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	var lines []string
+	for _, l := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines, nil
+}
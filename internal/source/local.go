@@ -0,0 +1,65 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/config"
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// local is a template directory on this machine
+type local struct {
+	path string
+}
+
+func newLocal(location string) Source {
+	if info, err := os.Stat(location); err != nil || !info.IsDir() {
+		return nil
+	}
+	return &local{path: location}
+}
+
+func (l *local) Kind() string     { return "local" }
+func (l *local) Location() string { return l.path }
+
+// Fetch returns the directory itself; nothing is copied
+func (l *local) Fetch(opts Options) (string, func(), error) {
+	abs, err := filepath.Abs(l.path)
+	if err != nil {
+		return "", noCleanup, i18n.Errorf("failed to get absolute path: %w", err)
+	}
+	return abs, noCleanup, nil
+}
+
+// registry is a template saved in the Foundry config, written as registry:<name>
+type registry struct {
+	name string
+}
+
+// registryPrefix marks a saved template name
+const registryPrefix = "registry:"
+
+func newRegistry(location string) Source {
+	name, ok := strings.CutPrefix(location, registryPrefix)
+	if !ok || name == "" {
+		return nil
+	}
+	return &registry{name: name}
+}
+
+func (r *registry) Kind() string     { return "registry" }
+func (r *registry) Location() string { return registryPrefix + r.name }
+
+// Fetch resolves the saved template to its directory
+func (r *registry) Fetch(opts Options) (string, func(), error) {
+	tmpl, err := config.GetTemplate(r.name)
+	if err != nil {
+		return "", noCleanup, err
+	}
+	if _, err := os.Stat(tmpl.Path); err != nil {
+		return "", noCleanup, i18n.Errorf("template path no longer exists: %s", tmpl.Path)
+	}
+	return tmpl.Path, noCleanup, nil
+}
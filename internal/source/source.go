@@ -0,0 +1,187 @@
+package source
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// Source is a place template files can be fetched from
+type Source interface {
+	// Kind names the source type, e.g. "git" or "archive"
+	Kind() string
+	// Location returns the location as the user gave it
+	Location() string
+	// Fetch makes the template files available in a local directory.
+	// The returned cleanup function removes anything Fetch created and is never nil.
+	Fetch(opts Options) (dir string, cleanup func(), err error)
+}
+
+// Options controls how sources are fetched
+type Options struct {
+	CacheDir string        // Directory for cached git mirrors; empty disables the cache
+	CacheTTL time.Duration // How long a cached mirror is used before fetching again
+	Depth    int           // History depth for git clones; 0 fetches the full history
+	SHA256   string        // Expected checksum of archive downloads; empty skips the check
+	Ref      string        // Commit, tag or branch to check out for git sources; implies a full clone
+
+	// Warn reports problems that do not stop the fetch, e.g. using a stale cache. Optional.
+	Warn func(format string, args ...interface{})
+}
+
+// warn reports a non-fatal problem through opts.Warn, if set
+func (o Options) warn(format string, args ...interface{}) {
+	if o.Warn != nil {
+		o.Warn(format, args...)
+	}
+}
+
+// Factory returns a Source for a location it recognizes, or nil
+type Factory func(location string) Source
+
+type factory struct {
+	kind string
+	new  Factory
+}
+
+// factories are tried in order by Parse; local directories come last as the catch-all
+var factories = []factory{
+	{"embedded", newEmbedded},
+	{"registry", newRegistry},
+	{"bucket", newBucket},
+	{"archive", newArchive},
+	{"git", newGit},
+	{"local", newLocal},
+}
+
+// Register adds a source type. It is tried before the built-in types,
+// so new schemes (e.g. s3://) can be supported without touching callers.
+func Register(kind string, f Factory) {
+	factories = append([]factory{{kind, f}}, factories...)
+}
+
+// Parse returns the source for a location, picking the first type that recognizes it
+func Parse(location string) (Source, error) {
+	for _, f := range factories {
+		if s := f.new(location); s != nil {
+			return s, nil
+		}
+	}
+	return nil, i18n.Errorf("unsupported template source: %s", location)
+}
+
+// SupportsChecksum reports whether a source downloads a single file that Options.SHA256 can pin
+func SupportsChecksum(s Source) bool {
+	return s.Kind() == "archive" || s.Kind() == "bucket"
+}
+
+// verifyChecksum refuses file when expected is set and does not match its SHA-256
+func verifyChecksum(file, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	actual := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(actual, strings.TrimPrefix(expected, "sha256:")) {
+		return i18n.Errorf("checksum mismatch: expected sha256 %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// IsRemote reports whether a source has to be fetched into a managed copy to be reused
+func IsRemote(s Source) bool {
+	return s.Kind() != "local" && s.Kind() != "registry"
+}
+
+// Install fetches s and replaces dest with a copy of its files, without any .git directory
+func Install(s Source, dest string, opts Options) error {
+	dir, cleanup, err := s.Fetch(opts)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	staging := dest + ".new"
+	os.RemoveAll(staging)
+	if err := copyDir(dir, staging); err != nil {
+		os.RemoveAll(staging)
+		return err
+	}
+	if err := os.RemoveAll(dest); err != nil {
+		return i18n.Errorf("cannot replace %s: %w", dest, err)
+	}
+	return os.Rename(staging, dest)
+}
+
+// copyDir copies the regular files and directories of src into dst, skipping .git
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFile(path, target, info.Mode().Perm())
+	})
+}
+
+// copyFile copies a single file, creating its parent directory
+func copyFile(src, dst string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// hasScheme reports whether location starts with one of the given URL schemes
+func hasScheme(location string, schemes ...string) bool {
+	for _, s := range schemes {
+		if strings.HasPrefix(location, s+"://") {
+			return true
+		}
+	}
+	return false
+}
+
+// noCleanup is returned by sources that do not create temporary files
+func noCleanup() {}
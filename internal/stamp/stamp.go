@@ -0,0 +1,185 @@
+package stamp
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Dir is the per-project directory where Foundry keeps its bookkeeping
+const Dir = ".foundry"
+
+// FileName is the name of the stamp file inside Dir
+const FileName = "stamp.yaml"
+
+// Stamp records how a project was generated so later commands can build on it
+type Stamp struct {
+	Template        string            `yaml:"template,omitempty"`
+	Bundle          string            `yaml:"bundle,omitempty"`
+	Source          string            `yaml:"source,omitempty"`
+	TemplateVersion string            `yaml:"template_version,omitempty"` // manifest version of the template
+	TemplateCommit  string            `yaml:"template_commit,omitempty"`  // HEAD of git templates
+	Language        string            `yaml:"language,omitempty"`
+	Framework       string            `yaml:"framework,omitempty"`
+	ProjectName     string            `yaml:"project_name"`
+	FoundryVersion  string            `yaml:"foundry_version,omitempty"`
+	CreatedAt       string            `yaml:"created_at,omitempty"`
+	Variables       map[string]string `yaml:"variables,omitempty"`
+	Generated       map[string]string `yaml:"generated,omitempty"` // built-in {{DATE}}, {{UUID}}, ... values, kept by foundry update
+	Components      []string          `yaml:"components,omitempty"`
+
+	// SHA-256 of each file as Foundry last wrote it, keyed by relative path.
+	// foundry update uses them to tell files the user edited from ones it may refresh.
+	Files map[string]string `yaml:"files,omitempty"`
+
+	// Paths whose local version foundry update always keeps, chosen while resolving conflicts
+	KeepOurs []string `yaml:"keep_ours,omitempty"`
+
+	// Globs of hand-written paths (e.g. internal/**) that foundry update never touches
+	Protected []string `yaml:"protected,omitempty"`
+
+	// Third-party content Foundry fetched into the project, such as the default .gitignore
+	Origins []Origin `yaml:"origins,omitempty"`
+
+	// SBOM format (spdx or cyclonedx) kept up to date in Dir, set by foundry new --sbom
+	SBOM string `yaml:"sbom,omitempty"`
+
+	// Set by foundry new --reproducible; everything foundry reproduce needs besides the fields above
+	Reproducible *Pin `yaml:"reproducible,omitempty"`
+}
+
+// Origin is a project file Foundry downloaded rather than rendered from the template
+type Origin struct {
+	Path   string `yaml:"path"`
+	URL    string `yaml:"url"`
+	Digest string `yaml:"digest,omitempty"` // SHA-256 of the file as downloaded
+}
+
+// Pin records the inputs of a reproducible project that are not part of the stamp itself
+type Pin struct {
+	TemplateHash   string            `yaml:"template_hash"`             // template.Hash of the template directory
+	TemplateCommit string            `yaml:"template_commit,omitempty"` // HEAD of git-sourced templates
+	Author         string            `yaml:"author"`
+	Date           string            `yaml:"date"`               // release date given to generators
+	Versions       map[string]string `yaml:"versions,omitempty"` // runtime versions given to generators
+	Docker         bool              `yaml:"docker,omitempty"`
+	Tools          []string          `yaml:"tools,omitempty"`
+	Environment    map[string]string `yaml:"environment,omitempty"` // built-in {{OS}}, {{SHELL}}, ... values
+}
+
+// Path returns the stamp file location for a project
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, Dir, FileName)
+}
+
+// Load reads the stamp of the project in projectDir.
+// It returns nil without error when the project has no stamp.
+func Load(projectDir string) (*Stamp, error) {
+	s, err := LoadFile(Path(projectDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return s, err
+}
+
+// LoadFile reads a stamp from an explicit file path
+func LoadFile(path string) (*Stamp, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Stamp{}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, i18n.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the stamp into projectDir
+func Save(projectDir string, s *Stamp) error {
+	if err := fsys.MkdirAll(filepath.Join(projectDir, Dir), 0755); err != nil {
+		return i18n.Errorf("cannot create %s directory: %w", Dir, err)
+	}
+	file, err := fsys.Create(Path(projectDir))
+	if err != nil {
+		return i18n.Errorf("cannot create stamp file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := yaml.NewEncoder(file)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(s); err != nil {
+		return i18n.Errorf("failed to write stamp: %w", err)
+	}
+	return nil
+}
+
+// AddComponents records generated components, ignoring ones already present
+func (s *Stamp) AddComponents(names ...string) {
+	for _, name := range names {
+		found := false
+		for _, existing := range s.Components {
+			if existing == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			s.Components = append(s.Components, name)
+		}
+	}
+}
+
+// RecordFiles stores the current digest of the given project files, by relative path
+func (s *Stamp) RecordFiles(projectDir string, paths ...string) error {
+	for _, rel := range paths {
+		digest, err := utils.FileDigest(filepath.Join(projectDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		if s.Files == nil {
+			s.Files = map[string]string{}
+		}
+		s.Files[filepath.ToSlash(rel)] = digest
+	}
+	return nil
+}
+
+// KeepsOurs reports whether updates always keep the project's version of rel
+func (s *Stamp) KeepsOurs(rel string) bool {
+	for _, p := range s.KeepOurs {
+		if p == rel {
+			return true
+		}
+	}
+	return false
+}
+
+// AddKeepOurs makes updates always keep the project's version of rel
+func (s *Stamp) AddKeepOurs(rel string) {
+	if !s.KeepsOurs(rel) {
+		s.KeepOurs = append(s.KeepOurs, rel)
+	}
+}
+
+// AddOrigin records where a downloaded project file came from, replacing an earlier record of it
+func (s *Stamp) AddOrigin(projectDir, rel, url string) error {
+	digest, err := utils.FileDigest(filepath.Join(projectDir, filepath.FromSlash(rel)))
+	if err != nil {
+		return err
+	}
+	origin := Origin{Path: filepath.ToSlash(rel), URL: url, Digest: digest}
+	for i, o := range s.Origins {
+		if o.Path == origin.Path {
+			s.Origins[i] = origin
+			return nil
+		}
+	}
+	s.Origins = append(s.Origins, origin)
+	return nil
+}
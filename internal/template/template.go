@@ -2,10 +2,21 @@ package template
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"syscall"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"github.com/kajvans/foundry/internal/lang"
+	"github.com/kajvans/foundry/internal/manifest"
+	"github.com/kajvans/foundry/internal/utils"
 )
 
 // Template represents a saved project template
@@ -14,56 +25,34 @@ type Template struct {
 	Path        string   `yaml:"path"`
 	Language    string   `yaml:"language"`
 	Description string   `yaml:"description"`
-	Files       []string `yaml:"files,omitempty"` // List of files in template
-}
+	Framework   string   `yaml:"framework,omitempty"` // Detected framework, e.g. Django
+	Files       []string `yaml:"files,omitempty"`     // List of files in template
+
+	// Registry metadata read from the template's foundry.yaml
+	Homepage          string                `yaml:"homepage,omitempty"`
+	Maintainer        string                `yaml:"maintainer,omitempty"`
+	MinFoundryVersion string                `yaml:"min_foundry_version,omitempty"`
+	Screenshots       []string              `yaml:"screenshots,omitempty"`
+	Labels            map[string]string     `yaml:"labels,omitempty"`
+	Deprecated        *manifest.Deprecation `yaml:"deprecated,omitempty"`
 
-// languageIndicators maps file extensions and filenames to languages
-var languageIndicators = map[string]string{
-	// Extensions
-	".go":    "Go",
-	".mod":   "Go",
-	".py":    "Python",
-	".js":    "JavaScript",
-	".ts":    "TypeScript",
-	".jsx":   "React",
-	".tsx":   "React",
-	".rs":    "Rust",
-	".java":  "Java",
-	".kt":    "Kotlin",
-	".cpp":   "C++",
-	".c":     "C",
-	".cs":    "C#",
-	".php":   "PHP",
-	".rb":    "Ruby",
-	".swift": "Swift",
-	".vue":   "Vue",
-
-	// Specific filenames
-	"package.json":     "JavaScript",
-	"tsconfig.json":    "TypeScript",
-	"Cargo.toml":       "Rust",
-	"pom.xml":          "Java",
-	"build.gradle":     "Java",
-	"Gemfile":          "Ruby",
-	"composer.json":    "PHP",
-	"requirements.txt": "Python",
-	"Pipfile":          "Python",
-	"go.mod":           "Go",
-	"Makefile":         "C/C++",
+	// Fingerprint of the directory when it was scanned
+	Fingerprint string `yaml:"fingerprint,omitempty"`
 }
 
 // DetectLanguage scans a directory and determines the primary language
 func DetectLanguage(dir string) (string, error) {
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		return "", fmt.Errorf("directory does not exist: %s", dir)
+	if _, err := fsys.Stat(dir); os.IsNotExist(err) {
+		return "", i18n.Errorf("directory does not exist: %s", dir)
 	}
 
 	languageCounts := make(map[string]int)
+	languageIndicators := lang.Indicators()
 
 	// Load ignore patterns from root .foundryignore if present
 	ignores := loadIgnorePatterns(dir)
 
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+	err := fsys.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -75,7 +64,7 @@ func DetectLanguage(dir string) (string, error) {
 			}
 			// Skip ignored directories
 			rel, _ := filepath.Rel(dir, path)
-			if matchIgnore(rel, ignores) {
+			if matchIgnore(rel, ignores) || (rel != "." && utils.GlobalIgnored(rel, true)) {
 				return filepath.SkipDir
 			}
 			return nil
@@ -83,39 +72,39 @@ func DetectLanguage(dir string) (string, error) {
 
 		// Skip ignored files
 		rel, _ := filepath.Rel(dir, path)
-		if matchIgnore(rel, ignores) {
+		if matchIgnore(rel, ignores) || utils.GlobalIgnored(rel, false) {
 			return nil
 		}
 
 		// Check by filename first
 		basename := filepath.Base(path)
-		if lang, ok := languageIndicators[basename]; ok {
-			languageCounts[lang] += 5 // Higher weight for specific files
+		if name, ok := languageIndicators[basename]; ok {
+			languageCounts[name] += 5 // Higher weight for specific files
 			return nil
 		}
 
 		// Check by extension
 		ext := filepath.Ext(path)
-		if lang, ok := languageIndicators[ext]; ok {
-			languageCounts[lang]++
+		if name, ok := languageIndicators[ext]; ok {
+			languageCounts[name]++
 		}
 
 		return nil
 	})
 
 	if err != nil {
-		return "", fmt.Errorf("failed to scan directory: %w", err)
+		return "", i18n.Errorf("failed to scan directory: %w", err)
 	}
 
 	if len(languageCounts) == 0 {
 		return "Unknown", nil
 	}
 
-	// Find the most common language
+	// Find the most common language; ties go to the name that sorts first
 	maxCount := 0
 	primaryLang := "Unknown"
-	for lang, count := range languageCounts {
-		if count > maxCount {
+	for _, lang := range utils.SortedKeys(languageCounts) {
+		if count := languageCounts[lang]; count > maxCount {
 			maxCount = count
 			primaryLang = lang
 		}
@@ -128,14 +117,18 @@ func DetectLanguage(dir string) (string, error) {
 func ScanTemplate(name, path, description string) (*Template, error) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+		return nil, i18n.Errorf("failed to get absolute path: %w", err)
 	}
 
-	if _, err := os.Stat(absPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("template directory does not exist: %s", absPath)
+	if _, err := fsys.Stat(absPath); os.IsNotExist(err) {
+		return nil, i18n.Errorf("template directory does not exist: %s", absPath)
 	}
 
-	lang, err := DetectLanguage(absPath)
+	language, err := DetectLanguage(absPath)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := Fingerprint(absPath)
 	if err != nil {
 		return nil, err
 	}
@@ -143,50 +136,231 @@ func ScanTemplate(name, path, description string) (*Template, error) {
 	// List files in template
 	ignores := loadIgnorePatterns(absPath)
 	var files []string
-	err = filepath.Walk(absPath, func(p string, info os.FileInfo, err error) error {
+	err = fsys.Walk(absPath, func(p string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() {
-			relPath, _ := filepath.Rel(absPath, p)
-			if matchIgnore(relPath, ignores) {
-				return nil
+		relPath, _ := filepath.Rel(absPath, p)
+		if info.IsDir() {
+			if relPath != "." && utils.GlobalIgnored(relPath, true) {
+				return filepath.SkipDir
 			}
-			files = append(files, relPath)
+			return nil
+		}
+		if matchIgnore(relPath, ignores) || utils.GlobalIgnored(relPath, false) {
+			return nil
 		}
+		files = append(files, relPath)
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list template files: %w", err)
+		return nil, i18n.Errorf("failed to list template files: %w", err)
 	}
 
 	tmpl := &Template{
 		Name:        name,
 		Path:        absPath,
-		Language:    lang,
+		Language:    language,
 		Description: description,
+		Framework:   lang.DetectFramework(absPath),
 		Files:       files,
+		Fingerprint: fingerprint,
+	}
+
+	// Pick up metadata from the manifest, if the template ships one
+	m, err := manifest.Load(absPath)
+	if err != nil {
+		return nil, err
+	}
+	if m != nil {
+		if tmpl.Description == "" {
+			tmpl.Description = m.Description
+		}
+		tmpl.Homepage = m.Homepage
+		tmpl.Maintainer = m.Maintainer
+		tmpl.MinFoundryVersion = m.MinFoundryVersion
+		tmpl.Screenshots = m.Screenshots
+		tmpl.Labels = m.Labels
+		tmpl.Deprecated = m.Deprecated
 	}
 
 	return tmpl, nil
 }
 
+// Fingerprint returns a SHA-256 over the relative path, size and modification time of every
+// file in dir, skipping .git. Unlike Hash it reads no file contents, so it is cheap enough to
+// check before every use whether a saved template was edited since it was scanned.
+func Fingerprint(dir string) (string, error) {
+	h := sha256.New()
+	err := fsys.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if info.Mode().IsRegular() {
+			rel, _ := filepath.Rel(dir, p)
+			fmt.Fprintf(h, "%s\x00%d\x00%d\x00", filepath.ToSlash(rel), info.Size(), info.ModTime().UnixNano())
+		}
+		return nil
+	})
+	if err != nil {
+		return "", i18n.Errorf("failed to hash template: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Hash returns a SHA-256 over the relative paths and contents of every file in dir, skipping .git.
+// It changes whenever a file is added, removed, renamed or edited.
+func Hash(dir string) (string, error) {
+	var files []string
+	err := fsys.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if info.Mode().IsRegular() {
+			rel, _ := filepath.Rel(dir, p)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", i18n.Errorf("failed to hash template: %w", err)
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, rel := range files {
+		data, err := fsys.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return "", i18n.Errorf("failed to hash template: %w", err)
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", rel, len(data))
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FileChanges lists how the files of a template differ between two scans
+type FileChanges struct {
+	Added, Removed, Changed []string
+}
+
+// Empty reports whether nothing changed
+func (c FileChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// Digests returns the SHA-256 of each of files, relative paths under dir. Files that cannot be
+// read get an empty digest, so they count as present with unknown contents.
+func Digests(dir string, files []string) map[string]string {
+	digests := make(map[string]string, len(files))
+	for _, rel := range files {
+		digests[rel], _ = utils.FileDigest(filepath.Join(dir, rel))
+	}
+	return digests
+}
+
+// CompareFiles returns the files added, removed and changed from before to after, which map
+// relative paths to digests. A file whose digest is empty on either side counts as unchanged.
+func CompareFiles(before, after map[string]string) FileChanges {
+	var c FileChanges
+	for _, rel := range utils.SortedKeys(after) {
+		old, ok := before[rel]
+		switch {
+		case !ok:
+			c.Added = append(c.Added, rel)
+		case old != "" && after[rel] != "" && old != after[rel]:
+			c.Changed = append(c.Changed, rel)
+		}
+	}
+	for _, rel := range utils.SortedKeys(before) {
+		if _, ok := after[rel]; !ok {
+			c.Removed = append(c.Removed, rel)
+		}
+	}
+	return c
+}
+
+// readmeNames lists the README file names looked up in a template root, in order of preference
+var readmeNames = []string{"README.md", "README", "README.txt", "readme.md", "Readme.md"}
+
+// ReadReadme returns the contents of the README in the root of dir, or an empty string if none exists
+func ReadReadme(dir string) string {
+	for _, name := range readmeNames {
+		data, err := fsys.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			return string(data)
+		}
+	}
+	return ""
+}
+
 // ValidateName checks if a template name is valid
 func ValidateName(name string) error {
 	if name == "" {
-		return fmt.Errorf("template name cannot be empty")
+		return i18n.Errorf("template name cannot be empty")
 	}
 	if strings.ContainsAny(name, `/\:*?"<>|`) {
-		return fmt.Errorf("template name contains invalid characters")
+		return i18n.Errorf("template name contains invalid characters")
 	}
 	return nil
 }
 
+// Move relocates the template directory oldPath to newPath, which must not exist yet.
+// Across filesystems the directory is copied and the original removed afterwards.
+func Move(oldPath, newPath string) error {
+	if _, err := fsys.Stat(newPath); err == nil {
+		return i18n.Errorf("%s already exists", newPath)
+	}
+	if err := fsys.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return i18n.Errorf("cannot create %s: %w", filepath.Dir(newPath), err)
+	}
+	err := fsys.Rename(oldPath, newPath)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	err = fsys.Walk(oldPath, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(oldPath, p)
+		target := filepath.Join(newPath, rel)
+		switch {
+		case info.IsDir():
+			return fsys.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			data, err := fsys.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			return fsys.WriteFile(target, data, info.Mode().Perm())
+		}
+		return nil
+	})
+	if err != nil {
+		fsys.RemoveAll(newPath)
+		return i18n.Errorf("cannot copy %s to %s: %w", oldPath, newPath, err)
+	}
+	return fsys.RemoveAll(oldPath)
+}
+
 // loadIgnorePatterns reads .foundryignore in the root directory (if present)
 // and returns a list of glob patterns relative to the root.
 func loadIgnorePatterns(root string) []string {
 	path := filepath.Join(root, ".foundryignore")
-	f, err := os.Open(path)
+	f, err := fsys.Open(path)
 	if err != nil {
 		return nil
 	}
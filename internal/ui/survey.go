@@ -0,0 +1,78 @@
+package ui
+
+import (
+	survey "github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/kajvans/foundry/internal/utils"
+)
+
+// pageSize is the most options a menu shows at once
+const pageSize = 10
+
+// DisableColor turns off color in prompts
+func DisableColor() {
+	core.DisableColor = true
+}
+
+// Survey prompts in the terminal with github.com/AlecAivazis/survey
+type Survey struct{}
+
+// Select shows an arrow-key menu
+func (Survey) Select(message string, options []string, def int, describe func(index int) string) (int, error) {
+	prompt := &survey.Select{
+		Message:  message,
+		Options:  options,
+		PageSize: utils.Min(len(options), pageSize),
+	}
+	if def > 0 && def < len(options) {
+		prompt.Default = options[def]
+	}
+	if describe != nil {
+		prompt.Description = func(_ string, index int) string {
+			return describe(index)
+		}
+	}
+	var chosen int
+	err := survey.AskOne(prompt, &chosen)
+	return chosen, err
+}
+
+// MultiSelect shows an arrow-key menu where space toggles options
+func (Survey) MultiSelect(message string, options []string, defaults []int) ([]int, error) {
+	prompt := &survey.MultiSelect{
+		Message:  message,
+		Options:  options,
+		PageSize: utils.Min(len(options), pageSize),
+	}
+	if len(defaults) > 0 {
+		prompt.Default = defaults
+	}
+	var chosen []int
+	err := survey.AskOne(prompt, &chosen)
+	return chosen, err
+}
+
+// Input reads a line of text
+func (Survey) Input(message, def string, required bool) (string, error) {
+	var opts []survey.AskOpt
+	if required {
+		opts = append(opts, survey.WithValidator(survey.Required))
+	}
+	var value string
+	err := survey.AskOne(&survey.Input{Message: message, Default: def}, &value, opts...)
+	return value, err
+}
+
+// Password reads a line of text, shown as asterisks
+func (Survey) Password(message string) (string, error) {
+	var value string
+	err := survey.AskOne(&survey.Password{Message: message}, &value)
+	return value, err
+}
+
+// Confirm asks a y/N question
+func (Survey) Confirm(message string, def bool) (bool, error) {
+	value := def
+	err := survey.AskOne(&survey.Confirm{Message: message, Default: def}, &value)
+	return value, err
+}
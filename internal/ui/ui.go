@@ -0,0 +1,73 @@
+// Package ui asks the user questions. Commands prompt through the package functions, which
+// delegate to the current Prompter, so the prompting backend can be replaced, prompts can be
+// turned off for a whole run, and command logic can be driven by scripted answers.
+package ui
+
+import "errors"
+
+// ErrDisabled is returned by every prompt when prompting is turned off
+var ErrDisabled = errors.New("prompts are disabled (non-interactive mode)")
+
+// Prompter asks questions and returns the answers. An error means no answer was given:
+// the user cancelled, or prompting is not possible.
+type Prompter interface {
+	// Select offers options, starting at the one at def, and returns the index of the chosen one.
+	// describe, when not nil, returns a description shown for the highlighted option.
+	Select(message string, options []string, def int, describe func(index int) string) (int, error)
+	// MultiSelect offers options and returns the indexes of the chosen ones, in order;
+	// the options at defaults start out chosen.
+	MultiSelect(message string, options []string, defaults []int) ([]int, error)
+	// Input asks for a line of text, offering def as the default. A required answer cannot be empty.
+	Input(message, def string, required bool) (string, error)
+	// Password asks for a line of text without echoing it. An empty answer is returned as is.
+	Password(message string) (string, error)
+	// Confirm asks a yes/no question, offering def as the default.
+	Confirm(message string, def bool) (bool, error)
+}
+
+// current is the Prompter the package functions use
+var current Prompter = Survey{}
+
+// SetPrompter makes p answer all later prompts
+func SetPrompter(p Prompter) {
+	current = p
+}
+
+// Disable turns prompting off: every prompt returns ErrDisabled
+func Disable() {
+	current = Disabled{}
+}
+
+// Select asks the current Prompter to choose one of options
+func Select(message string, options []string, def int, describe func(index int) string) (int, error) {
+	return current.Select(message, options, def, describe)
+}
+
+// MultiSelect asks the current Prompter to choose any of options
+func MultiSelect(message string, options []string, defaults []int) ([]int, error) {
+	return current.MultiSelect(message, options, defaults)
+}
+
+// Input asks the current Prompter for a line of text
+func Input(message, def string, required bool) (string, error) {
+	return current.Input(message, def, required)
+}
+
+// Password asks the current Prompter for a line of text without echoing it
+func Password(message string) (string, error) {
+	return current.Password(message)
+}
+
+// Confirm asks the current Prompter a yes/no question
+func Confirm(message string, def bool) (bool, error) {
+	return current.Confirm(message, def)
+}
+
+// Disabled is the Prompter of non-interactive runs; it answers nothing
+type Disabled struct{}
+
+func (Disabled) Select(string, []string, int, func(int) string) (int, error) { return 0, ErrDisabled }
+func (Disabled) MultiSelect(string, []string, []int) ([]int, error)          { return nil, ErrDisabled }
+func (Disabled) Input(string, string, bool) (string, error)                  { return "", ErrDisabled }
+func (Disabled) Password(string) (string, error)                             { return "", ErrDisabled }
+func (Disabled) Confirm(string, bool) (bool, error)                          { return false, ErrDisabled }
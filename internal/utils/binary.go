@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"bytes"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode/utf16"
+)
+
+// sniffBytes is how much of a file content sniffing looks at, and binaryCheckBytes how much
+// is searched for NUL bytes
+const (
+	sniffBytes       = 512
+	binaryCheckBytes = 8000
+)
+
+// defaultBinaryExtensions are never rendered, whatever their content looks like
+var defaultBinaryExtensions = []string{
+	".png", ".jpg", ".jpeg", ".gif", ".bmp", ".ico", ".webp", ".avif", ".tif", ".tiff", ".psd",
+	".pdf", ".woff", ".woff2", ".ttf", ".otf", ".eot",
+	".zip", ".gz", ".tgz", ".bz2", ".xz", ".7z", ".rar", ".tar", ".jar", ".war",
+	".exe", ".dll", ".so", ".dylib", ".a", ".o", ".class", ".pyc", ".wasm",
+	".mp3", ".mp4", ".wav", ".ogg", ".webm", ".mov", ".avi", ".flac",
+	".sqlite", ".db", ".keystore", ".jks", ".p12",
+}
+
+var (
+	// binaryExtensions and textExtensions are lower-case extensions decided by name alone;
+	// text wins when an extension is in both
+	binaryExtensions = extensionSet(defaultBinaryExtensions)
+	textExtensions   = map[string]bool{}
+)
+
+// SetFileExtensions adds extensions always treated as binary (.psd) or as text (.svg) to the
+// built-in ones; binary_extensions and text_extensions in the config set them
+func SetFileExtensions(binary, text []string) {
+	binaryExtensions = extensionSet(append(append([]string{}, defaultBinaryExtensions...), binary...))
+	textExtensions = extensionSet(text)
+}
+
+// extensionSet normalizes extensions to lower case with a leading dot
+func extensionSet(exts []string) map[string]bool {
+	set := make(map[string]bool, len(exts))
+	for _, ext := range exts {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		set[ext] = true
+	}
+	return set
+}
+
+// IsBinaryFile reports whether the file name holding data is binary, so it is copied without
+// placeholder replacement: its extension decides when listed, otherwise its content (IsBinary)
+func IsBinaryFile(name string, data []byte) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	switch {
+	case textExtensions[ext]:
+		return false
+	case binaryExtensions[ext]:
+		return true
+	}
+	return IsBinary(data, binaryCheckBytes)
+}
+
+// IsBinary reports whether data likely represents a binary file. UTF-16 text with a byte order
+// mark is text, formats content sniffing recognizes (images, PDF, fonts, archives) are binary,
+// and anything else is when its first maxCheckBytes hold a NUL byte.
+func IsBinary(data []byte, maxCheckBytes int) bool {
+	if DetectEncoding(data) != UTF8 {
+		return false
+	}
+	sniffed := http.DetectContentType(data[:Min(len(data), sniffBytes)])
+	for _, prefix := range binaryTypes {
+		if strings.HasPrefix(sniffed, prefix) {
+			return true
+		}
+	}
+	return bytes.IndexByte(data[:Min(len(data), maxCheckBytes)], 0) >= 0
+}
+
+// binaryTypes are content-type prefixes of sniffed formats that are never text
+var binaryTypes = []string{
+	"image/", "audio/", "video/", "font/", "application/pdf", "application/zip",
+	"application/x-gzip", "application/wasm", "application/vnd.ms-fontobject", "application/x-rar-compressed",
+}
+
+// TextEncoding is how a text file encodes its characters
+type TextEncoding int
+
+// Encodings of text files, told apart by their byte order mark
+const (
+	UTF8 TextEncoding = iota
+	UTF16LE
+	UTF16BE
+)
+
+// DetectEncoding returns the encoding named by the byte order mark data starts with, UTF8 when none
+func DetectEncoding(data []byte) TextEncoding {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return UTF16LE
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return UTF16BE
+	}
+	return UTF8
+}
+
+// DecodeText returns the text of data and its encoding; UTF-16 is converted to UTF-8 without
+// its byte order mark, which EncodeText restores
+func DecodeText(data []byte) (string, TextEncoding) {
+	enc := DetectEncoding(data)
+	if enc == UTF8 {
+		return string(data), enc
+	}
+	data = data[2:]
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		if enc == UTF16LE {
+			units[i] = uint16(data[2*i]) | uint16(data[2*i+1])<<8
+		} else {
+			units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		}
+	}
+	return string(utf16.Decode(units)), enc
+}
+
+// EncodeText returns text encoded as enc, with the byte order mark for UTF-16
+func EncodeText(text string, enc TextEncoding) []byte {
+	if enc == UTF8 {
+		return []byte(text)
+	}
+	units := utf16.Encode([]rune(text))
+	out := make([]byte, 0, 2+2*len(units))
+	if enc == UTF16LE {
+		out = append(out, 0xFF, 0xFE)
+	} else {
+		out = append(out, 0xFE, 0xFF)
+	}
+	for _, u := range units {
+		if enc == UTF16LE {
+			out = append(out, byte(u), byte(u>>8))
+		} else {
+			out = append(out, byte(u>>8), byte(u))
+		}
+	}
+	return out
+}
+
+// Line ending modes of rendered text files; auto uses the platform's own, CRLF on Windows
+const (
+	LineEndingsLF   = "lf"
+	LineEndingsCRLF = "crlf"
+	LineEndingsAuto = "auto"
+)
+
+// LineEndingModes lists the valid line_endings values
+var LineEndingModes = []string{LineEndingsLF, LineEndingsCRLF, LineEndingsAuto}
+
+// NormalizeLineEndings converts every line ending of text to the given mode; an empty mode
+// leaves text as it is
+func NormalizeLineEndings(text, mode string) string {
+	if mode == LineEndingsAuto {
+		mode = LineEndingsLF
+		if runtime.GOOS == "windows" {
+			mode = LineEndingsCRLF
+		}
+	}
+	switch mode {
+	case LineEndingsLF:
+		return strings.ReplaceAll(text, "\r\n", "\n")
+	case LineEndingsCRLF:
+		return strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")
+	}
+	return text
+}
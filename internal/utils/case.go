@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// CaseFuncs are the one-argument string functions every template syntax offers: Go-engine
+// templates ({{ .NAME | kebab }}), handlebars helpers ({{kebab NAME}}) and computed values
+// (kebab(NAME))
+var CaseFuncs = map[string]func(string) string{
+	"lower":  strings.ToLower,
+	"upper":  strings.ToUpper,
+	"title":  CapitalizeFirst,
+	"kebab":  KebabCase,
+	"snake":  SnakeCase,
+	"camel":  CamelCase,
+	"pascal": PascalCase,
+	"slug":   Slug,
+	"trim":   strings.TrimSpace,
+}
+
+// Words splits a name into its words at separators (anything but letters, digits and combining
+// marks) and at case changes, so "my-api", "my_api", "MyAPI" and "myApi" all give [my api]. A run
+// of capitals is one word, ending before a capital that starts a lower-case word: "HTTPServer"
+// gives [http server]. Letters without case, such as CJK characters, never start a new word.
+func Words(s string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(s)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	for i, r := range runes {
+		if unicode.IsMark(r) {
+			// An accent written as a separate character (e + ◌́) belongs to the letter before it
+			if len(word) > 0 {
+				word = append(word, r)
+			}
+			continue
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+// capitalize puts the first letter of word in title case, which for most letters is upper case
+// but keeps digraphs such as ǆ readable (ǅ). It is the same in every locale.
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	if size == 0 || r == utf8.RuneError {
+		return word
+	}
+	return string(unicode.ToTitle(r)) + word[size:]
+}
+
+// KebabCase returns s as lower-case words joined by hyphens: my-api
+func KebabCase(s string) string {
+	return strings.Join(Words(s), "-")
+}
+
+// SnakeCase returns s as lower-case words joined by underscores: my_api
+func SnakeCase(s string) string {
+	return strings.Join(Words(s), "_")
+}
+
+// PascalCase returns s as capitalized words without separators: MyApi
+func PascalCase(s string) string {
+	words := Words(s)
+	for i, w := range words {
+		words[i] = capitalize(w)
+	}
+	return strings.Join(words, "")
+}
+
+// CamelCase returns s as PascalCase with the first word in lower case: myApi
+func CamelCase(s string) string {
+	words := Words(s)
+	for i := 1; i < len(words); i++ {
+		words[i] = capitalize(words[i])
+	}
+	return strings.Join(words, "")
+}
+
+// ProjectNameVars returns the built-in variables derived from the project name, keyed by
+// placeholder name: PROJECT_NAME, its _LOWER, _UPPER, _KEBAB, _SNAKE, _CAMEL and _PASCAL forms,
+// and the sanitized identifiers PROJECT_SLUG, PROJECT_NAME_ALNUM, PROJECT_NAME_DNS and PROJECT_NAME_DB
+func ProjectNameVars(projectName string) map[string]string {
+	return map[string]string{
+		"PROJECT_NAME":        projectName,
+		"PROJECT_NAME_LOWER":  strings.ToLower(projectName),
+		"PROJECT_NAME_UPPER":  strings.ToUpper(projectName),
+		"PROJECT_NAME_KEBAB":  KebabCase(projectName),
+		"PROJECT_NAME_SNAKE":  SnakeCase(projectName),
+		"PROJECT_NAME_CAMEL":  CamelCase(projectName),
+		"PROJECT_NAME_PASCAL": PascalCase(projectName),
+		"PROJECT_SLUG":        Slug(projectName),
+		"PROJECT_NAME_ALNUM":  Alnum(projectName),
+		"PROJECT_NAME_DNS":    DNSLabel(projectName),
+		"PROJECT_NAME_DB":     DBIdentifier(projectName),
+	}
+}
+
+// IsReserved reports whether name is a built-in variable templates rely on and that must not
+// be redefined: AUTHOR and the forms of the project name
+func IsReserved(name string) bool {
+	_, ok := ProjectNameVars("")[name]
+	return ok || name == "AUTHOR"
+}
+
+// canonicalVarName is the form variable names are compared in, so api_key, API_KEY and apiKey
+// are recognized as the same name
+func canonicalVarName(name string) string {
+	return strings.ToUpper(SnakeCase(name))
+}
+
+// VarCollisions describes the variables in names that shadow a built-in or each other. Reserved
+// variables (see IsReserved) may not be redefined at all. The other built-ins, given in
+// overridable, may be overridden by their exact name but not by a name that only differs in
+// case or separators. Two variables that only differ that way collide with each other too.
+func VarCollisions(names, overridable []string) []string {
+	builtins := map[string]string{}
+	for name := range ProjectNameVars("") {
+		builtins[canonicalVarName(name)] = name
+	}
+	builtins["AUTHOR"] = "AUTHOR"
+	for _, name := range overridable {
+		if !IsReserved(name) {
+			builtins[canonicalVarName(name)] = name
+		}
+	}
+
+	var collisions []string
+	seen := map[string]string{}
+	for _, name := range names {
+		canonical := canonicalVarName(name)
+		if builtin, ok := builtins[canonical]; ok {
+			switch {
+			case IsReserved(name):
+				collisions = append(collisions, i18n.Sprintf("variable %s redefines the built-in %s", name, name))
+			case builtin != name:
+				collisions = append(collisions, i18n.Sprintf("variable %s differs only in case from the built-in %s", name, builtin))
+			}
+			continue
+		}
+		if other, ok := seen[canonical]; ok && other != name {
+			collisions = append(collisions, i18n.Sprintf("variables %s and %s differ only in case", other, name))
+		} else if !ok {
+			seen[canonical] = name
+		}
+	}
+	return collisions
+}
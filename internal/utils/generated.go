@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// GeneratedNames are the built-in variables whose values are generated when a project is created
+var GeneratedNames = []string{"YEAR", "DATE", "TIMESTAMP", "UUID"}
+
+// GeneratedVars returns the generated built-in variables for a project created at now:
+// YEAR (2025), DATE (2025-01-31) and TIMESTAMP (RFC 3339), all in UTC, and a random UUID
+func GeneratedVars(now time.Time) (map[string]string, error) {
+	uuid, err := NewUUID()
+	if err != nil {
+		return nil, err
+	}
+	now = now.UTC()
+	return map[string]string{
+		"YEAR":      now.Format("2006"),
+		"DATE":      now.Format("2006-01-02"),
+		"TIMESTAMP": now.Format(time.RFC3339),
+		"UUID":      uuid,
+	}, nil
+}
+
+// NewUUID returns a random (version 4) UUID in its canonical lower-case form
+func NewUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", i18n.Errorf("cannot generate UUID: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/fsys"
+)
+
+// globalIgnores holds the user's global gitignore patterns once enabled with SetGlobalIgnores
+var globalIgnores []string
+
+// SetGlobalIgnores sets the global gitignore patterns that template scans and project creation
+// leave out; nil turns the check off
+func SetGlobalIgnores(patterns []string) {
+	globalIgnores = patterns
+}
+
+// GlobalGitignorePath returns the user's global gitignore: the file named by git's
+// core.excludesfile, or git's default $XDG_CONFIG_HOME/git/ignore (~/.config/git/ignore)
+func GlobalGitignorePath() string {
+	if out, err := exec.Command("git", "config", "--global", "--path", "--get", "core.excludesfile").Output(); err == nil {
+		if path := strings.TrimSpace(string(out)); path != "" {
+			return path
+		}
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "git", "ignore")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "git", "ignore")
+}
+
+// LoadGlobalGitignore reads the patterns of the user's global gitignore. Negated patterns
+// (!keep.me) are skipped. It returns nil when there is no such file.
+func LoadGlobalGitignore() []string {
+	path := GlobalGitignorePath()
+	if path == "" {
+		return nil
+	}
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns
+}
+
+// GlobalIgnored reports whether the relative path matches the global gitignore set with
+// SetGlobalIgnores. As in git, a pattern without a slash matches the name at any depth, a
+// leading slash anchors it to the template root and a trailing slash matches only directories.
+func GlobalIgnored(relPath string, isDir bool) bool {
+	relPath = filepath.ToSlash(relPath)
+	for _, pattern := range globalIgnores {
+		if strings.HasSuffix(pattern, "/") {
+			if !isDir {
+				continue
+			}
+			pattern = strings.TrimSuffix(pattern, "/")
+		}
+		anchored := strings.HasPrefix(pattern, "/")
+		pattern = strings.TrimPrefix(pattern, "/")
+		if !anchored && !strings.Contains(pattern, "/") {
+			if matched, _ := filepath.Match(pattern, filepath.Base(relPath)); matched {
+				return true
+			}
+		} else if matchSegments(strings.Split(pattern, "/"), strings.Split(relPath, "/")) {
+			return true
+		}
+	}
+	return false
+}
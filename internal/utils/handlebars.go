@@ -0,0 +1,416 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// Token and node kinds of the handlebars engine
+const (
+	hbText     = 't'
+	hbEscaped  = '{' // {{name}}, HTML-escaped as Handlebars does
+	hbRaw      = '&' // {{{name}}} or {{& name}}
+	hbOpen     = '#' // {{#if name}}, {{#each name}}, {{#name}}
+	hbInverted = '^' // {{^name}}
+	hbClose    = '/'
+	hbElse     = 'e'
+	hbComment  = '!'
+)
+
+// hbEscaper escapes the characters Handlebars escapes in {{name}}
+var hbEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&#x27;", "`", "&#x60;", "=", "&#x3D;")
+
+// hbAliases are the names plop gives the case functions
+var hbAliases = map[string]string{
+	"lowerCase":  "lower",
+	"upperCase":  "upper",
+	"camelCase":  "camel",
+	"snakeCase":  "snake",
+	"dashCase":   "kebab",
+	"kebabCase":  "kebab",
+	"pascalCase": "pascal",
+	"properCase": "pascal",
+}
+
+// hbHelpers are the helpers that can be called with one variable, as in {{kebab name}}:
+// CaseFuncs under their own names and plop's, and plop's constantCase
+var hbHelpers = map[string]func(string) string{
+	"constantCase": func(s string) string { return strings.ToUpper(SnakeCase(s)) },
+}
+
+func init() {
+	for name, fn := range CaseFuncs {
+		hbHelpers[name] = fn
+	}
+	for alias, name := range hbAliases {
+		hbHelpers[alias] = CaseFuncs[name]
+	}
+}
+
+type hbToken struct {
+	kind  byte
+	value string
+	line  int
+}
+
+type hbNode struct {
+	kind    byte
+	value   string
+	line    int
+	body    []hbNode
+	inverse []hbNode // the {{else}} part of a block
+}
+
+// hbScope is the item an {{#each}} block is rendering
+type hbScope struct {
+	item         string
+	index, count int
+}
+
+// RenderHandlebars renders content written for Handlebars or Mustache, as used by scaffolding
+// tools such as plop: {{name}} (HTML-escaped) and {{{name}}}, {{#if}}, {{#unless}} and {{#each}}
+// with {{else}}, Mustache sections {{#name}} and {{^name}}, comments, whitespace control with ~,
+// \{{ for literal braces and one-argument helpers such as {{kebab name}}. Variables are looked up
+// exactly, then case-insensitively; in {{#each}}, {{this}}, {{@index}}, {{@first}} and {{@last}}
+// describe the item. A variable that is not defined is an error, except as a condition.
+func RenderHandlebars(name, content, projectName, author string, extraVars map[string]string, lists map[string]bool) (string, error) {
+	values := ProjectNameVars(projectName)
+	values["AUTHOR"] = author
+	for k, v := range extraVars {
+		values[k] = v
+	}
+
+	tokens, err := tokenizeHandlebars(content)
+	if err != nil {
+		return "", i18n.Errorf("cannot parse %s: %w", name, err)
+	}
+	nodes, err := parseHandlebars(tokens)
+	if err != nil {
+		return "", i18n.Errorf("cannot parse %s: %w", name, err)
+	}
+	var b strings.Builder
+	if err := renderHandlebars(&b, nodes, values, lists, nil); err != nil {
+		return "", i18n.Errorf("cannot render %s: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+// tokenizeHandlebars splits content into text and tags, applying ~ whitespace control and
+// removing the lines of block tags and comments that stand alone on them
+func tokenizeHandlebars(content string) ([]hbToken, error) {
+	var tokens []hbToken
+	trimNext := false
+	addText := func(text string) {
+		if trimNext {
+			text = strings.TrimLeft(text, " \t\r\n")
+			trimNext = false
+		}
+		if text != "" {
+			tokens = append(tokens, hbToken{kind: hbText, value: text})
+		}
+	}
+
+	for i := 0; ; {
+		j := strings.Index(content[i:], "{{")
+		if j < 0 {
+			addText(content[i:])
+			break
+		}
+		j += i
+		line := strings.Count(content[:j], "\n") + 1
+		if j > 0 && content[j-1] == '\\' {
+			addText(content[i:j-1] + "{{")
+			i = j + 2
+			continue
+		}
+		addText(content[i:j])
+
+		start, closing := j+2, "}}"
+		triple := strings.HasPrefix(content[start:], "{")
+		if triple {
+			start, closing = start+1, "}}}"
+		}
+		if strings.HasPrefix(content[start:], "~") {
+			start++
+			if n := len(tokens); n > 0 && tokens[n-1].kind == hbText {
+				tokens[n-1].value = strings.TrimRight(tokens[n-1].value, " \t\r\n")
+			}
+		}
+		if strings.HasPrefix(content[start:], "!--") {
+			closing = "--}}"
+			plain := strings.Index(content[start:], "--}}")
+			if k := strings.Index(content[start:], "--~}}"); k >= 0 && (plain < 0 || k < plain) {
+				closing = "--~}}"
+			}
+		}
+		k := strings.Index(content[start:], closing)
+		if k < 0 {
+			return nil, i18n.Errorf("line %d: unclosed {{", line)
+		}
+		inner := content[start : start+k]
+		i = start + k + len(closing)
+		if closing == "--~}}" {
+			trimNext = true
+		} else if strings.HasSuffix(inner, "~") {
+			inner = inner[:len(inner)-1]
+			trimNext = true
+		}
+
+		token := hbToken{line: line}
+		inner = strings.TrimSpace(inner)
+		switch {
+		case triple:
+			token.kind, token.value = hbRaw, inner
+		case strings.HasPrefix(inner, "!"):
+			token.kind = hbComment
+		case inner == "else" || inner == "^":
+			token.kind = hbElse
+		case strings.HasPrefix(inner, ">"):
+			return nil, i18n.Errorf("line %d: partials ({{%s}}) are not supported", line, inner)
+		case strings.HasPrefix(inner, "#"), strings.HasPrefix(inner, "^"), strings.HasPrefix(inner, "/"), strings.HasPrefix(inner, "&"):
+			token.kind, token.value = inner[0], strings.TrimSpace(inner[1:])
+		default:
+			token.kind, token.value = hbEscaped, inner
+		}
+		tokens = append(tokens, token)
+	}
+	return removeStandalone(tokens), nil
+}
+
+// removeStandalone drops the indentation and line break around block tags and comments that
+// are alone on their line, so they leave no blank lines behind
+func removeStandalone(tokens []hbToken) []hbToken {
+	trimHead := make([]bool, len(tokens)) // text up to and including its first line break
+	trimTail := make([]bool, len(tokens)) // text after its last line break
+	for k, t := range tokens {
+		if t.kind == hbText || t.kind == hbEscaped || t.kind == hbRaw {
+			continue
+		}
+		// The tag must start its line...
+		if k > 0 {
+			before := tokens[k-1]
+			if before.kind != hbText {
+				continue
+			}
+			nl := strings.LastIndex(before.value, "\n")
+			if (nl < 0 && k > 1) || strings.TrimLeft(before.value[nl+1:], " \t") != "" {
+				continue
+			}
+		}
+		// ...and end it
+		if k+1 < len(tokens) {
+			after := tokens[k+1]
+			if after.kind != hbText {
+				continue
+			}
+			rest, _, found := strings.Cut(after.value, "\n")
+			if (!found && k+2 < len(tokens)) || strings.TrimRight(rest, " \t\r") != "" {
+				continue
+			}
+			trimHead[k+1] = true
+		}
+		if k > 0 {
+			trimTail[k-1] = true
+		}
+	}
+	for k := range tokens {
+		value := tokens[k].value
+		start, end := 0, len(value)
+		if trimHead[k] {
+			start = strings.Index(value, "\n") + 1
+			if start == 0 {
+				start = len(value)
+			}
+		}
+		if trimTail[k] {
+			end = strings.LastIndex(value, "\n") + 1
+		}
+		if start > end {
+			start = end
+		}
+		tokens[k].value = value[start:end]
+	}
+	return tokens
+}
+
+// parseHandlebars nests the tokens between block tags into their blocks
+func parseHandlebars(tokens []hbToken) ([]hbNode, error) {
+	type frame struct {
+		node   *hbNode
+		inElse bool
+	}
+	stack := []*frame{{node: &hbNode{}}}
+	add := func(n hbNode) {
+		f := stack[len(stack)-1]
+		if f.inElse {
+			f.node.inverse = append(f.node.inverse, n)
+		} else {
+			f.node.body = append(f.node.body, n)
+		}
+	}
+	for _, t := range tokens {
+		switch t.kind {
+		case hbComment:
+		case hbOpen, hbInverted:
+			if t.kind == hbOpen && (t.value == "if" || t.value == "unless" || t.value == "each") {
+				return nil, i18n.Errorf("line %d: {{#%s}} needs a variable", t.line, t.value)
+			}
+			stack = append(stack, &frame{node: &hbNode{kind: t.kind, value: t.value, line: t.line}})
+		case hbElse:
+			if len(stack) == 1 {
+				return nil, i18n.Errorf("line %d: {{else}} outside a block", t.line)
+			}
+			stack[len(stack)-1].inElse = true
+		case hbClose:
+			if len(stack) == 1 {
+				return nil, i18n.Errorf("line %d: {{/%s}} closes no block", t.line, t.value)
+			}
+			top := stack[len(stack)-1].node
+			if open := blockName(top.value); t.value != open {
+				return nil, i18n.Errorf("line %d: {{/%s}} does not close {{#%s}}", t.line, t.value, open)
+			}
+			stack = stack[:len(stack)-1]
+			add(*top)
+		default:
+			add(hbNode{kind: t.kind, value: t.value, line: t.line})
+		}
+	}
+	if len(stack) > 1 {
+		top := stack[len(stack)-1].node
+		return nil, i18n.Errorf("line %d: {{#%s}} is not closed", top.line, blockName(top.value))
+	}
+	return stack[0].node.body, nil
+}
+
+// blockName returns the helper or section name a block tag opens, e.g. if for {{#if ready}}
+func blockName(expr string) string {
+	name, _, _ := strings.Cut(expr, " ")
+	return name
+}
+
+// hbLookup finds a variable, exactly or case-insensitively, or describes the current item
+func hbLookup(path string, values map[string]string, scope *hbScope) (key, value string, ok bool) {
+	if scope != nil {
+		switch path {
+		case "this", ".":
+			return "", scope.item, true
+		case "@index":
+			return "", strconv.Itoa(scope.index), true
+		case "@first":
+			return "", strconv.FormatBool(scope.index == 0), true
+		case "@last":
+			return "", strconv.FormatBool(scope.index == scope.count-1), true
+		}
+	}
+	if v, ok := values[path]; ok {
+		return path, v, true
+	}
+	for _, k := range SortedKeys(values) {
+		if strings.EqualFold(k, path) {
+			return k, values[k], true
+		}
+	}
+	return "", "", false
+}
+
+// hbTruthy reports whether a condition holds: lists when not empty, other values when neither
+// empty nor false
+func hbTruthy(key, value string, lists map[string]bool) bool {
+	if lists[key] {
+		return len(SplitList(value)) > 0
+	}
+	return value != "" && value != "false"
+}
+
+// hbEval returns the value of a tag's expression: a variable, or a helper applied to one
+func hbEval(n hbNode, values map[string]string, scope *hbScope) (string, error) {
+	fields := strings.Fields(n.value)
+	switch len(fields) {
+	case 1:
+		_, v, ok := hbLookup(fields[0], values, scope)
+		if !ok {
+			return "", i18n.Errorf("line %d: %s is not defined", n.line, fields[0])
+		}
+		return v, nil
+	case 2:
+		helper, ok := hbHelpers[fields[0]]
+		if !ok {
+			return "", i18n.Errorf("line %d: unknown helper '%s'", n.line, fields[0])
+		}
+		_, v, ok := hbLookup(fields[1], values, scope)
+		if !ok {
+			return "", i18n.Errorf("line %d: %s is not defined", n.line, fields[1])
+		}
+		return helper(v), nil
+	}
+	return "", i18n.Errorf("line %d: unsupported expression '%s'", n.line, n.value)
+}
+
+func renderHandlebars(b *strings.Builder, nodes []hbNode, values map[string]string, lists map[string]bool, scope *hbScope) error {
+	for _, n := range nodes {
+		switch n.kind {
+		case hbText:
+			b.WriteString(n.value)
+		case hbEscaped, hbRaw:
+			v, err := hbEval(n, values, scope)
+			if err != nil {
+				return err
+			}
+			if n.kind == hbEscaped {
+				v = hbEscaper.Replace(v)
+			}
+			b.WriteString(v)
+		case hbInverted:
+			key, v, _ := hbLookup(n.value, values, scope)
+			part := n.inverse
+			if !hbTruthy(key, v, lists) {
+				part = n.body
+			}
+			if err := renderHandlebars(b, part, values, lists, scope); err != nil {
+				return err
+			}
+		case hbOpen:
+			if err := renderBlock(b, n, values, lists, scope); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// renderBlock renders {{#if}}, {{#unless}}, {{#each}} and Mustache sections, which repeat for
+// each item of a list and otherwise render once when their variable holds
+func renderBlock(b *strings.Builder, n hbNode, values map[string]string, lists map[string]bool, scope *hbScope) error {
+	helper, arg, _ := strings.Cut(n.value, " ")
+	arg = strings.TrimSpace(arg)
+	if helper != "if" && helper != "unless" && helper != "each" && arg != "" {
+		return i18n.Errorf("line %d: unsupported block helper '%s'", n.line, helper)
+	}
+	if arg == "" {
+		arg = helper
+	}
+	key, v, _ := hbLookup(arg, values, scope)
+
+	if helper == "each" || (lists[key] && helper != "if" && helper != "unless") {
+		items := SplitList(v)
+		if len(items) == 0 {
+			return renderHandlebars(b, n.inverse, values, lists, scope)
+		}
+		for i, item := range items {
+			if err := renderHandlebars(b, n.body, values, lists, &hbScope{item: item, index: i, count: len(items)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	holds := hbTruthy(key, v, lists)
+	if helper == "unless" {
+		holds = !holds
+	}
+	if holds {
+		return renderHandlebars(b, n.body, values, lists, scope)
+	}
+	return renderHandlebars(b, n.inverse, values, lists, scope)
+}
@@ -0,0 +1,97 @@
+package utils
+
+import "strings"
+
+// maxIdentifierLen is the longest DNS label (RFC 1123), which is also the limit for bucket
+// names, Kubernetes namespaces and PostgreSQL identifiers
+const maxIdentifierLen = 63
+
+// fallbackIdentifier is used when nothing of the project name survives sanitizing, e.g. for a
+// name written entirely in a non-Latin script
+const fallbackIdentifier = "project"
+
+// foldings spell Latin letters with diacritics and ligatures in plain ASCII
+var foldings = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'æ': "ae", 'ç': "c", 'ć': "c", 'č': "c", 'ď': "d", 'đ': "d", 'ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ğ': "g", 'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'į': "i", 'ı': "i",
+	'ł': "l", 'ľ': "l", 'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o", 'œ': "oe",
+	'ř': "r", 'ś': "s", 'š': "s", 'ş': "s", 'ș': "s", 'ß': "ss", 'ť': "t", 'ț': "t", 'þ': "th",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+	'ý': "y", 'ÿ': "y", 'ź': "z", 'ż': "z", 'ž': "z", 'ǆ': "dz", 'ǉ': "lj", 'ǌ': "nj",
+}
+
+// asciiWords returns the words of s (see Words) spelled in lower-case ASCII letters and digits.
+// Diacritics are dropped (é becomes e, ß becomes ss); other characters are left out.
+func asciiWords(s string) []string {
+	var words []string
+	for _, word := range Words(s) {
+		var b strings.Builder
+		for _, r := range word {
+			switch {
+			case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+				b.WriteRune(r)
+			case foldings[r] != "":
+				b.WriteString(foldings[r])
+			}
+		}
+		if b.Len() > 0 {
+			words = append(words, b.String())
+		}
+	}
+	return words
+}
+
+// truncate shortens s to at most n bytes without leaving a separator at either end
+func truncate(s string, n int, sep string) string {
+	if len(s) > n {
+		s = s[:n]
+	}
+	return strings.Trim(s, sep)
+}
+
+// Slug returns s as lower-case ASCII words joined by hyphens, for URLs and file names:
+// "Über Cool_API" gives uber-cool-api
+func Slug(s string) string {
+	if slug := strings.Join(asciiWords(s), "-"); slug != "" {
+		return slug
+	}
+	return fallbackIdentifier
+}
+
+// Alnum returns s as lower-case ASCII letters and digits only, for names that allow no
+// separators, such as storage accounts: "my-api v2" gives myapiv2
+func Alnum(s string) string {
+	if alnum := strings.Join(asciiWords(s), ""); alnum != "" {
+		return alnum
+	}
+	return fallbackIdentifier
+}
+
+// DNSLabel returns s as a DNS label (RFC 1123): lower-case ASCII letters, digits and hyphens,
+// starting and ending with a letter or digit, at most 63 characters. It fits bucket names,
+// Kubernetes namespaces and host names.
+func DNSLabel(s string) string {
+	if label := truncate(strings.Join(asciiWords(s), "-"), maxIdentifierLen, "-"); label != "" {
+		return label
+	}
+	return fallbackIdentifier
+}
+
+// DBIdentifier returns s as a database identifier usable without quoting: lower-case ASCII
+// letters, digits and underscores, not starting with a digit, at most 63 characters
+func DBIdentifier(s string) string {
+	ident := strings.Join(asciiWords(s), "_")
+	if ident == "" {
+		return fallbackIdentifier
+	}
+	if ident[0] >= '0' && ident[0] <= '9' {
+		ident = "_" + ident
+	}
+	if len(ident) > maxIdentifierLen {
+		ident = strings.TrimRight(ident[:maxIdentifierLen], "_")
+	}
+	return ident
+}
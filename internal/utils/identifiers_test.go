@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIdentifiers(t *testing.T) {
+	long := strings.Repeat("abcdefghij ", 8) // 80 characters, eight words
+	tests := []struct {
+		name                     string
+		in                       string
+		slug, alnum, dns, dbName string
+	}{
+		{"empty", "", "project", "project", "project", "project"},
+		{"only symbols", "!@#$%^&*()", "project", "project", "project", "project"},
+		{"only separators", " -_- ", "project", "project", "project", "project"},
+		{"plain", "my-api", "my-api", "myapi", "my-api", "my_api"},
+		{"spaces around", "  My API  ", "my-api", "myapi", "my-api", "my_api"},
+		{"repeated separators", "a--b__c  d", "a-b-c-d", "abcd", "a-b-c-d", "a_b_c_d"},
+		{"camel case", "HTTPServer v2", "http-server-v2", "httpserverv2", "http-server-v2", "http_server_v2"},
+		{"leading digits", "123 go", "123-go", "123go", "123-go", "_123_go"},
+		{"only digits", "2024", "2024", "2024", "2024", "_2024"},
+		{"diacritics", "Über Cool_API", "uber-cool-api", "ubercoolapi", "uber-cool-api", "uber_cool_api"},
+		{"ligatures", "Œuvre Straße", "oeuvre-strasse", "oeuvrestrasse", "oeuvre-strasse", "oeuvre_strasse"},
+		{"combining accent", "Cafe\u0301 Bar", "cafe-bar", "cafebar", "cafe-bar", "cafe_bar"},
+		{"cjk with latin", "東京 app", "app", "app", "app", "app"},
+		{"cjk only", "東京", "project", "project", "project", "project"},
+		{"cyrillic only", "Москва", "project", "project", "project", "project"},
+		{"emoji", "rocket 🚀 ship", "rocket-ship", "rocketship", "rocket-ship", "rocket_ship"},
+		{
+			"longer than 63 characters", long,
+			strings.TrimSuffix(strings.ReplaceAll(long, " ", "-"), "-"),
+			strings.ReplaceAll(long, " ", ""),
+			"abcdefghij-abcdefghij-abcdefghij-abcdefghij-abcdefghij-abcdefgh",
+			"abcdefghij_abcdefghij_abcdefghij_abcdefghij_abcdefghij_abcdefgh",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Slug(tt.in); got != tt.slug {
+				t.Errorf("Slug(%q) = %q, want %q", tt.in, got, tt.slug)
+			}
+			if got := Alnum(tt.in); got != tt.alnum {
+				t.Errorf("Alnum(%q) = %q, want %q", tt.in, got, tt.alnum)
+			}
+			if got := DNSLabel(tt.in); got != tt.dns {
+				t.Errorf("DNSLabel(%q) = %q, want %q", tt.in, got, tt.dns)
+			}
+			if got := DBIdentifier(tt.in); got != tt.dbName {
+				t.Errorf("DBIdentifier(%q) = %q, want %q", tt.in, got, tt.dbName)
+			}
+		})
+	}
+}
+
+func TestIdentifierLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"one long word", strings.Repeat("x", 100)},
+		{"separator at the limit", strings.Repeat("a", 62) + " " + strings.Repeat("b", 10)},
+		{"leading digit at the limit", "1" + strings.Repeat("a", 62) + " b"},
+		{"folded letters", strings.Repeat("ß", 40)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for fn, got := range map[string]string{"DNSLabel": DNSLabel(tt.in), "DBIdentifier": DBIdentifier(tt.in)} {
+				if len(got) > maxIdentifierLen {
+					t.Errorf("%s(%q) is %d characters, want at most %d", fn, tt.in, len(got), maxIdentifierLen)
+				}
+				if got == "" || strings.TrimRight(got, "-_") != got {
+					t.Errorf("%s(%q) = %q, which is empty or ends with a separator", fn, tt.in, got)
+				}
+			}
+			if got := DBIdentifier(tt.in); got[0] >= '0' && got[0] <= '9' {
+				t.Errorf("DBIdentifier(%q) = %q, which starts with a digit", tt.in, got)
+			}
+			if got := DNSLabel(tt.in); got[0] == '-' || strings.Trim(got, "abcdefghijklmnopqrstuvwxyz0123456789-") != "" {
+				t.Errorf("DNSLabel(%q) = %q, which starts with a hyphen or has characters outside [a-z0-9-]", tt.in, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// includePattern matches {{include "path"}}, with optional spaces inside the braces
+var includePattern = regexp.MustCompile(`\{\{\s*include\s+"([^"]+)"\s*\}\}`)
+
+// maxIncludeDepth bounds how deeply partials may include other partials
+const maxIncludeDepth = 10
+
+// ExpandIncludes replaces each {{include "path"}} in content, the text of the template file
+// name, with the file at path relative to the template root. A single trailing newline of the
+// included file is dropped so the directive can stand on its own line. Included files may
+// include others; they are expanded before rendering, so they can use placeholders like any
+// template file. Paths that leave the template root and include cycles are errors.
+func ExpandIncludes(root, name, content string) (string, error) {
+	return expandIncludes(root, name, content, []string{filepath.ToSlash(name)})
+}
+
+// expandIncludes expands the includes in content; stack holds the files being expanded
+func expandIncludes(root, name, content string, stack []string) (string, error) {
+	if !strings.Contains(content, "include") {
+		return content, nil
+	}
+	var firstErr error
+	expanded := includePattern.ReplaceAllStringFunc(content, func(match string) string {
+		if firstErr != nil {
+			return match
+		}
+		partial, err := readPartial(root, includePattern.FindStringSubmatch(match)[1], stack)
+		if err != nil {
+			firstErr = i18n.Errorf("%s: %w", name, err)
+			return match
+		}
+		return partial
+	})
+	return expanded, firstErr
+}
+
+// readPartial returns the expanded content of the partial at rel
+func readPartial(root, rel string, stack []string) (string, error) {
+	clean := filepath.ToSlash(filepath.Clean(filepath.FromSlash(rel)))
+	if filepath.IsAbs(rel) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", i18n.Errorf("cannot include %q: the path must stay inside the template", rel)
+	}
+	for _, s := range stack {
+		if s == clean {
+			return "", i18n.Errorf("cannot include %q: include cycle %s", rel, strings.Join(append(stack, clean), " → "))
+		}
+	}
+	if len(stack) > maxIncludeDepth {
+		return "", i18n.Errorf("cannot include %q: includes nested more than %d deep", rel, maxIncludeDepth)
+	}
+	data, err := fsys.ReadFile(filepath.Join(root, filepath.FromSlash(clean)))
+	if err != nil {
+		return "", i18n.Errorf("cannot include %q: %w", rel, err)
+	}
+	content := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+	return expandIncludes(root, clean, content, append(stack, clean))
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/kajvans/foundry/internal/i18n"
+)
+
+// Template rendering engines a template's foundry.yaml can choose
+const (
+	EnginePlaceholders = "placeholders" // plain {{NAME}} substitution, the default
+	EngineGo           = "go"           // Go text/template with expressions, conditionals and pipelines
+	EngineHandlebars   = "handlebars"   // Handlebars/Mustache, for templates written for other scaffolding tools
+)
+
+// EnvPrefix starts the names of the environment variables a template reads, as in
+// {{ENV.GITHUB_USER}}; the go engine nests them under .ENV
+const EnvPrefix = "ENV."
+
+// Engines lists the valid rendering engines
+var Engines = []string{EnginePlaceholders, EngineGo, EngineHandlebars}
+
+// engineAliases are the names other scaffolding tools give the engines
+var engineAliases = map[string]string{"simple": EnginePlaceholders, "gotemplate": EngineGo}
+
+// EngineName returns the engine an alias such as gotemplate stands for; other names are
+// returned as they are
+func EngineName(name string) string {
+	if engine, ok := engineAliases[name]; ok {
+		return engine
+	}
+	return name
+}
+
+// identPattern matches names that can be called as template functions
+var identPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// renderFuncs are the helpers available in Go-engine templates, besides CaseFuncs
+var renderFuncs = template.FuncMap{
+	"replace":   func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"contains":  func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix": func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix": func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"list":      SplitList,
+	"has": func(item string, list interface{}) bool {
+		items, ok := list.([]string)
+		if !ok {
+			items = SplitList(fmt.Sprint(list))
+		}
+		for _, i := range items {
+			if i == item {
+				return true
+			}
+		}
+		return false
+	},
+	"join": func(sep string, items []string) string { return strings.Join(items, sep) },
+	"default": func(def string, value interface{}) interface{} {
+		if s, ok := value.(string); ok && s == "" {
+			return def
+		}
+		return value
+	},
+}
+
+func init() {
+	for name, fn := range CaseFuncs {
+		renderFuncs[name] = fn
+	}
+}
+
+// RenderTemplate renders content as a Go text/template. The variables are fields of the data
+// ({{ .PROJECT_NAME }}, {{ if .DOCKER }}), with "true" and "false" as booleans and the variables
+// named in lists as []string ({{ range .FEATURES }}), and can still be written as {{PROJECT_NAME}},
+// so templates written for plain placeholders keep working. Environment variables are a map
+// ({{ .ENV.GITHUB_USER }}, or {{ENV.GITHUB_USER}}). A variable that is not defined is an
+// error rather than an empty string.
+func RenderTemplate(name, content, projectName, author string, extraVars map[string]string, lists map[string]bool) (string, error) {
+	values := ProjectNameVars(projectName)
+	values["AUTHOR"] = author
+	for k, v := range extraVars {
+		values[k] = v
+	}
+
+	data := make(map[string]interface{}, len(values))
+	funcs := template.FuncMap{}
+	for k, v := range renderFuncs {
+		funcs[k] = v
+	}
+	env := map[string]string{}
+	data["ENV"] = env
+	funcs["ENV"] = func() map[string]string { return env }
+	for k, v := range values {
+		if name, ok := strings.CutPrefix(k, EnvPrefix); ok {
+			env[name] = v
+			continue
+		}
+		var value interface{} = v
+		switch {
+		case lists[k]:
+			items := SplitList(v)
+			if items == nil {
+				items = []string{}
+			}
+			value = items
+		case v == "true":
+			value = true
+		case v == "false":
+			value = false
+		}
+		data[k] = value
+		if _, taken := renderFuncs[k]; !taken && identPattern.MatchString(k) {
+			// {{FEATURES}} reads as in the placeholders engine, so lists stay comma-separated
+			plain := value
+			if lists[k] {
+				plain = v
+			}
+			funcs[k] = func() interface{} { return plain }
+		}
+	}
+
+	t, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return "", i18n.Errorf("cannot parse %s: %w", name, err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", i18n.Errorf("cannot render %s: %w", name, err)
+	}
+	return b.String(), nil
+}
@@ -2,10 +2,22 @@ package utils
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/kajvans/foundry/internal/fsys"
+	"github.com/kajvans/foundry/internal/i18n"
+	"gopkg.in/yaml.v3"
 )
 
 // Min returns the smaller of two ints
@@ -16,32 +28,42 @@ func Min(a, b int) int {
 	return b
 }
 
-// CapitalizeFirst returns the string with the first letter capitalized
-func CapitalizeFirst(s string) string {
-	if len(s) == 0 {
-		return s
+// SortedKeys returns the keys of m in sorted order, so output built from a map is the same on every run
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
-	return strings.ToUpper(s[:1]) + s[1:]
+	sort.Strings(keys)
+	return keys
 }
 
-// IsBinary reports whether data likely represents a binary file
-func IsBinary(data []byte, maxCheckBytes int) bool {
-	checkSize := Min(len(data), maxCheckBytes)
-	for i := 0; i < checkSize; i++ {
-		if data[i] == 0 {
-			return true
-		}
+// CapitalizeFirst returns the string with the first letter capitalized, also when it is
+// outside ASCII: élan gives Élan
+func CapitalizeFirst(s string) string {
+	return capitalize(s)
+}
+
+// Digest returns the hex SHA-256 of data
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// FileDigest returns the hex SHA-256 of the file at path
+func FileDigest(path string) (string, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return "", err
 	}
-	return false
+	return Digest(data), nil
 }
 
 // ReplacePlaceholders replaces all placeholders in content
 func ReplacePlaceholders(content, projectName, author string, extraVars map[string]string) string {
-	replacements := map[string]string{
-		"{{PROJECT_NAME}}":       projectName,
-		"{{AUTHOR}}":             author,
-		"{{PROJECT_NAME_LOWER}}": strings.ToLower(projectName),
-		"{{PROJECT_NAME_UPPER}}": strings.ToUpper(projectName),
+	replacements := map[string]string{"{{AUTHOR}}": author}
+	for k, v := range ProjectNameVars(projectName) {
+		replacements["{{"+k+"}}"] = v
 	}
 	for k, v := range extraVars {
 		replacements["{{"+k+"}}"] = v
@@ -59,21 +81,209 @@ func ParseVars(kvs []string) (map[string]string, error) {
 	for _, kv := range kvs {
 		parts := strings.SplitN(kv, "=", 2)
 		if len(parts) != 2 {
-			return nil, errors.New("invalid var format '" + kv + "', expected key=value")
+			return nil, i18n.Errorf("invalid var format '%s', expected key=value", kv)
 		}
 		key := strings.TrimSpace(parts[0])
 		if key == "" {
-			return nil, errors.New("variable key cannot be empty")
+			return nil, errors.New(i18n.T("variable key cannot be empty"))
 		}
 		result[key] = parts[1]
 	}
 	return result, nil
 }
 
+// ParseVarsJSON reads variables from a JSON object such as {"PORT": 8080, "FEATURES": ["auth",
+// "metrics"]}. Numbers and booleans are written as in JSON, arrays of them become comma-separated
+// lists and null an empty value; nested objects are an error.
+func ParseVarsJSON(r io.Reader) (map[string]string, error) {
+	var raw map[string]interface{}
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, i18n.Errorf("expected a JSON object of variables: %w", err)
+	}
+	result := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if strings.TrimSpace(key) == "" {
+			return nil, errors.New(i18n.T("variable key cannot be empty"))
+		}
+		if items, ok := value.([]interface{}); ok {
+			values := make([]string, 0, len(items))
+			for _, item := range items {
+				s, err := jsonScalar(key, item)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, s)
+			}
+			result[key] = strings.Join(values, ",")
+			continue
+		}
+		s, err := jsonScalar(key, value)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = s
+	}
+	return result, nil
+}
+
+// jsonScalar returns a decoded JSON string, number, boolean or null as a variable value
+func jsonScalar(key string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number, bool:
+		return fmt.Sprint(v), nil
+	}
+	return "", i18n.Errorf("variable '%s': expected a string, number, boolean or list", key)
+}
+
+// ParseVarsFile reads variables from an answers file: a JSON object when the file ends in .json,
+// a YAML mapping otherwise. YAML values are taken as written, so 1.10 stays 1.10, lists become
+// comma-separated and null or ~ an empty value.
+func ParseVarsFile(path string) (map[string]string, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ParseVarsJSON(bytes.NewReader(data))
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, i18n.Errorf("expected a YAML mapping of variables: %w", err)
+	}
+	result := map[string]string{}
+	if len(doc.Content) == 0 {
+		return result, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New(i18n.T("expected a YAML mapping of variables"))
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+		if strings.TrimSpace(key) == "" {
+			return nil, errors.New(i18n.T("variable key cannot be empty"))
+		}
+		if value.Kind == yaml.SequenceNode {
+			values := make([]string, 0, len(value.Content))
+			for _, item := range value.Content {
+				s, err := yamlScalar(key, item)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, s)
+			}
+			result[key] = strings.Join(values, ",")
+			continue
+		}
+		s, err := yamlScalar(key, value)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = s
+	}
+	return result, nil
+}
+
+// yamlScalar returns a YAML scalar as written, or an empty value for null
+func yamlScalar(key string, node *yaml.Node) (string, error) {
+	if node.Kind != yaml.ScalarNode {
+		return "", i18n.Errorf("variable '%s': expected a string, number, boolean or list", key)
+	}
+	if node.Tag == "!!null" {
+		return "", nil
+	}
+	return node.Value, nil
+}
+
+// SplitList splits a comma-separated list variable into its values, trimming spaces
+// around them and dropping empty ones: "auth, metrics," gives [auth metrics]
+func SplitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// SplitArgs splits a command line into words the way a POSIX shell does: words are separated
+// by whitespace, single quotes keep everything literally, and inside double quotes or outside
+// quotes a backslash escapes the next character
+func SplitArgs(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inWord = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, i18n.Errorf("unterminated quote or escape in %q", line)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// CheckWritableDir verifies that dir exists, is a directory, and accepts new files
+func CheckWritableDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return i18n.Errorf("%s is not a directory", dir)
+	}
+	f, err := os.CreateTemp(dir, ".foundry-write-check-*")
+	if err != nil {
+		return i18n.Errorf("%s is not writable", dir)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
 // LoadIgnorePatterns reads ignore patterns from a file
 func LoadIgnorePatterns(root, filename string) []string {
 	ignorePath := filepath.Join(root, filename)
-	f, err := os.Open(ignorePath)
+	f, err := fsys.Open(ignorePath)
 	if err != nil {
 		return nil
 	}
@@ -105,3 +315,79 @@ func MatchIgnore(relPath string, patterns []string) bool {
 	}
 	return false
 }
+
+// MatchGlob reports whether a slash-separated relative path matches pattern.
+// "**" matches any number of directories; a pattern without "/" is matched against the base name.
+func MatchGlob(pattern, relPath string) bool {
+	pattern = filepath.ToSlash(pattern)
+	relPath = filepath.ToSlash(relPath)
+	if !strings.Contains(pattern, "/") {
+		matched, _ := filepath.Match(pattern, filepath.Base(relPath))
+		return matched
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+}
+
+// matchSegments matches path segments against pattern segments, expanding "**"
+func matchSegments(pattern, path []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(path); i++ {
+				if matchSegments(pattern[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(path) == 0 {
+			return false
+		}
+		if matched, _ := filepath.Match(pattern[0], path[0]); !matched {
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0
+}
+
+// CompareVersions compares two dotted versions (e.g. "v1.2.3") and returns -1, 0 or 1.
+// A leading "v" and any pre-release or build suffix are ignored; missing parts count as zero.
+func CompareVersions(a, b string) (int, error) {
+	pa, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	pb, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < len(pa); i++ {
+		if pa[i] < pb[i] {
+			return -1, nil
+		}
+		if pa[i] > pb[i] {
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// parseVersion splits a version string into major, minor and patch numbers
+func parseVersion(v string) ([3]int, error) {
+	var parts [3]int
+	clean := strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(clean, "-+"); i >= 0 {
+		clean = clean[:i]
+	}
+	if clean == "" {
+		return parts, i18n.Errorf("invalid version '%s'", v)
+	}
+	for i, field := range strings.SplitN(clean, ".", 3) {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, i18n.Errorf("invalid version '%s'", v)
+		}
+		parts[i] = n
+	}
+	return parts, nil
+}